@@ -3,6 +3,8 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/knadh/koanf/parsers/yaml"
@@ -11,6 +13,7 @@ import (
 	"github.com/knadh/koanf/providers/posflag"
 	"github.com/knadh/koanf/v2"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/yacobolo/cssgen/internal/cssgen"
 )
 
@@ -20,7 +23,11 @@ var k = koanf.New(".")
 // whether a flag was explicitly set on the command line.
 var activeCmd *cobra.Command
 
-// loadConfig loads configuration with precedence: flags > env > file > defaults.
+// moduleRoot is the directory holding the nearest go.mod above the working
+// directory ("" if none); it drives the default lint scan paths
+var moduleRoot string
+
+// loadConfig loads configuration with precedence: flags > preset > env > file > defaults.
 // It must be called after cobra parses flags (in PreRunE or RunE).
 func loadConfig(cmd *cobra.Command) error {
 	activeCmd = cmd
@@ -31,22 +38,88 @@ func loadConfig(cmd *cobra.Command) error {
 		configPath = ".cssgen.yaml"
 	}
 
+	if cwd, err := os.Getwd(); err == nil {
+		moduleRoot = findModuleRoot(cwd)
+	}
+
+	// go generate runs cssgen in the package directory; without a config there,
+	// run from the module root so its .cssgen.yaml and relative paths apply
+	if os.Getenv("GOPACKAGE") != "" && !cmd.Flags().Changed("config") && moduleRoot != "" {
+		if _, err := os.Stat(configPath); err != nil {
+			if _, err := os.Stat(filepath.Join(moduleRoot, configPath)); err == nil {
+				if err := os.Chdir(moduleRoot); err != nil {
+					return withExitCode(exitIO, fmt.Errorf("changing to module root: %w", err))
+				}
+			}
+		}
+	}
+
 	// Load config file and env vars
 	if err := loadConfigFromPath(configPath); err != nil {
-		return err
+		return withExitCode(exitUsage, err)
+	}
+
+	// Preset bundles override the config file and env; explicit flags still win
+	preset, _ := cmd.Flags().GetString("preset")
+	if !cmd.Flags().Changed("preset") {
+		preset = k.String("preset")
+	}
+	if err := applyPreset(preset); err != nil {
+		return withExitCode(exitUsage, err)
 	}
 
 	// 3. CLI flags (highest precedence — only flags that were explicitly set)
 	// Merge flags from the specific command and its parent (root) flags.
 	// The koanf instance (k) is passed so posflag can skip flags whose
 	// keys already exist from the config file / env providers.
-	if err := k.Load(posflag.Provider(cmd.Flags(), ".", k), nil); err != nil {
-		return fmt.Errorf("loading command flags: %w", err)
+	// generate --lint is read from the flag set: as a key it would replace the lint: section
+	flags := cmd.Flags()
+	if err := k.Load(posflag.ProviderWithFlag(flags, ".", k, func(f *pflag.Flag) (string, interface{}) {
+		if f.Name == "lint" {
+			return "", nil
+		}
+		return f.Name, posflag.FlagVal(flags, f)
+	}), nil); err != nil {
+		return withExitCode(exitUsage, fmt.Errorf("loading command flags: %w", err))
 	}
 
 	return nil
 }
 
+// presets are named config bundles selected with --preset (or preset: in the config file)
+var presets = map[string]map[string]interface{}{
+	// ci gates on every error and warning from lint and generate, with source
+	// lines and a bounded report
+	"ci": {
+		"generate.fail-on-warning":   true,
+		"lint.strict":                true,
+		"lint.print-lines":           true,
+		"lint.max-issues-per-linter": 50,
+	},
+}
+
+// applyPreset sets the keys of the named preset ("" applies nothing)
+func applyPreset(name string) error {
+	if name == "" {
+		return nil
+	}
+	values, ok := presets[name]
+	if !ok {
+		known := make([]string, 0, len(presets))
+		for preset := range presets {
+			known = append(known, preset)
+		}
+		sort.Strings(known)
+		return fmt.Errorf("unknown preset %q (known: %s)", name, strings.Join(known, ", "))
+	}
+	for key, value := range values {
+		if err := k.Set(key, value); err != nil {
+			return fmt.Errorf("applying preset %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // loadConfigFromPath loads configuration from a file and environment variables.
 // This is separated from loadConfig to allow testing without a cobra command.
 func loadConfigFromPath(configPath string) error {
@@ -82,9 +155,54 @@ func buildGenerateConfig() cssgen.Config {
 		Verbose:            getBoolWithFallback("verbose", "verbose", false),
 		Format:             getStringWithFallback("format", "generate.format", "markdown"),
 		PropertyLimit:      getIntWithFallback("property-limit", "generate.property-limit", 5),
+		CommentWidth:       getIntWithFallback("max-line-width", "generate.max-line-width", 120),
+		WrapComments:       getBoolWithFallback("wrap-comments", "generate.wrap-comments", false),
 		ShowInternal:       getBoolWithFallback("show-internal", "generate.show-internal", false),
+		UnprefixVendor:     getBoolWithFallback("unprefix-vendor", "generate.unprefix-vendor", false),
 		ExtractIntent:      getBoolWithFallback("extract-intent", "generate.extract-intent", true),
+		InheritIntent:      getBoolWithFallback("inherit-intent", "generate.inherit-intent", false),
 		LayerInferFromPath: getBoolWithFallback("infer-layer", "generate.infer-layer", true),
+		DefaultLayer:       getStringWithFallback("default-layer", "generate.default-layer", "base"),
+		ExtractIDs:         getBoolWithFallback("extract-ids", "generate.extract-ids", false),
+		ManifestFile:       getStringWithFallback("emit-manifest", "generate.emit-manifest", ""),
+		DocsFile:           getStringWithFallback("emit-docs", "generate.emit-docs", ""),
+		IntentsFile:        getStringWithFallback("emit-intents", "generate.emit-intents", ""),
+		EmitTypedGroups:    getBoolWithFallback("typed-groups", "generate.typed-groups", false),
+		UsageFile:          getStringWithFallback("usage-file", "generate.usage-file", ""),
+		MinUsage:           getIntWithFallback("min-usage", "generate.min-usage", 1),
+		LineEnding:         getStringWithFallback("line-ending", "generate.line-ending", "lf"),
+		ModifierSeparator:  getStringWithFallback("modifier-separator", "generate.modifier-separator", "--"),
+		ElementSeparator:   getStringWithFallback("element-separator", "generate.element-separator", "__"),
+
+		DetectLayerOverrides: getBoolWithFallback("layer-overrides", "generate.layer-overrides", false),
+		HonorSourceComments:  getBoolWithFallback("honor-source-comments", "generate.honor-source-comments", false),
+		FailOnWarning:        getBoolWithFallback("fail-on-warning", "generate.fail-on-warning", false),
+	}
+
+	// Handle build tags: check flag key first, then config key
+	if tags := k.Strings("build-tags"); len(tags) > 0 {
+		config.BuildTags = tags
+	} else if tags := k.Strings("generate.build-tags"); len(tags) > 0 {
+		config.BuildTags = tags
+	}
+
+	// Handle internal prefixes: check flag key first, then config key (nil = built-in list)
+	if prefixes := k.Strings("internal-prefixes"); len(prefixes) > 0 {
+		config.InternalPrefixes = prefixes
+	} else if prefixes := k.Strings("generate.internal-prefixes"); len(prefixes) > 0 {
+		config.InternalPrefixes = prefixes
+	}
+
+	// Category overrides are config-file only (property: category)
+	if overrides := k.StringMap("generate.category-overrides"); len(overrides) > 0 {
+		config.CategoryOverrides = make(map[string]cssgen.PropertyCategory, len(overrides))
+		for prop, name := range overrides {
+			cat, ok := cssgen.ParsePropertyCategory(name)
+			if !ok {
+				cat = cssgen.PropertyCategory(name) // Rejected by Generate with a clear error
+			}
+			config.CategoryOverrides[prop] = cat
+		}
 	}
 
 	// Handle includes: check flag key first, then config key
@@ -106,11 +224,18 @@ func buildGenerateConfig() cssgen.Config {
 // buildLintConfig constructs the library's LintConfig struct from koanf state.
 func buildLintConfig(generatedFile string) cssgen.LintConfig {
 	// Handle paths: check flag key first, then config key
+	pathsFrom := getStringWithFallback("paths-from", "lint.paths-from", "")
+
 	var scanPaths []string
 	if paths := k.Strings("paths"); len(paths) > 0 {
 		scanPaths = paths
 	} else if paths := k.Strings("lint.paths"); len(paths) > 0 {
 		scanPaths = paths
+	} else if pathsFrom != "" {
+		// A path list replaces the default patterns unless paths are configured explicitly
+		scanPaths = nil
+	} else if cwd, err := os.Getwd(); err == nil && moduleRoot != "" {
+		scanPaths = moduleScanPaths(moduleRoot, cwd)
 	} else {
 		scanPaths = []string{
 			"internal/web/features/**/*.templ",
@@ -118,10 +243,65 @@ func buildLintConfig(generatedFile string) cssgen.LintConfig {
 		}
 	}
 
+	// Usage paths: flag key first, then config key (nil = none)
+	usagePaths := k.Strings("usage-paths")
+	if len(usagePaths) == 0 {
+		usagePaths = k.Strings("lint.usage-paths")
+	}
+
+	// Exclude patterns: flag key first, then config key (nil = none)
+	exclude := k.Strings("exclude")
+	if len(exclude) == 0 {
+		exclude = k.Strings("lint.exclude")
+	}
+
+	// Attributes: flag key first, then config key (nil = scanner default "class")
+	attributes := k.Strings("attributes")
+	if len(attributes) == 0 {
+		attributes = k.Strings("lint.attributes")
+	}
+
+	// YAML/JSON class keys are opt-in
+	var dataKeys []string
+	if getBoolWithFallback("scan-data", "lint.scan-data", false) {
+		dataKeys = k.Strings("data-keys")
+		if len(dataKeys) == 0 {
+			dataKeys = k.Strings("lint.data-keys")
+		}
+		if len(dataKeys) == 0 {
+			dataKeys = []string{"class"}
+		}
+	}
+
+	// Class toggle expressions (:class="{ 'btn': on }") are opt-in
+	toggles := k.Strings("toggle-attributes")
+	if len(toggles) == 0 {
+		toggles = k.Strings("lint.toggle-attributes")
+	}
+
+	// Rule filter: flag key first, then config key (nil = all rules)
+	only := k.Strings("only")
+	if len(only) == 0 {
+		only = k.Strings("lint.only")
+	}
+
+	pkg := getStringWithFallback("package", "package", "ui")
+
 	return cssgen.LintConfig{
 		GeneratedFile:      generatedFile,
-		PackageName:        getStringWithFallback("package", "package", "ui"),
+		PackageName:        pkg,
+		PackageAlias:       getStringWithFallback("package-alias", "lint.package-alias", pkg),
+		ImportPath:         getStringWithFallback("import-path", "lint.import-path", ""),
+		DefaultLayer:       getStringWithFallback("default-layer", "generate.default-layer", "base"),
 		ScanPaths:          scanPaths,
+		PathsFrom:          pathsFrom,
+		UsagePaths:         usagePaths,
+		Exclude:            exclude,
+		Attributes:         attributes,
+		StructTags:         getBoolWithFallback("struct-tags", "lint.struct-tags", false),
+		DataKeys:           dataKeys,
+		ToggleAttributes:   toggles,
+		Only:               only,
 		Verbose:            getBoolWithFallback("verbose", "verbose", false),
 		Strict:             getBoolWithFallback("strict", "lint.strict", false),
 		Threshold:          getFloat64WithFallback("threshold", "lint.threshold", 0.0),
@@ -131,7 +311,93 @@ func buildLintConfig(generatedFile string) cssgen.LintConfig {
 		PrintIssuedLines:   getBoolWithFallback("print-lines", "lint.print-lines", true),
 		PrintLinterName:    getBoolWithFallback("print-linter-name", "lint.print-linter-name", true),
 		UseColors:          getBoolWithFallback("color", "color", false),
+		NoColor:            getBoolWithFallback("no-color", "no-color", false),
+		ProgressThreshold:  getIntWithFallback("progress-threshold", "lint.progress-threshold", 50),
+		GroupBy:            cssgen.GroupBy(getStringWithFallback("group-by", "lint.group-by", "")),
+		PathFilter:         getStringWithFallback("path-filter", "lint.path-filter", ""),
+		MaxFiles:           getIntWithFallback("max-files", "lint.max-files", 0),
+
+		QuickWinMinOccurrences: getIntWithFallback("quick-win-min-occurrences", "lint.quick-win-min-occurrences", 1),
+		ComponentScope:         getBoolWithFallback("component-scope", "lint.component-scope", false),
+		SuggestLimit:           getIntWithFallback("suggest-limit", "lint.suggest-limit", 1),
+		UnusedWarnThreshold:    getIntWithFallback("unused-warn-threshold", "lint.unused-warn-threshold", 50),
+		LowAdoptionThreshold:   getFloat64WithFallback("low-adoption-threshold", "lint.low-adoption-threshold", 20),
+
+		ErrorOnUnused: getBoolWithFallback("error-on-unused", "lint.error-on-unused", false),
+		CheckA11y:     getBoolWithFallback("check-a11y", "lint.check-a11y", false),
+		DebugScan:     getBoolWithFallback("debug-scan", "lint.debug-scan", false),
+		BaselineFile:  getStringWithFallback("baseline", "lint.baseline", ""),
+		NewOnly:       getBoolWithFallback("new-only", "lint.new-only", false),
+
+		// Per-layer thresholds are config-file only (layer: percentage)
+		LayerThresholds: k.Float64Map("lint.thresholds"),
+
+		// Dynamic patterns are config-file only (regexes may contain commas)
+		DynamicPatterns: k.Strings("lint.dynamic-patterns"),
+
+		// Custom rules are config-file only (list of maps)
+		CustomRules: customRules(),
+	}
+}
+
+// customRules reads lint.custom-rules entries of name, class-pattern, message and severity
+func customRules() []cssgen.CustomRule {
+	var rules []cssgen.CustomRule
+	for _, entry := range k.Slices("lint.custom-rules") {
+		rules = append(rules, cssgen.CustomRule{
+			Name:         entry.String("name"),
+			ClassPattern: entry.String("class-pattern"),
+			Message:      entry.String("message"),
+			Severity:     entry.String("severity"),
+		})
+	}
+	return rules
+}
+
+// forceColors reports whether --color is set and not overridden by --no-color
+func forceColors() bool {
+	return getBoolWithFallback("color", "color", false) && !getBoolWithFallback("no-color", "no-color", false)
+}
+
+// findModuleRoot walks up from dir to the nearest directory containing go.mod ("" if none)
+func findModuleRoot(dir string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// moduleScanPaths returns **/*.templ and **/*.go patterns covering the module at root,
+// relative to cwd where possible. vendor and hidden directories are left out.
+func moduleScanPaths(root, cwd string) []string {
+	base := root
+	if rel, err := filepath.Rel(cwd, root); err == nil {
+		base = rel
+	}
+
+	pattern := func(parts ...string) string {
+		return filepath.ToSlash(filepath.Join(append([]string{base}, parts...)...))
+	}
+
+	paths := []string{pattern("*.templ"), pattern("*.go")}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return paths
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == "vendor" || strings.HasPrefix(name, ".") {
+			continue
+		}
+		paths = append(paths, pattern(name, "**", "*.templ"), pattern(name, "**", "*.go"))
 	}
+	return paths
 }
 
 // flagChanged reports whether the given flag was explicitly set on the command line.
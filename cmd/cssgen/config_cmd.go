@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Print the resolved configuration",
+	Long: `Print the effective generate and lint settings as YAML after applying
+flags > preset > environment (CSSGEN_*) > config file > defaults.
+Accepts the generate and lint flags; a flag both share (--output-format)
+sets both, as it does for generate --lint.`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		return printResolvedConfig(cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	addResolvedConfigFlags(configCmd.Flags())
+}
+
+// addResolvedConfigFlags registers the generate and lint flags on a command that
+// resolves the whole config, so overrides reach it as they reach those commands
+func addResolvedConfigFlags(f *pflag.FlagSet) {
+	addGenerateFlags(f)
+	// Lint flags named like generate ones (output-dir, output-format, out) are skipped
+	lintFlags := pflag.NewFlagSet("lint", pflag.ContinueOnError)
+	addLintFlags(lintFlags)
+	f.AddFlagSet(lintFlags)
+}
+
+// printResolvedConfig writes the effective configuration in .cssgen.yaml layout
+func printResolvedConfig(w io.Writer) error {
+	gen := buildGenerateConfig()
+	lint := buildLintConfig(filepath.Join(gen.OutputDir, "styles.gen.go"))
+
+	overrides := make(map[string]interface{}, len(gen.CategoryOverrides))
+	for prop, cat := range gen.CategoryOverrides {
+		overrides[prop] = string(cat)
+	}
+
+	customRules := make([]interface{}, 0, len(lint.CustomRules))
+	for _, rule := range lint.CustomRules {
+		customRules = append(customRules, map[string]interface{}{
+			"name":          rule.Name,
+			"class-pattern": rule.ClassPattern,
+			"message":       rule.Message,
+			"severity":      rule.Severity,
+		})
+	}
+
+	resolved := map[string]interface{}{
+		"package":  gen.PackageName,
+		"verbose":  gen.Verbose,
+		"color":    lint.UseColors,
+		"no-color": lint.NoColor,
+		"preset":   getStringWithFallback("preset", "preset", ""),
+		"generate": map[string]interface{}{
+			"source":                gen.SourceDir,
+			"output-dir":            gen.OutputDir,
+			"include":               gen.Includes,
+			"format":                gen.Format,
+			"property-limit":        gen.PropertyLimit,
+			"max-line-width":        gen.CommentWidth,
+			"wrap-comments":         gen.WrapComments,
+			"show-internal":         gen.ShowInternal,
+			"internal-prefixes":     gen.InternalPrefixes,
+			"unprefix-vendor":       gen.UnprefixVendor,
+			"extract-intent":        gen.ExtractIntent,
+			"inherit-intent":        gen.InheritIntent,
+			"infer-layer":           gen.LayerInferFromPath,
+			"default-layer":         gen.DefaultLayer,
+			"extract-ids":           gen.ExtractIDs,
+			"build-tags":            gen.BuildTags,
+			"category-overrides":    overrides,
+			"layer-overrides":       gen.DetectLayerOverrides,
+			"fail-on-warning":       gen.FailOnWarning,
+			"emit-manifest":         gen.ManifestFile,
+			"emit-docs":             gen.DocsFile,
+			"emit-intents":          gen.IntentsFile,
+			"typed-groups":          gen.EmitTypedGroups,
+			"usage-file":            gen.UsageFile,
+			"min-usage":             gen.MinUsage,
+			"line-ending":           gen.LineEnding,
+			"modifier-separator":    gen.ModifierSeparator,
+			"element-separator":     gen.ElementSeparator,
+			"honor-source-comments": gen.HonorSourceComments,
+			"output-format":         getStringWithFallback("output-format", "generate.output-format", "text"),
+		},
+		"lint": map[string]interface{}{
+			"paths":                     lint.ScanPaths,
+			"exclude":                   lint.Exclude,
+			"paths-from":                lint.PathsFrom,
+			"usage-paths":               lint.UsagePaths,
+			"attributes":                lint.Attributes,
+			"struct-tags":               lint.StructTags,
+			"scan-data":                 len(lint.DataKeys) > 0,
+			"data-keys":                 lint.DataKeys,
+			"toggle-attributes":         lint.ToggleAttributes,
+			"generated-file":            lint.GeneratedFile,
+			"package-alias":             lint.PackageAlias,
+			"import-path":               lint.ImportPath,
+			"strict":                    lint.Strict,
+			"threshold":                 lint.Threshold,
+			"thresholds":                lint.LayerThresholds,
+			"dynamic-patterns":          lint.DynamicPatterns,
+			"custom-rules":              customRules,
+			"only":                      lint.Only,
+			"output-format":             getStringWithFallback("output-format", "lint.output-format", ""),
+			"max-issues-per-linter":     lint.MaxIssuesPerLinter,
+			"max-same-issues":           lint.MaxSameIssues,
+			"print-lines":               lint.PrintIssuedLines,
+			"print-linter-name":         lint.PrintLinterName,
+			"progress-threshold":        lint.ProgressThreshold,
+			"group-by":                  string(lint.GroupBy),
+			"path-filter":               lint.PathFilter,
+			"max-files":                 lint.MaxFiles,
+			"quick-win-min-occurrences": lint.QuickWinMinOccurrences,
+			"component-scope":           lint.ComponentScope,
+			"suggest-limit":             lint.SuggestLimit,
+			"unused-warn-threshold":     lint.UnusedWarnThreshold,
+			"low-adoption-threshold":    lint.LowAdoptionThreshold,
+			"error-on-unused":           lint.ErrorOnUnused,
+			"check-a11y":                lint.CheckA11y,
+			"debug-scan":                lint.DebugScan,
+			"baseline":                  lint.BaselineFile,
+			"new-only":                  lint.NewOnly,
+			"staged":                    getBoolWithFallback("staged", "lint.staged", false),
+			"out":                       getStringWithFallback("out", "lint.out", ""),
+			"export-fixes":              getStringWithFallback("export-fixes", "lint.export-fixes", ""),
+			"fix":                       getBoolWithFallback("fix", "lint.fix", false),
+			"fix-unsafe":                getBoolWithFallback("fix-unsafe", "lint.fix-unsafe", false),
+			"export-unused-patch":       getStringWithFallback("export-unused-patch", "lint.export-unused-patch", ""),
+			"prune-unused":              getBoolWithFallback("prune-unused", "lint.prune-unused", false),
+			"append-history":            getStringWithFallback("append-history", "lint.append-history", ""),
+		},
+	}
+
+	out, err := yaml.Parser().Marshal(resolved)
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -8,11 +9,13 @@ import (
 	"github.com/knadh/koanf/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/yacobolo/cssgen/internal/cssgen"
 )
 
 // resetKoanf creates a fresh koanf instance for each test.
 func resetKoanf() {
 	k = koanf.New(".")
+	moduleRoot = ""
 }
 
 func TestConfigFileLoading(t *testing.T) {
@@ -123,6 +126,39 @@ func TestBuildLintConfig_Defaults(t *testing.T) {
 	}, config.ScanPaths)
 }
 
+func TestBuildLintConfig_ModuleDefaults(t *testing.T) {
+	resetKoanf()
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/app\n"), 0644))
+	for _, dir := range []string{"internal/web", "cmd/app", "vendor/github.com/x", ".git"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0755))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(root, "main.go"), []byte("package main\n"), 0644))
+
+	nested := filepath.Join(root, "internal", "web")
+	assert.Equal(t, root, findModuleRoot(nested))
+	assert.Equal(t, "", findModuleRoot(filepath.Dir(root)))
+
+	expected := []string{
+		"*.templ", "*.go",
+		"cmd/**/*.templ", "cmd/**/*.go",
+		"internal/**/*.templ", "internal/**/*.go",
+	}
+	assert.Equal(t, expected, moduleScanPaths(root, root))
+	assert.Equal(t, "../../cmd/**/*.go", moduleScanPaths(root, nested)[3])
+
+	origDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(root))
+	t.Cleanup(func() {
+		_ = os.Chdir(origDir)
+		moduleRoot = ""
+	})
+	moduleRoot = root
+	assert.Equal(t, expected, buildLintConfig("/test/styles.gen.go").ScanPaths)
+}
+
 func TestBuildGenerateConfig_FromConfigFile(t *testing.T) {
 	resetKoanf()
 
@@ -175,64 +211,6 @@ lint:
 	assert.False(t, config.PrintIssuedLines)
 }
 
-func TestInitCommand_CreatesConfigFile(t *testing.T) {
-	dir := t.TempDir()
-	origDir, _ := os.Getwd()
-	require.NoError(t, os.Chdir(dir))
-	t.Cleanup(func() {
-		_ = os.Chdir(origDir)
-	})
-
-	cmd := rootCmd
-	cmd.SetArgs([]string{"init"})
-	require.NoError(t, cmd.Execute())
-
-	// Verify file was created
-	data, err := os.ReadFile(".cssgen.yaml")
-	require.NoError(t, err)
-	assert.Contains(t, string(data), "package: ui")
-	assert.Contains(t, string(data), "generate:")
-	assert.Contains(t, string(data), "lint:")
-}
-
-func TestInitCommand_RefusesOverwrite(t *testing.T) {
-	dir := t.TempDir()
-	origDir, _ := os.Getwd()
-	require.NoError(t, os.Chdir(dir))
-	t.Cleanup(func() {
-		_ = os.Chdir(origDir)
-	})
-
-	// Create existing file
-	require.NoError(t, os.WriteFile(".cssgen.yaml", []byte("existing"), 0644))
-
-	cmd := rootCmd
-	cmd.SetArgs([]string{"init"})
-	err := cmd.Execute()
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "already exists")
-}
-
-func TestInitCommand_ForceOverwrite(t *testing.T) {
-	dir := t.TempDir()
-	origDir, _ := os.Getwd()
-	require.NoError(t, os.Chdir(dir))
-	t.Cleanup(func() {
-		_ = os.Chdir(origDir)
-	})
-
-	// Create existing file
-	require.NoError(t, os.WriteFile(".cssgen.yaml", []byte("existing"), 0644))
-
-	cmd := rootCmd
-	cmd.SetArgs([]string{"init", "--force"})
-	require.NoError(t, cmd.Execute())
-
-	data, err := os.ReadFile(".cssgen.yaml")
-	require.NoError(t, err)
-	assert.Contains(t, string(data), "package: ui")
-}
-
 func TestVersionCommand(t *testing.T) {
 	cmd := rootCmd
 	cmd.SetArgs([]string{"version"})
@@ -267,3 +245,103 @@ func TestGetFloat64WithFallback(t *testing.T) {
 	// No keys set - should return default
 	assert.InDelta(t, 3.14, getFloat64WithFallback("flag-key", "config.key", 3.14), 0.01)
 }
+
+func TestCategoryOverridesFromConfig(t *testing.T) {
+	resetKoanf()
+
+	configPath := filepath.Join(t.TempDir(), ".cssgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+generate:
+  category-overrides:
+    scroll-snap-type: layout
+    accent-color: Typography
+`), 0644))
+	require.NoError(t, loadConfigFromPath(configPath))
+
+	config := buildGenerateConfig()
+	assert.Equal(t, cssgen.CategoryLayout, config.CategoryOverrides["scroll-snap-type"])
+	assert.Equal(t, cssgen.CategoryTypography, config.CategoryOverrides["accent-color"])
+}
+
+func TestPrintResolvedConfigReflectsEnv(t *testing.T) {
+	resetKoanf()
+
+	configPath := filepath.Join(t.TempDir(), ".cssgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("generate:\n  source: from-file\n"), 0644))
+	t.Setenv("CSSGEN_GENERATE_SOURCE", "from-env")
+	t.Setenv("CSSGEN_LINT_STRICT", "true")
+
+	require.NoError(t, loadConfigFromPath(configPath))
+
+	var buf bytes.Buffer
+	require.NoError(t, printResolvedConfig(&buf))
+
+	out := buf.String()
+	assert.Contains(t, out, "source: from-env")
+	assert.NotContains(t, out, "from-file")
+	assert.Contains(t, out, "strict: true")
+	assert.Contains(t, out, "generated-file: internal/web/ui/styles.gen.go")
+}
+
+func TestPresetCI(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, ".cssgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("lint:\n  strict: false\n  max-issues-per-linter: 5\n"), 0644))
+
+	lintConfig := func(args ...string) cssgen.LintConfig {
+		resetCommandState(t)
+		require.NoError(t, lintCmd.ParseFlags(append([]string{"--config", configPath}, args...)))
+		require.NoError(t, loadConfig(lintCmd))
+		return buildLintConfig("/test/styles.gen.go")
+	}
+
+	config := lintConfig("--preset", "ci")
+	assert.True(t, config.Strict, "preset overrides the config file")
+	assert.True(t, config.PrintIssuedLines)
+	assert.Equal(t, 50, config.MaxIssuesPerLinter)
+
+	config = lintConfig("--preset", "ci", "--strict=false", "--max-issues-per-linter", "10")
+	assert.False(t, config.Strict, "explicit flags win over the preset")
+	assert.Equal(t, 10, config.MaxIssuesPerLinter)
+
+	assert.False(t, lintConfig().Strict)
+
+	// The preset gates generate warnings too
+	resetCommandState(t)
+	require.NoError(t, generateCmd.ParseFlags([]string{"--config", configPath, "--preset", "ci"}))
+	require.NoError(t, loadConfig(generateCmd))
+	assert.True(t, buildGenerateConfig().FailOnWarning)
+
+	resetCommandState(t)
+	require.NoError(t, lintCmd.ParseFlags([]string{"--config", configPath, "--preset", "nightly"}))
+	err := loadConfig(lintCmd)
+	require.Error(t, err)
+	assert.Equal(t, exitUsage, exitCode(err))
+	assert.Contains(t, err.Error(), `unknown preset "nightly" (known: ci)`)
+}
+
+func TestResolvedConfigCommandsApplyFlags(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	require.NoError(t, os.MkdirAll(srcDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "app.css"), []byte(`.btn { color: red; }`), 0644))
+	configPath := filepath.Join(dir, ".cssgen.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("generate:\n  source: "+srcDir+"\nlint:\n  strict: false\n"), 0644))
+
+	output := func(args ...string) string {
+		var buf bytes.Buffer
+		rootCmd.SetOut(&buf)
+		t.Cleanup(func() { rootCmd.SetOut(nil) })
+		resetCommandState(t)
+		require.Equal(t, exitOK, run(append(args, "--config", configPath, "--output-dir", dir)))
+		return buf.String()
+	}
+
+	out := output("config", "--strict", "--max-issues-per-linter", "7", "--format", "compact")
+	assert.Contains(t, out, "strict: true")
+	assert.Contains(t, out, "max-issues-per-linter: 7")
+	assert.Contains(t, out, "format: compact")
+
+	// A lint override changes the cache key
+	assert.NotEqual(t, output("hash"), output("hash", "--strict"))
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var coverageCmd = &cobra.Command{
+	Use:   "coverage",
+	Short: "Show how much of the stylesheet is referenced in code",
+	Long: `Report the fraction of CSS classes (from AllCSSClasses) referenced anywhere in
+scanned Go/templ files, hardcoded or via constants, broken down by @layer.
+This is the stylesheet-centric inverse of lint's constant adoption.`,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(_ *cobra.Command, _ []string) error {
+		outputDir := getStringWithFallback("output-dir", "generate.output-dir", "internal/web/ui")
+		config := buildLintConfig(filepath.Join(outputDir, "styles.gen.go"))
+
+		report, err := cssgen.Coverage(config)
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("coverage failed: %w", err))
+		}
+
+		switch format := getStringWithFallback("output-format", "coverage.output-format", "table"); format {
+		case "table":
+			cssgen.PrintCoverage(os.Stdout, report, forceColors())
+		case "json":
+			if err := cssgen.WriteCoverageJSON(os.Stdout, report); err != nil {
+				return withExitCode(exitIO, err)
+			}
+		default:
+			return withExitCode(exitUsage, fmt.Errorf("invalid --output-format %q (want table or json)", format))
+		}
+		return nil
+	},
+}
+
+func init() {
+	f := coverageCmd.Flags()
+	f.StringSlice("paths", nil, "File patterns to scan for class references (default: **/*.templ and **/*.go under the go.mod root, minus vendor)")
+	f.String("paths-from", "", "File listing extra paths to scan, one per line (no glob expansion)")
+	f.String("output-dir", "internal/web/ui", "Output directory containing generated files")
+	f.String("output-format", "table", "Output format: table|json")
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+)
+
+// Exit codes shared by all commands
+const (
+	exitOK     = 0 // Clean run
+	exitPolicy = 1 // Lint errors, strict mode issues or threshold not met
+	exitUsage  = 2 // Bad flags, arguments or configuration
+	exitIO     = 3 // Reading or writing files failed
+)
+
+// exitError carries the process exit code for an error
+// A nil err means the failure was already reported (e.g. lint output) and nothing more is printed
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	if e.err == nil {
+		return ""
+	}
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode tags err with an exit code (nil stays nil)
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// exitCode maps an error returned by a command to the process exit code
+// Untagged errors come from cobra flag/argument parsing and count as usage errors
+func exitCode(err error) int {
+	if err == nil {
+		return exitOK
+	}
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return exitUsage
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lintFixture is a minimal project for running commands end to end
+type lintFixture struct {
+	dir        string
+	outputDir  string
+	cleanFile  string // Uses constants only
+	typoFile   string // References a class missing from the stylesheet
+	goodConfig string
+	badConfig  string // Invalid YAML
+}
+
+func newLintFixture(t *testing.T) lintFixture {
+	t.Helper()
+	dir := t.TempDir()
+	fx := lintFixture{
+		dir:        dir,
+		outputDir:  filepath.Join(dir, "ui"),
+		cleanFile:  filepath.Join(dir, "clean.templ"),
+		typoFile:   filepath.Join(dir, "typo.templ"),
+		goodConfig: filepath.Join(dir, "good.yaml"),
+		badConfig:  filepath.Join(dir, "bad.yaml"),
+	}
+
+	require.NoError(t, os.MkdirAll(fx.outputDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(fx.outputDir, "styles.gen.go"), []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+}
+
+const Btn = "btn"
+`), 0644))
+	require.NoError(t, os.WriteFile(fx.cleanFile, []byte("package test\n\ntempl A() {\n\t<div class={ ui.Btn }></div>\n}\n"), 0644))
+	require.NoError(t, os.WriteFile(fx.typoFile, []byte("package test\n\ntempl B() {\n\t<div class=\"btn--typo\"></div>\n}\n"), 0644))
+	require.NoError(t, os.WriteFile(fx.goodConfig, []byte("package: ui\n"), 0644))
+	require.NoError(t, os.WriteFile(fx.badConfig, []byte("lint: [unclosed\n"), 0644))
+
+	return fx
+}
+
+// resetCommandState restores flag defaults and config so run() calls don't leak into each other
+func resetCommandState(t *testing.T) {
+	t.Helper()
+	resetKoanf()
+	for _, flags := range []*pflag.FlagSet{rootCmd.PersistentFlags(), lintCmd.Flags(), generateCmd.Flags(), initCmd.Flags(), verifyCmd.Flags(), configCmd.Flags(), hashCmd.Flags()} {
+		flags.VisitAll(func(f *pflag.Flag) {
+			if sv, ok := f.Value.(pflag.SliceValue); ok {
+				var def []string
+				if trimmed := strings.Trim(f.DefValue, "[]"); trimmed != "" {
+					def = strings.Split(trimmed, ",")
+				}
+				require.NoError(t, sv.Replace(def))
+			} else {
+				require.NoError(t, f.Value.Set(f.DefValue))
+			}
+			f.Changed = false
+		})
+	}
+	activeCmd = nil
+}
+
+// chdirTemp moves into a fresh temporary directory for the rest of the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+func TestVerifyExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	outDir := filepath.Join(dir, "ui")
+	require.NoError(t, os.MkdirAll(srcDir, 0755))
+	require.NoError(t, os.MkdirAll(outDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "app.css"), []byte(`.btn { color: red; }`), 0644))
+
+	args := func(cmd string) []string {
+		return []string{cmd, "--quiet", "--config", filepath.Join(dir, "none.yaml"),
+			"--source", srcDir, "--output-dir", outDir, "--include", "*.css"}
+	}
+
+	resetCommandState(t)
+	require.Equal(t, exitOK, run(args("generate")))
+
+	resetCommandState(t)
+	assert.Equal(t, exitOK, run(args("verify")))
+
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "app.css"), []byte(`.btn { color: blue; }`), 0644))
+	resetCommandState(t)
+	assert.Equal(t, exitPolicy, run(args("verify")))
+}
+
+func TestGenerateFailOnWarningExitCode(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	outDir := filepath.Join(dir, "ui")
+	require.NoError(t, os.MkdirAll(srcDir, 0755))
+	require.NoError(t, os.MkdirAll(outDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.css"), []byte(`.btn { color: red; }`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "b.css"), []byte(`.btn { color: blue; }`), 0644))
+
+	args := []string{"generate", "--quiet", "--config", filepath.Join(dir, "none.yaml"),
+		"--source", srcDir, "--output-dir", outDir, "--include", "*.css"}
+
+	resetCommandState(t)
+	assert.Equal(t, exitOK, run(args))
+
+	resetCommandState(t)
+	assert.Equal(t, exitPolicy, run(append(args, "--fail-on-warning")))
+
+	// Bad settings are usage errors, not I/O failures
+	resetCommandState(t)
+	assert.Equal(t, exitUsage, run(append(args, "--line-ending", "cr")))
+
+	resetCommandState(t)
+	assert.Equal(t, exitUsage, run(append(args, "--internal-prefixes", "-webkit-,")))
+}
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, exitOK, exitCode(nil))
+	assert.Equal(t, exitUsage, exitCode(errors.New("unknown flag: --nope")))
+	assert.Equal(t, exitIO, exitCode(withExitCode(exitIO, errors.New("read failed"))))
+	assert.Equal(t, exitPolicy, exitCode(&exitError{code: exitPolicy}))
+	assert.NoError(t, withExitCode(exitIO, nil))
+}
+
+func TestRunExitCodes(t *testing.T) {
+	fx := newLintFixture(t)
+	lint := func(config string, extra ...string) []string {
+		return append([]string{"lint", "--quiet", "--config", config, "--output-dir", fx.outputDir}, extra...)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"clean lint", lint(fx.goodConfig, "--paths", fx.cleanFile), exitOK},
+		{"lint errors", lint(fx.goodConfig, "--paths", fx.typoFile), exitPolicy},
+		{"invalid config file", lint(fx.badConfig, "--paths", fx.cleanFile), exitUsage},
+		{"unknown flag", []string{"lint", "--no-such-flag"}, exitUsage},
+		{"new-only without baseline", lint(fx.goodConfig, "--paths", fx.cleanFile, "--new-only"), exitUsage},
+		{"unreadable baseline", lint(fx.goodConfig, "--paths", fx.cleanFile, "--new-only", "--baseline", filepath.Join(fx.dir, "missing.json")), exitIO},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCommandState(t)
+			assert.Equal(t, tt.want, run(tt.args))
+		})
+	}
+}
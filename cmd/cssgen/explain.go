@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var explainCmd = &cobra.Command{
+	Use:   "explain [rule]",
+	Short: "Describe a lint rule: why it fires and how to fix it",
+	Long: `Describe a lint rule, why it fires and how to fix it.
+Without a rule, list the rules that can be explained.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		if len(args) == 0 {
+			for _, rule := range cssgen.ExplainableRules() {
+				fmt.Fprintln(out, rule)
+			}
+			return nil
+		}
+
+		text, err := cssgen.ExplainRule(args[0])
+		if err != nil {
+			return withExitCode(exitUsage, err)
+		}
+		fmt.Fprintln(out, text)
+		return nil
+	},
+}
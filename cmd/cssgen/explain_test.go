@@ -0,0 +1,27 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainCommand(t *testing.T) {
+	resetCommandState(t)
+	var buf bytes.Buffer
+	rootCmd.SetOut(&buf)
+	t.Cleanup(func() { rootCmd.SetOut(nil) })
+
+	require.Equal(t, exitOK, run([]string{"explain", "hardcoded-class"}))
+	assert.Contains(t, buf.String(), "hardcoded-class")
+	assert.Contains(t, buf.String(), "Generated constants")
+	assert.Contains(t, buf.String(), "Classes starting with _")
+
+	buf.Reset()
+	require.Equal(t, exitOK, run([]string{"explain"}))
+	assert.Contains(t, buf.String(), "invalid-class\n")
+
+	assert.Equal(t, exitUsage, run([]string{"explain", "no-such-rule"}))
+}
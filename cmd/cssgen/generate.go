@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"os"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/yacobolo/cssgen/internal/cssgen"
 )
 
@@ -20,40 +23,106 @@ Each CSS class becomes exactly one Go constant.`,
 }
 
 func init() {
-	f := generateCmd.Flags()
+	addGenerateFlags(generateCmd.Flags())
+}
+
+// addGenerateFlags registers the generate flags; config and hash share them
+func addGenerateFlags(f *pflag.FlagSet) {
 	f.String("source", "web/ui/src/styles", "Source CSS directory")
 	f.String("output-dir", "internal/web/ui", "Output directory for generated files")
 	f.StringSlice("include", nil, "Glob patterns for CSS files to include")
 	f.String("format", "markdown", "Generation format: markdown|compact")
 	f.Int("property-limit", 5, "Max properties per category in comments")
+	f.Int("max-line-width", 120, "Width of compact property comments before truncating or wrapping")
+	f.Bool("wrap-comments", false, "Wrap long compact property comments onto continuation lines instead of truncating")
 	f.Bool("show-internal", false, "Show -webkit-* properties")
+	f.StringSlice("internal-prefixes", nil, "Property prefixes categorized as Internal (default: -webkit-,-moz-,-ms-,-o-)")
+	f.Bool("unprefix-vendor", false, "Categorize known vendor-prefixed properties like their unprefixed form (-webkit-transform as Effects)")
 	f.Bool("extract-intent", true, "Parse @intent comments from CSS")
+	f.Bool("inherit-intent", false, "Show the base class's @intent on modifiers without their own")
 	f.Bool("infer-layer", true, "Infer layer from file path")
+	f.String("default-layer", "base", "Layer for classes in files whose path names no layer")
+	f.Bool("extract-ids", false, "Generate ID constants (styles_ids.gen.go) from #id selectors")
+	f.Bool("layer-overrides", false, "Warn when classes in different @layer blocks set the same property")
+	f.StringSlice("build-tags", nil, "Build constraints for generated files (joined with &&)")
+	f.String("emit-manifest", "", "Write the BEM tree (base classes with modifiers/elements) as JSON to this file")
+	f.Bool("typed-groups", false, "Give each BEM family a named string type (const BtnPrimary BtnClass = \"btn--primary\")")
+	f.String("emit-intents", "", "Write every class with an @intent, grouped by layer, to this file (JSON for .json, else Markdown)")
+	f.String("emit-docs", "", "Write a Markdown catalog of classes (Go const, layer, intent, properties) to this file")
+	f.String("usage-file", "", "Coverage JSON report; only emit constants for classes it shows as used")
+	f.Int("min-usage", 1, "Minimum references in --usage-file to keep a constant")
+	f.String("line-ending", "lf", "Line endings of generated files: lf|crlf|auto")
+	f.String("modifier-separator", "--", "Separator marking BEM modifiers (e.g. _ for btn_primary)")
+	f.String("element-separator", "__", "Separator marking BEM elements")
+	f.Bool("honor-source-comments", false, "Attribute classes in a bundled CSS file to the preceding /* src: file.css */ comment")
+	f.Bool("fail-on-warning", false, "Exit with an error, writing nothing, if generation produces warnings")
+	f.String("output-format", "text", "Output format: text|json (with --lint, also the lint report format; the stats JSON then goes to stderr unless --out is set)")
 	f.Bool("lint", false, "Run linter after generation")
+	f.String("out", "", "Write the lint report to a file instead of stdout (with --lint)")
 }
 
 func runGenerate(cmd *cobra.Command, _ []string) error {
 	config := buildGenerateConfig()
 
+	switch format := getStringWithFallback("output-format", "generate.output-format", "text"); format {
+	case "text", "json":
+	default:
+		return withExitCode(exitUsage, fmt.Errorf("invalid --output-format %q (want text or json)", format))
+	}
+
+	if err := cssgen.ValidateConfig(config); err != nil {
+		return withExitCode(exitUsage, fmt.Errorf("invalid configuration: %w", err))
+	}
+
 	result, err := cssgen.Generate(config)
+	var warningsErr *cssgen.WarningsError
+	if errors.As(err, &warningsErr) {
+		return withExitCode(exitPolicy, fmt.Errorf("generation failed: %w", err))
+	}
 	if err != nil {
-		return fmt.Errorf("generation failed: %w", err)
+		return withExitCode(exitIO, fmt.Errorf("generation failed: %w", err))
 	}
 
 	quiet := getBoolWithFallback("quiet", "quiet", false)
+	outputFormat := getStringWithFallback("output-format", "generate.output-format", "text")
+	lint, _ := cmd.Flags().GetBool("lint")
 
-	if !quiet {
+	// stdout holds a single JSON document: the lint report when it goes there too
+	statsOut := os.Stdout
+	if lint && getStringWithFallback("out", "lint.out", "") == "" {
+		statsOut = os.Stderr
+	}
+
+	switch {
+	case quiet:
+	case outputFormat == "json":
+		if err := cssgen.WriteGenerateJSON(statsOut, result); err != nil {
+			return withExitCode(exitIO, fmt.Errorf("writing JSON: %w", err))
+		}
+	default:
 		fmt.Printf("Generated files in %s\n", config.OutputDir)
 		fmt.Printf("  Files scanned: %d\n", result.FilesScanned)
 		fmt.Printf("  Classes generated: %d\n", result.ClassesGenerated)
+		if result.IDsGenerated > 0 {
+			fmt.Printf("  IDs generated: %d\n", result.IDsGenerated)
+		}
+		if result.ClassesPruned > 0 {
+			fmt.Printf("  Classes pruned (below usage threshold): %d\n", result.ClassesPruned)
+		}
+
+		if !result.Changes.Empty() {
+			fmt.Println("  Changes since last generation:")
+			for _, line := range result.Changes.Lines() {
+				fmt.Printf("    %s\n", line)
+			}
+		}
 
-		for _, w := range result.Warnings {
+		for _, w := range result.Warnings.Strings() {
 			fmt.Printf("  Warning: %s\n", w)
 		}
 	}
 
 	// Run lint after generate if --lint flag set
-	lint, _ := cmd.Flags().GetBool("lint")
 	if lint {
 		return runLint(config.OutputDir, config.PackageName)
 	}
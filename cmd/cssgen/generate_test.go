@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+func TestGenerateLintJSONStdout(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	outDir := filepath.Join(dir, "ui")
+	require.NoError(t, os.MkdirAll(srcDir, 0755))
+	require.NoError(t, os.MkdirAll(outDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "app.css"), []byte(`.btn { color: red; }`), 0644))
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package web\n\ntempl P() {\n\t<div class={ ui.Btn }></div>\n}\n"), 0644))
+	config := filepath.Join(dir, "cssgen.yaml")
+	require.NoError(t, os.WriteFile(config, []byte("lint:\n  paths: ["+page+"]\n"), 0644))
+
+	// Swap stdout and stderr for pipes, read back once the run is done
+	capture := func(target **os.File) func() string {
+		r, w, err := os.Pipe()
+		require.NoError(t, err)
+		orig := *target
+		*target = w
+		return func() string {
+			*target = orig
+			require.NoError(t, w.Close())
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			return string(data)
+		}
+	}
+
+	resetCommandState(t)
+	stdout, stderr := capture(&os.Stdout), capture(&os.Stderr)
+	code := run([]string{"generate", "--lint", "--output-format", "json", "--config", config,
+		"--source", srcDir, "--output-dir", outDir, "--include", "*.css"})
+	errOut := stderr()
+	out := stdout()
+	require.Equal(t, exitOK, code, errOut)
+
+	// stdout is exactly one document, the lint report
+	var report cssgen.JSONOutput
+	decoder := json.NewDecoder(strings.NewReader(out))
+	require.NoError(t, decoder.Decode(&report))
+	assert.False(t, decoder.More(), "stdout holds a single JSON document")
+	assert.Equal(t, 1, report.Stats.TotalConstants)
+	assert.Equal(t, 1, report.Summary.FilesScanned, "lint.paths from the config applies with --lint")
+
+	assert.Contains(t, errOut, `"classes_generated": 1`)
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// gitCommand runs git with args in the working directory and returns its stdout
+// It is a variable so tests can stub git
+var gitCommand = func(args ...string) ([]byte, error) {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return nil, fmt.Errorf("git %s: %s", strings.Join(args, " "), bytes.TrimSpace(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("git %s: %w", strings.Join(args, " "), err)
+	}
+	return out, nil
+}
+
+// gitFiles runs a git command that prints one path per line
+func gitFiles(args ...string) ([]string, error) {
+	out, err := gitCommand(args...)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, filepath.FromSlash(line))
+		}
+	}
+	return files, nil
+}
+
+// stagedFiles lists files added, copied, modified or renamed in the index,
+// relative to the working directory
+func stagedFiles() ([]string, error) {
+	return gitFiles("diff", "--cached", "--name-only", "--diff-filter=ACMR", "--relative")
+}
+
+// matchScanPaths keeps the files matched by any of the scan patterns
+func matchScanPaths(files, patterns []string) []string {
+	var matched []string
+	for _, file := range files {
+		for _, pattern := range patterns {
+			target := file
+			if filepath.IsAbs(pattern) {
+				abs, err := filepath.Abs(file)
+				if err != nil {
+					continue
+				}
+				target = abs
+			}
+			ok, _ := doublestar.Match(filepath.ToSlash(filepath.Clean(pattern)), filepath.ToSlash(target))
+			if ok {
+				matched = append(matched, file)
+				break
+			}
+		}
+	}
+	return matched
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var hashCmd = &cobra.Command{
+	Use:   "hash",
+	Short: "Print a hash of the inputs for use as a CI cache key",
+	Long: `Print a stable SHA-256 of the resolved configuration, the CSS files generate
+would read and the styles*.gen.go files in the output directory. It changes
+whenever any of them does, so CI can use it as a cache key to skip runs:
+
+  key: cssgen-$(cssgen hash)
+
+The "// Generated:" timestamp in generated files is ignored.`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		var settings bytes.Buffer
+		if err := printResolvedConfig(&settings); err != nil {
+			return withExitCode(exitUsage, err)
+		}
+
+		sum, err := cssgen.InputHash(buildGenerateConfig(), settings.Bytes())
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("hash failed: %w", err))
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), sum)
+		return nil
+	},
+}
+
+func init() {
+	// Every resolved setting is part of the key, so every override is accepted
+	addResolvedConfigFlags(hashCmd.Flags())
+}
@@ -1,38 +1,255 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 
+	"github.com/knadh/koanf/parsers/yaml"
 	"github.com/spf13/cobra"
 )
 
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Generate a default .cssgen.yaml config file",
-	Long:  `Create a .cssgen.yaml configuration file in the current directory with sensible defaults.`,
+	Long: `Create a .cssgen.yaml configuration file in the current directory with sensible defaults.
+With --with-generate, also write a styles_gen.go stub in the output directory holding a
+bare "//go:generate cssgen generate" directive, so "go generate ./..." regenerates the
+constants with the settings in .cssgen.yaml.
+With --hooks, also add a local hook running "cssgen lint --staged --quiet" on every
+commit to .pre-commit-config.yaml (https://pre-commit.com), creating it or appending
+to its repos list; other hooks are kept.
+With either option an existing .cssgen.yaml is kept unless --force is given.`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		force, _ := cmd.Flags().GetBool("force")
+		withGenerate, _ := cmd.Flags().GetBool("with-generate")
+		hooks, _ := cmd.Flags().GetBool("hooks")
+		source, _ := cmd.Flags().GetString("source")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		pkg, _ := cmd.Flags().GetString("package")
 
+		// Check every target before writing anything
+		writeConfig := true
 		if _, err := os.Stat(".cssgen.yaml"); err == nil && !force {
-			return fmt.Errorf(".cssgen.yaml already exists (use --force to overwrite)")
+			if !withGenerate && !hooks {
+				return withExitCode(exitUsage, fmt.Errorf(".cssgen.yaml already exists (use --force to overwrite)"))
+			}
+			// Adding a stub or hooks to a configured project keeps its config
+			writeConfig = false
 		}
 
-		if err := os.WriteFile(".cssgen.yaml", []byte(defaultConfig), 0644); err != nil {
-			return fmt.Errorf("writing config file: %w", err)
+		stubPath := filepath.Join(outputDir, generateStubName)
+		var stub string
+		if withGenerate {
+			if _, err := os.Stat(stubPath); err == nil && !force {
+				return withExitCode(exitUsage, fmt.Errorf("%s already exists (use --force to overwrite)", stubPath))
+			}
+			stub = renderGenerateStub(pkg)
+		}
+
+		// Hooks are merged into an existing pre-commit config, never overwritten
+		var hooksConfig string
+		hooksExist := false
+		if hooks {
+			// #nosec G304 - fixed file name in the current directory
+			existing, err := os.ReadFile(preCommitConfigName)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				hooksConfig = renderPreCommitConfig()
+			case err != nil:
+				return withExitCode(exitIO, fmt.Errorf("reading %s: %w", preCommitConfigName, err))
+			default:
+				hooksExist = true
+				if hooksConfig, err = addPreCommitHook(string(existing)); err != nil {
+					return withExitCode(exitUsage, fmt.Errorf("%s: %w", preCommitConfigName, err))
+				}
+			}
+		}
+
+		if writeConfig {
+			if err := os.WriteFile(".cssgen.yaml", []byte(renderDefaultConfig(source, outputDir, pkg)), 0644); err != nil {
+				return withExitCode(exitIO, fmt.Errorf("writing config file: %w", err))
+			}
+			fmt.Println("Created .cssgen.yaml")
+		} else {
+			fmt.Println("Keeping existing .cssgen.yaml")
+		}
+
+		if withGenerate {
+			if err := os.MkdirAll(outputDir, 0755); err != nil {
+				return withExitCode(exitIO, fmt.Errorf("creating output directory: %w", err))
+			}
+			if err := os.WriteFile(stubPath, []byte(stub), 0644); err != nil {
+				return withExitCode(exitIO, fmt.Errorf("writing %s: %w", stubPath, err))
+			}
+
+			fmt.Printf("Created %s (run: go generate ./...)\n", stubPath)
+		}
+
+		if hooks {
+			switch {
+			case hooksConfig == "":
+				fmt.Printf("%s already runs cssgen-lint\n", preCommitConfigName)
+			default:
+				if err := os.WriteFile(preCommitConfigName, []byte(hooksConfig), 0644); err != nil {
+					return withExitCode(exitIO, fmt.Errorf("writing %s: %w", preCommitConfigName, err))
+				}
+				verb := "Created"
+				if hooksExist {
+					verb = "Added cssgen-lint to"
+				}
+				fmt.Printf("%s %s (run: pre-commit install)\n", verb, preCommitConfigName)
+			}
 		}
 
-		fmt.Println("Created .cssgen.yaml")
 		return nil
 	},
 }
 
+// generateStubName is the hand-owned file carrying the go:generate directive
+// It must not match styles_*.gen.go, which generation cleans up
+const generateStubName = "styles_gen.go"
+
+// preCommitConfigName is the file pre-commit reads its hooks from
+const preCommitConfigName = ".pre-commit-config.yaml"
+
+// preCommitHook is the local repo entry running cssgen from PATH, at list-item indent 0
+// The config is shared, so it names the command rather than this machine's binary
+// lint --staged asks git for the staged files itself, so pre-commit passes none
+const preCommitHook = `- repo: local
+  hooks:
+    - id: cssgen-lint
+      name: cssgen lint
+      entry: cssgen lint --staged --quiet
+      language: system
+      files: \.(templ|go)$
+      pass_filenames: false
+`
+
+// repoKeyPattern matches the top-level repos: key of a pre-commit config
+var repoKeyPattern = regexp.MustCompile(`^repos:\s*(#.*)?$`)
+
+// renderPreCommitConfig builds a pre-commit config holding only the cssgen hook
+func renderPreCommitConfig() string {
+	return `# pre-commit hooks (https://pre-commit.com), written by cssgen init --hooks
+# Install with: pre-commit install
+repos:
+` + indentLines(preCommitHook, "  ")
+}
+
+// addPreCommitHook appends the cssgen hook to the repos list of an existing pre-commit
+// config, keeping everything else as written ("" if the hook is already there)
+func addPreCommitHook(existing string) (string, error) {
+	if strings.Contains(existing, "id: cssgen-lint") {
+		return "", nil
+	}
+	before, err := preCommitRepos(existing)
+	if err != nil {
+		return "", err
+	}
+
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	lines := strings.SplitAfter(existing, "\n")
+	keyLine := -1
+	for i, line := range lines {
+		if repoKeyPattern.MatchString(strings.TrimRight(line, "\r\n")) {
+			keyLine = i
+			break
+		}
+	}
+
+	var merged string
+	if keyLine == -1 {
+		merged = existing + "repos:\n" + indentLines(preCommitHook, "  ")
+	} else {
+		// The list ends at the next top-level key; trailing blanks and comments stay after it
+		end, itemIndent := len(lines), "  "
+		foundItem := false
+		for i := keyLine + 1; i < len(lines); i++ {
+			trimmed := strings.TrimLeft(lines[i], " \t")
+			if !foundItem && strings.HasPrefix(trimmed, "- ") {
+				itemIndent, foundItem = lines[i][:len(lines[i])-len(trimmed)], true
+			}
+			if lines[i] != "" && !strings.ContainsAny(lines[i][:1], " \t-#\r\n") {
+				end = i
+				break
+			}
+		}
+		for end > keyLine+1 && (strings.TrimSpace(lines[end-1]) == "" || strings.HasPrefix(lines[end-1], "#")) {
+			end--
+		}
+		merged = strings.Join(lines[:end], "") + indentLines(preCommitHook, itemIndent) + strings.Join(lines[end:], "")
+	}
+
+	// Refuse to write anything pre-commit would read differently than intended
+	after, err := preCommitRepos(merged)
+	if err != nil || after != before+1 {
+		return "", fmt.Errorf("can't add the hook automatically; add this under repos:\n%s", preCommitHook)
+	}
+	return merged, nil
+}
+
+// preCommitRepos counts the entries of a pre-commit config's repos list
+func preCommitRepos(content string) (int, error) {
+	parsed, err := yaml.Parser().Unmarshal([]byte(content))
+	if err != nil {
+		return 0, fmt.Errorf("parsing: %w", err)
+	}
+	repos, ok := parsed["repos"]
+	if !ok || repos == nil {
+		return 0, nil
+	}
+	list, ok := repos.([]interface{})
+	if !ok {
+		return 0, fmt.Errorf("repos is not a list")
+	}
+	return len(list), nil
+}
+
+// indentLines prefixes every non-empty line of text with indent
+func indentLines(text, indent string) string {
+	lines := strings.SplitAfter(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// renderDefaultConfig fills the chosen package, source and output directories into defaultConfig
+func renderDefaultConfig(source, outputDir, pkg string) string {
+	config := strings.Replace(defaultConfig, "\npackage: ui\n", "\npackage: "+pkg+"\n", 1)
+	config = strings.Replace(config, "  source: web/ui/src/styles\n", "  source: "+source+"\n", 1)
+	return strings.Replace(config, "  output-dir: internal/web/ui\n", "  output-dir: "+outputDir+"\n", 1)
+}
+
+// renderGenerateStub builds the styles_gen.go stub; the directive carries no
+// settings, cssgen reads them from .cssgen.yaml at the module root
+func renderGenerateStub(pkg string) string {
+	return fmt.Sprintf(`package %s
+
+// Regenerate the CSS class constants in this package with: go generate ./...
+// Settings come from .cssgen.yaml at the module root.
+//
+//go:generate cssgen generate
+`, pkg)
+}
+
 const defaultConfig = `# cssgen configuration
 # Docs: https://github.com/yacobolo/cssgen
 
 # Shared settings
 package: ui
 verbose: false
+no-color: false            # disable colors (NO_COLOR in the environment does the same)
+preset: ""                 # ci: strict, fail-on-warning, print-lines, max 50 issues per linter (flags still win)
 
 # Generation settings
 generate:
@@ -44,24 +261,83 @@ generate:
     - "layers/base.css"
   format: markdown         # markdown | compact
   property-limit: 5
+  max-line-width: 120      # width of compact property comments
+  wrap-comments: false     # wrap long compact comments instead of truncating with ...
   show-internal: false
+  internal-prefixes: []    # prefixes hidden as Internal (empty = -webkit-, -moz-, -ms-, -o-)
+  unprefix-vendor: false   # categorize -webkit-transform like transform
   extract-intent: true
+  inherit-intent: false    # modifiers without @intent show their base's intent
   infer-layer: true
+  default-layer: base      # layer for files whose path names no layer
+  extract-ids: false       # also generate ID constants from #id selectors
+  category-overrides: {}   # e.g. scroll-snap-type: layout
+  layer-overrides: false   # warn when layers set the same property
+  emit-manifest: ""        # write the BEM tree as JSON (e.g. docs/bem.json)
+  emit-docs: ""            # write a Markdown class catalog (e.g. docs/classes.md)
+  emit-intents: ""         # write @intent docs by layer (.json or Markdown, e.g. docs/intents.md)
+  typed-groups: false      # const BtnPrimary BtnClass = "btn--primary" for BEM families
+  usage-file: ""           # coverage JSON; prune constants for unused classes
+  min-usage: 1             # references needed to keep a constant (with usage-file)
+  line-ending: lf          # lf | crlf | auto (crlf on Windows)
+  modifier-separator: "--" # BEM modifier marker (e.g. "_" for btn_primary)
+  element-separator: "__"  # BEM element marker
+  honor-source-comments: false # attribute bundled classes to /* src: file.css */ comments
+  output-format: text      # text | json (stats and warnings for CI)
+  fail-on-warning: false   # exit non-zero without writing files on any warning
 
 # Linting settings
 lint:
   paths:
     - "internal/web/features/**/*.templ"
     - "internal/web/features/**/*.go"
+  paths-from: ""           # file listing extra paths, one per line
+  usage-paths: []          # code scanned only for ui.Const usage, e.g. "internal/api/**/*.go"
+  exclude: []              # matched files to skip, e.g. "**/legacy/**"
+  attributes:              # attributes holding class strings
+    - class
+  struct-tags: false       # also scan class:"..." in Go struct tags
+  scan-data: false         # scan .yaml/.json files in paths for data-keys values
+  data-keys:               # keys holding class strings in YAML/JSON
+    - class
+  toggle-attributes: []    # class toggle expressions, e.g. [":class", "x-bind:class"]
+  package-alias: ""        # qualifier in suggestions, default: package (css -> css.Btn)
+  import-path: ""          # e.g. example.com/app/internal/web/ui
   strict: false
   threshold: 0.0
-  output-format: issues    # issues | summary | full | json | markdown
+  thresholds: {}           # per-layer minimum adoption, e.g. components: 80
+  dynamic-patterns: []     # regexes for runtime classes, e.g. "^btn--.*$"
+  only: []                 # limit to rules, e.g. [invalid-class] (empty = all)
+  custom-rules: []         # e.g. - {name: no-debug, class-pattern: "^debug-", severity: error}
+  output-format: issues    # issues | summary | full | json | ndjson | csv | markdown
+  out: ""                  # write the report to a file instead of stdout
+  append-history: ""       # e.g. .cssgen-history.jsonl (read by cssgen trend)
   max-issues-per-linter: 0 # 0 = unlimited
   max-same-issues: 0       # 0 = unlimited
   print-lines: true
   print-linter-name: true
+  progress-threshold: 50   # 0 = never print "Scanning complete"
+  max-files: 0             # abort when the scan paths match more files (0 = unlimited)
+  group-by: ""             # "" | severity | type | file
+  path-filter: ""          # only print issues in matching files, e.g. internal/web/admin/**
+  quick-win-min-occurrences: 1 # hide Quick Wins seen fewer times
+  component-scope: false       # also group Quick Wins per templ component (same file)
+  suggest-limit: 1             # "did you mean" candidates per invalid class
+  unused-warn-threshold: 50    # suggest removing unused constants above this many
+  low-adoption-threshold: 20.0 # suggest Quick Wins below this usage percentage
+  error-on-unused: false   # fail on constants that are never used
+  check-a11y: false        # experimental: sr-only combined with display: none
+  fix: false               # rewrite hardcoded class strings to constants
+  fix-unsafe: false        # with fix, also drop classes without a constant and apply typo guesses
+  export-unused-patch: ""  # write line ranges of unused constants as JSON
+  prune-unused: false      # delete unused constants from the generated files
 `
 
 func init() {
-	initCmd.Flags().Bool("force", false, "Overwrite existing config file")
+	f := initCmd.Flags()
+	f.Bool("force", false, "Overwrite existing config file (and generate stub)")
+	f.Bool("with-generate", false, "Also write a styles_gen.go stub with a //go:generate directive")
+	f.Bool("hooks", false, "Also add a hook running cssgen lint --staged --quiet to .pre-commit-config.yaml")
+	f.String("source", "web/ui/src/styles", "Source CSS directory written to the config")
+	f.String("output-dir", "internal/web/ui", "Output directory written to the config (and stub location)")
 }
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitCommand(t *testing.T) {
+	const hook = "      - id: cssgen-lint\n        name: cssgen lint\n        entry: cssgen lint --staged --quiet\n"
+	otherHooks := `repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.6.0
+    hooks:
+      - id: trailing-whitespace
+
+# formatting
+default_stages: [pre-commit]
+`
+
+	tests := []struct {
+		name       string
+		args       []string
+		config     string // Existing .cssgen.yaml ("" = none)
+		preCommit  string // Existing .pre-commit-config.yaml ("" = none)
+		wantCode   int
+		wantConfig []string // Substrings of .cssgen.yaml afterwards (nil = not written)
+		wantStub   []string // Substrings of pkg/styles/styles_gen.go (nil = not checked)
+		wantHooks  []string // Substrings of .pre-commit-config.yaml (nil = not checked)
+	}{
+		{
+			name:       "creates config file",
+			args:       []string{"init"},
+			wantConfig: []string{"package: ui", "generate:", "lint:"},
+		},
+		{
+			name:       "refuses overwrite",
+			args:       []string{"init"},
+			config:     "existing",
+			wantCode:   exitUsage,
+			wantConfig: []string{"existing"},
+		},
+		{
+			name:       "force overwrite",
+			args:       []string{"init", "--force"},
+			config:     "existing",
+			wantConfig: []string{"package: ui"},
+		},
+		{
+			name:       "generate stub",
+			args:       []string{"init", "--with-generate", "--source", "assets/css", "--output-dir", "pkg/styles", "--package", "styles"},
+			wantConfig: []string{"\npackage: styles\n", "  source: assets/css\n", "  output-dir: pkg/styles\n"},
+			wantStub:   []string{"package styles\n", "\n//go:generate cssgen generate\n"},
+		},
+		{
+			name:       "hooks in a new project",
+			args:       []string{"init", "--hooks"},
+			wantConfig: []string{"generate:"},
+			wantHooks:  []string{"repos:\n  - repo: local\n    hooks:\n" + hook, "pass_filenames: false\n"},
+		},
+		{
+			name:       "hooks keep an existing config",
+			args:       []string{"init", "--hooks"},
+			config:     "package: custom\n",
+			wantConfig: []string{"package: custom\n"},
+			wantHooks:  []string{hook},
+		},
+		{
+			name:       "hooks keep other hooks",
+			args:       []string{"init", "--hooks", "--force"},
+			config:     "package: custom\n",
+			preCommit:  otherHooks,
+			wantConfig: []string{"generate:"},
+			wantHooks: []string{
+				"      - id: trailing-whitespace\n  - repo: local\n    hooks:\n" + hook,
+				"        pass_filenames: false\n\n# formatting\ndefault_stages: [pre-commit]\n",
+			},
+		},
+		{
+			name:       "hook already present",
+			args:       []string{"init", "--hooks"},
+			config:     "package: custom\n",
+			preCommit:  "repos:\n  - repo: local\n    hooks:\n      - id: cssgen-lint\n",
+			wantConfig: []string{"package: custom\n"},
+			wantHooks:  []string{"repos:\n  - repo: local\n    hooks:\n      - id: cssgen-lint\n"},
+		},
+		{
+			name:      "unparseable hooks file writes nothing",
+			args:      []string{"init", "--hooks"},
+			preCommit: "repos: [unclosed\n",
+			wantCode:  exitUsage,
+			wantHooks: []string{"repos: [unclosed\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			resetCommandState(t)
+			if tt.config != "" {
+				require.NoError(t, os.WriteFile(".cssgen.yaml", []byte(tt.config), 0644))
+			}
+			if tt.preCommit != "" {
+				require.NoError(t, os.WriteFile(".pre-commit-config.yaml", []byte(tt.preCommit), 0644))
+			}
+
+			assert.Equal(t, tt.wantCode, run(tt.args))
+
+			config, err := os.ReadFile(".cssgen.yaml")
+			if tt.wantConfig == nil {
+				assert.ErrorIs(t, err, os.ErrNotExist, "nothing is written when a target fails")
+			} else {
+				require.NoError(t, err)
+				for _, want := range tt.wantConfig {
+					assert.Contains(t, string(config), want)
+				}
+			}
+
+			if tt.wantStub != nil {
+				stub, err := os.ReadFile(filepath.Join("pkg", "styles", "styles_gen.go"))
+				require.NoError(t, err)
+				for _, want := range tt.wantStub {
+					assert.Contains(t, string(stub), want)
+				}
+			}
+
+			if tt.wantHooks != nil {
+				hooks, err := os.ReadFile(".pre-commit-config.yaml")
+				require.NoError(t, err)
+				for _, want := range tt.wantHooks {
+					assert.Contains(t, string(hooks), want)
+				}
+				if tt.wantCode == exitOK {
+					assert.Equal(t, 1, strings.Count(string(hooks), "id: cssgen-lint"))
+				}
+			}
+		})
+	}
+}
+
+// The stub's directive runs in the package directory, as go generate does, and
+// picks up the module root's config
+func TestInitGenerateStubFromPackageDir(t *testing.T) {
+	dir := chdirTemp(t)
+	resetCommandState(t)
+	require.Equal(t, exitOK, run([]string{"init", "--with-generate",
+		"--source", "assets/css", "--output-dir", "pkg/styles", "--package", "styles"}))
+
+	require.NoError(t, os.WriteFile("go.mod", []byte("module example.com/app\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join("assets", "css", "layers"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join("assets", "css", "layers", "base.css"), []byte(".btn { color: red; }\n"), 0644))
+	require.NoError(t, os.Chdir(filepath.Join("pkg", "styles")))
+	t.Setenv("GOPACKAGE", "styles")
+	resetCommandState(t)
+	assert.Equal(t, exitOK, run([]string{"generate", "--quiet"}))
+
+	generated, err := os.ReadFile(filepath.Join(dir, "pkg", "styles", "styles.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(generated), "package styles\n")
+	assert.Contains(t, string(generated), `Btn = "btn"`)
+}
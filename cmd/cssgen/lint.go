@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/yacobolo/cssgen/internal/cssgen"
 )
 
@@ -25,19 +29,53 @@ Detects hardcoded class strings, invalid classes, and unused constants.`,
 }
 
 func init() {
-	f := lintCmd.Flags()
-	f.StringSlice("paths", []string{
-		"internal/web/features/**/*.templ",
-		"internal/web/features/**/*.go",
-	}, "File patterns to scan for class references")
+	addLintFlags(lintCmd.Flags())
+}
+
+// addLintFlags registers the lint flags; config and hash share them
+func addLintFlags(f *pflag.FlagSet) {
+	f.StringSlice("paths", nil, "File patterns to scan for class references (default: **/*.templ and **/*.go under the go.mod root, minus vendor)")
+	f.StringSlice("exclude", nil, "Patterns of matched files to skip (listed as excluded by --debug-scan)")
+	f.StringSlice("usage-paths", nil, "Extra file patterns scanned only for constant references (e.g. handler code); they count as used")
+	f.String("paths-from", "", "File listing extra paths to scan, one per line (no glob expansion)")
+	f.StringSlice("attributes", []string{"class"}, "Attributes whose values are scanned as class strings (e.g. class,data-class)")
+	f.Bool("struct-tags", false, `Also scan class:"..." in Go struct tags`)
+	f.StringSlice("toggle-attributes", nil, "Attributes holding class toggle expressions whose strings and object keys are classes (e.g. :class,x-bind:class)")
+	f.Bool("scan-data", false, "Scan .yaml/.yml/.json files in the scan paths for class strings under --data-keys")
+	f.StringSlice("data-keys", []string{"class"}, "Keys whose values are class strings in YAML/JSON files (with --scan-data)")
 	f.String("output-dir", "internal/web/ui", "Output directory containing generated files")
+	f.String("package-alias", "", "Qualifier used in suggestions, e.g. css for css.Btn (default: --package)")
+	f.String("import-path", "", "Import path of the constants package shown in suggestions")
 	f.Bool("strict", false, "Exit 1 on any issue (CI mode)")
 	f.Float64("threshold", 0.0, "Minimum adoption percentage for strict mode")
-	f.String("output-format", "", "Output format: issues|summary|full|json|markdown")
-	f.Int("max-issues-per-linter", 0, "Max issues to show per linter (0=unlimited)")
-	f.Int("max-same-issues", 0, "Max repeated issues to show (0=unlimited)")
+	f.String("output-format", "", "Output format: issues|summary|full|json|ndjson|csv|markdown")
+	f.String("out", "", "Write the report to a file instead of stdout")
+	f.String("export-fixes", "", "Write computable fixes as JSON (file offsets + new text) without applying them")
+	f.Bool("fix", false, "Rewrite hardcoded class strings to constants where every class has one")
+	f.Bool("fix-unsafe", false, "With --fix, also apply fixes that change rendered output (dropped classes, \"did you mean\" guesses)")
+	f.String("export-unused-patch", "", "Write the generated-file line ranges of unused constants as JSON")
+	f.Bool("prune-unused", false, "Delete unused constant declarations from the generated files")
+	f.String("append-history", "", "Append this run's stats as a JSON line to a history file")
+	f.Int("max-issues-per-linter", 0, "Max issues to print per linter in the issues and full formats (0=unlimited)")
+	f.Int("max-same-issues", 0, "Max repeated issues to print in the issues and full formats (0=unlimited)")
 	f.Bool("print-lines", true, "Show source lines with issues")
 	f.Bool("print-linter-name", true, "Show (csslint) suffix on issues")
+	f.String("group-by", "", "Group issues under headers: severity|type|file")
+	f.String("path-filter", "", "Only print issues in files matching this glob (e.g. internal/web/admin/**)")
+	f.Int("progress-threshold", 50, "Print a scan notice above N files (0=disabled)")
+	f.Int("max-files", 0, "Abort if the scan paths match more than N files (0=unlimited)")
+	f.Int("quick-win-min-occurrences", 1, "Hide Quick Wins seen fewer than N times")
+	f.Bool("component-scope", false, "Also group Quick Wins by templ component, across the elements of one component in a file")
+	f.Int("suggest-limit", 1, `Most "did you mean" candidates listed per invalid class (only a sole candidate is offered as a fix)`)
+	f.Int("unused-warn-threshold", 50, "Suggest removing unused constants when more than N are unused")
+	f.Float64("low-adoption-threshold", 20, "Suggest starting with Quick Wins below this usage percentage")
+	f.Bool("error-on-unused", false, "Report completely unused constants as errors")
+	f.Bool("check-a11y", false, "Experimental: flag sr-only/visually-hidden classes combined with display: none")
+	f.Bool("debug-scan", false, "List skipped files and the reason they were skipped")
+	f.String("baseline", "", "JSON report of accepted issues (used with --new-only)")
+	f.Bool("new-only", false, "Report only issues not present in the baseline")
+	f.Bool("staged", false, "Lint only staged files (git diff --cached) matching the scan paths, for pre-commit hooks; their working-tree content is linted")
+	f.StringSlice("only", nil, "Report only these rules: invalid-class|hardcoded-class|unused-constant|redundant-class|stale-constant|empty-class|a11y-hidden")
 }
 
 // runLint is shared between `cssgen lint` and `cssgen generate --lint`.
@@ -47,25 +85,126 @@ func runLint(outputDir, pkg string) error {
 	// Override package name from the parameter (may come from generate config)
 	lintConfig.PackageName = pkg
 
+	if lintConfig.NewOnly && lintConfig.BaselineFile == "" {
+		return withExitCode(exitUsage, fmt.Errorf("--new-only requires --baseline"))
+	}
+
+	switch lintConfig.GroupBy {
+	case cssgen.GroupByNone, cssgen.GroupBySeverity, cssgen.GroupByType, cssgen.GroupByFile:
+	default:
+		return withExitCode(exitUsage, fmt.Errorf("invalid --group-by %q (want severity, type or file)", lintConfig.GroupBy))
+	}
+
+	if _, err := cssgen.CompileDynamicPatterns(lintConfig.DynamicPatterns); err != nil {
+		return withExitCode(exitUsage, err)
+	}
+	if err := cssgen.ValidateCustomRules(lintConfig.CustomRules); err != nil {
+		return withExitCode(exitUsage, err)
+	}
+	if err := cssgen.ValidateRules(lintConfig.Only, lintConfig.CustomRules...); err != nil {
+		return withExitCode(exitUsage, fmt.Errorf("invalid --only: %w", err))
+	}
+	if !doublestar.ValidatePattern(lintConfig.PathFilter) {
+		return withExitCode(exitUsage, fmt.Errorf("invalid --path-filter %q", lintConfig.PathFilter))
+	}
+
+	// --staged: lint only the staged files among the scan paths (pre-commit hooks)
+	if getBoolWithFallback("staged", "lint.staged", false) {
+		// Constants look unused when only a few files are scanned
+		if getBoolWithFallback("prune-unused", "lint.prune-unused", false) {
+			return withExitCode(exitUsage, fmt.Errorf("--staged cannot be combined with --prune-unused"))
+		}
+		lintConfig.ErrorOnUnused = false
+
+		staged, err := stagedFiles()
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("listing staged files: %w", err))
+		}
+		// Staged names are scanned as-is, like --paths-from, so names holding
+		// glob characters ([draft].templ) are not expanded
+		lintConfig.LiteralPaths = matchScanPaths(staged, lintConfig.ScanPaths)
+		lintConfig.ScanPaths = nil
+		lintConfig.PathsFrom = ""
+		if len(lintConfig.LiteralPaths) == 0 {
+			if !getBoolWithFallback("quiet", "quiet", false) {
+				fmt.Fprintln(os.Stderr, "No staged files to lint")
+			}
+			return nil
+		}
+	}
+
 	lintResult, err := cssgen.Lint(lintConfig)
 	if err != nil {
-		return fmt.Errorf("lint failed: %w", err)
+		return withExitCode(exitIO, fmt.Errorf("lint failed: %w", err))
 	}
 
 	quiet := getBoolWithFallback("quiet", "quiet", false)
+
+	if getBoolWithFallback("fix", "lint.fix", false) {
+		fixResult, err := cssgen.ApplyFixes(lintResult.Issues, getBoolWithFallback("fix-unsafe", "lint.fix-unsafe", false))
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("applying fixes: %w", err))
+		}
+		if !quiet {
+			reportFixes(os.Stderr, fixResult)
+		}
+
+		// Report and gate on the files as they are now
+		if len(fixResult.Applied) > 0 {
+			lintResult, err = cssgen.Lint(lintConfig)
+			if err != nil {
+				return withExitCode(exitIO, fmt.Errorf("lint failed: %w", err))
+			}
+		}
+	}
+
+	if history := getStringWithFallback("append-history", "lint.append-history", ""); history != "" {
+		if err := cssgen.AppendHistory(history, lintResult); err != nil {
+			return withExitCode(exitIO, err)
+		}
+	}
+
+	if fixesFile := getStringWithFallback("export-fixes", "lint.export-fixes", ""); fixesFile != "" {
+		if err := exportFixes(fixesFile, lintResult.Issues); err != nil {
+			return withExitCode(exitIO, err)
+		}
+	}
+
+	if patchFile := getStringWithFallback("export-unused-patch", "lint.export-unused-patch", ""); patchFile != "" {
+		if err := exportUnusedPatch(patchFile, lintResult); err != nil {
+			return withExitCode(exitIO, err)
+		}
+	}
+
+	if getBoolWithFallback("prune-unused", "lint.prune-unused", false) {
+		removed, err := cssgen.PruneUnused(lintResult)
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("pruning unused constants: %w", err))
+		}
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Pruned %d unused constant(s) from %s\n", removed, outputDir)
+		}
+	}
+
 	outputFormat := getStringWithFallback("output-format", "lint.output-format", "")
 	format := cssgen.DetermineOutputFormat(outputFormat, quiet)
 
+	// Quiet mode prints nothing at all, including the progress notice
 	if !quiet {
-		cssgen.WriteOutput(os.Stdout, lintResult, format, lintConfig)
+		out := getStringWithFallback("out", "lint.out", "")
+		if err := writeLintReport(out, lintResult, format, lintConfig); err != nil {
+			return withExitCode(exitIO, err)
+		}
 	}
 
 	// Exit code logic - "Soft Gate" approach
 	strict := getBoolWithFallback("strict", "lint.strict", false)
 	if strict {
-		// Strict mode: any issue (error or warning) fails the build
-		if len(lintResult.Issues) > 0 {
-			os.Exit(1)
+		// Strict mode: any error or warning fails the build (informational issues don't)
+		for _, issue := range lintResult.Issues {
+			if issue.Severity != cssgen.SeverityInfo {
+				return &exitError{code: exitPolicy}
+			}
 		}
 
 		// Also check threshold if specified
@@ -75,12 +214,86 @@ func runLint(outputDir, pkg string) error {
 				fmt.Fprintf(os.Stderr, "\nStrict mode: Usage percentage %.1f%% is below threshold %.1f%%\n",
 					lintResult.UsagePercentage, threshold)
 			}
-			os.Exit(1)
+			return &exitError{code: exitPolicy}
+		}
+
+		// Per-layer thresholds: layers without a threshold are exempt
+		failed := false
+		for _, layer := range lintResult.LayerAdoption {
+			want, ok := lintConfig.LayerThresholds[layer.Layer]
+			if !ok || layer.Percentage >= want {
+				continue
+			}
+			failed = true
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "\nStrict mode: @layer %s usage %.1f%% is below threshold %.1f%%\n",
+					layer.Layer, layer.Percentage, want)
+			}
+		}
+		if failed {
+			return &exitError{code: exitPolicy}
 		}
 	} else if lintResult.ErrorCount > 0 {
 		// Default "Soft Gate" mode: only errors fail the build
-		os.Exit(1)
+		return &exitError{code: exitPolicy}
 	}
 
 	return nil
 }
+
+// writeLintReport writes the report to stdout, or to path (creating parent dirs) when set
+// Progress notices keep going to stderr either way
+func writeLintReport(path string, result *cssgen.LintResult, format cssgen.OutputFormat, config cssgen.LintConfig) error {
+	if path == "" {
+		cssgen.WriteOutput(os.Stdout, result, format, config)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating report directory: %w", err)
+	}
+	// #nosec G304 - path comes from trusted configuration
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating report file: %w", err)
+	}
+
+	cssgen.WriteOutput(f, result, format, config)
+
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing report file: %w", err)
+	}
+	return nil
+}
+
+// exportUnusedPatch writes the unused-constant removals to path as JSON
+func exportUnusedPatch(path string, result *cssgen.LintResult) error {
+	var buf bytes.Buffer
+	if err := cssgen.WriteUnusedPatch(&buf, result); err != nil {
+		return fmt.Errorf("computing unused patch: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing unused patch file: %w", err)
+	}
+	return nil
+}
+
+// reportFixes prints how many fixes were applied and why the rest were skipped
+func reportFixes(w io.Writer, result *cssgen.FixResult) {
+	fmt.Fprintf(w, "Applied %d fix(es)\n", len(result.Applied))
+	for _, skipped := range result.Skipped {
+		fmt.Fprintf(w, "Skipped %s:%d %s: %s\n", skipped.File, skipped.Line, skipped.OldText, skipped.Reason)
+	}
+}
+
+// exportFixes writes the fixes for issues to path as JSON
+func exportFixes(path string, issues []cssgen.Issue) error {
+	var buf bytes.Buffer
+	if err := cssgen.WriteFixes(&buf, issues); err != nil {
+		return fmt.Errorf("computing fixes: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("writing fixes file: %w", err)
+	}
+	return nil
+}
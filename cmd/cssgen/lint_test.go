@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+// lintReport runs lint on the fixture with a JSON report written under a nested
+// directory, returning the exit code and the report (nil when none was written)
+func (fx lintFixture) lintReport(t *testing.T, args ...string) (int, *cssgen.JSONOutput) {
+	t.Helper()
+	resetCommandState(t)
+	reportFile := filepath.Join(fx.dir, "reports", "nested", "report.json")
+	code := run(append([]string{"lint", "--config", fx.goodConfig, "--output-dir", fx.outputDir,
+		"--output-format", "json", "--out", reportFile}, args...))
+
+	data, err := os.ReadFile(reportFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return code, nil
+	}
+	require.NoError(t, err)
+	var report cssgen.JSONOutput
+	require.NoError(t, json.Unmarshal(data, &report))
+	return code, &report
+}
+
+func TestLintReport(t *testing.T) {
+	const mixed = "package test\n\ntempl C() {\n\t<div class=\"btn\"></div>\n\t<div class=\"btn--typo\"></div>\n}\n"
+
+	tests := []struct {
+		name     string
+		page     string // Content of the scanned page.templ
+		args     []string
+		wantCode int
+		want     []string // One substring per reported issue, in order (nil = no report)
+	}{
+		{
+			name:     "report file in a new directory",
+			page:     "package test\n\ntempl B() {\n\t<div class=\"btn--typo\"></div>\n}\n",
+			wantCode: exitPolicy,
+			want:     []string{"btn--typo"},
+		},
+		{
+			// The report and exit code describe the fixed file, not the one linted
+			// first; the print limit doesn't hold back the second fix
+			name:     "fix reports the fixed file",
+			page:     "package test\n\ntempl P() {\n\t<div class=\"btn\"></div>\n\t<p class=\"btn\"></p>\n}\n",
+			args:     []string{"--strict", "--fix", "--max-issues-per-linter", "1"},
+			wantCode: exitOK,
+			want:     []string{},
+		},
+		{
+			name:     "every rule",
+			page:     mixed,
+			wantCode: exitPolicy,
+			want:     []string{"ui.Btn", "btn--typo"},
+		},
+		{
+			// The hardcoded "btn" warning is present, but --only hides it
+			name:     "only invalid-class",
+			page:     mixed,
+			args:     []string{"--only", "invalid-class"},
+			wantCode: exitPolicy,
+			want:     []string{"btn--typo"},
+		},
+		{
+			name:     "only hardcoded-class and unused-constant",
+			page:     mixed,
+			args:     []string{"--only", "hardcoded-class,unused-constant"},
+			wantCode: exitOK,
+			want:     []string{"ui.Btn"},
+		},
+		{
+			name:     "unknown rule",
+			page:     mixed,
+			args:     []string{"--only", "typos"},
+			wantCode: exitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := newLintFixture(t)
+			page := filepath.Join(fx.dir, "page.templ")
+			require.NoError(t, os.WriteFile(page, []byte(tt.page), 0644))
+
+			code, report := fx.lintReport(t, append([]string{"--paths", page}, tt.args...)...)
+			assert.Equal(t, tt.wantCode, code)
+			if tt.want == nil {
+				assert.Nil(t, report)
+				return
+			}
+			require.NotNil(t, report)
+			require.Len(t, report.Issues, len(tt.want))
+			for i, want := range tt.want {
+				assert.Contains(t, report.Issues[i].Message, want)
+			}
+		})
+	}
+}
+
+func TestLintLayerThresholds(t *testing.T) {
+	dir := t.TempDir()
+	outputDir := filepath.Join(dir, "ui")
+	require.NoError(t, os.MkdirAll(outputDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(outputDir, "styles.gen.go"), []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn":    true,
+	"card":   true,
+	"flex":   true,
+	"hidden": true,
+}
+
+// @layer components
+const Btn = "btn"
+
+// @layer components
+const Card = "card"
+
+// @layer utilities
+const Flex = "flex"
+
+// @layer utilities
+const Hidden = "hidden"
+`), 0644))
+
+	// components: 2/2 used (100%), utilities: 0/2 used (0%)
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package test\n\ntempl A() {\n\t<div class={ ui.Btn, ui.Card }></div>\n}\n"), 0644))
+
+	tests := []struct {
+		name       string
+		thresholds string
+		want       int
+	}{
+		{"passing layer only", "    components: 80\n", exitOK},
+		{"one layer below threshold", "    components: 80\n    utilities: 50\n", exitPolicy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetCommandState(t)
+			config := filepath.Join(dir, "cssgen.yaml")
+			require.NoError(t, os.WriteFile(config, []byte("lint:\n  thresholds:\n"+tt.thresholds), 0644))
+
+			assert.Equal(t, tt.want, run([]string{"lint", "--quiet", "--strict", "--config", config,
+				"--output-dir", outputDir, "--paths", page}))
+		})
+	}
+}
+
+func TestLintStaged(t *testing.T) {
+	tests := []struct {
+		name      string
+		staged    string // git diff --cached output
+		wantCode  int
+		wantFiles int // Files scanned
+		wantTypo  bool
+	}{
+		// typo.templ matches --paths but is not staged, so its invalid class is not reported
+		{name: "unstaged files are skipped", staged: "clean.templ\nnotes.md\n", wantCode: exitOK, wantFiles: 1},
+		{name: "staged typo fails the hook", staged: "typo.templ\n", wantCode: exitPolicy, wantFiles: 1, wantTypo: true},
+		// Staged names are literal paths: [draft].templ is not read as a character class
+		{name: "glob characters in a name", staged: "[draft].templ\n", wantCode: exitPolicy, wantFiles: 1, wantTypo: true},
+		{name: "nothing staged", staged: "", wantCode: exitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fx := newLintFixture(t)
+			typo, err := os.ReadFile(fx.typoFile)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(fx.dir, "[draft].templ"), typo, 0644))
+			wd, err := os.Getwd()
+			require.NoError(t, err)
+			require.NoError(t, os.Chdir(fx.dir))
+			t.Cleanup(func() { _ = os.Chdir(wd) })
+
+			var gitArgs []string
+			original := gitCommand
+			gitCommand = func(args ...string) ([]byte, error) {
+				gitArgs = args
+				return []byte(tt.staged), nil
+			}
+			t.Cleanup(func() { gitCommand = original })
+
+			code, report := fx.lintReport(t, "--paths", "*.templ", "--staged")
+			assert.Equal(t, tt.wantCode, code)
+			assert.Equal(t, []string{"diff", "--cached", "--name-only", "--diff-filter=ACMR", "--relative"}, gitArgs)
+			if tt.wantFiles == 0 {
+				assert.Nil(t, report, "nothing to lint writes no report")
+				return
+			}
+			require.NotNil(t, report)
+			assert.Equal(t, tt.wantFiles, report.Summary.FilesScanned)
+			assert.Equal(t, tt.wantTypo, len(report.Issues) == 1 && strings.Contains(report.Issues[0].Message, "btn--typo"))
+		})
+	}
+}
+
+func TestMatchScanPaths(t *testing.T) {
+	files := []string{"web/a.templ", "web/sub/b.go", "docs/c.md", "main.go"}
+	assert.Equal(t, []string{"web/a.templ", "web/sub/b.go"}, matchScanPaths(files, []string{"web/**/*.templ", "./web/**/*.go"}))
+	assert.Empty(t, matchScanPaths(files, []string{"internal/**/*.go"}))
+}
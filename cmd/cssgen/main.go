@@ -7,8 +7,15 @@ import (
 )
 
 func main() {
-	if err := rootCmd.Execute(); err != nil {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the root command with args and returns the process exit code
+func run(args []string) int {
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	if err != nil && err.Error() != "" {
 		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
 	}
+	return exitCode(err)
 }
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename <old-class> <new-class>",
+	Short: "Rewrite references to a renamed CSS class",
+	Long: `Rewrite ui.OldConst references and hardcoded "old-class" strings in the
+lint paths after a CSS class has been renamed. Stylesheets are not modified.`,
+	Args: cobra.ExactArgs(2),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(_ *cobra.Command, args []string) error {
+		outputDir := getStringWithFallback("output-dir", "generate.output-dir", "internal/web/ui")
+		lintConfig := buildLintConfig(filepath.Join(outputDir, "styles.gen.go"))
+
+		result, err := cssgen.Rename(cssgen.RenameConfig{
+			ScanPaths:     lintConfig.ScanPaths,
+			GeneratedFile: lintConfig.GeneratedFile,
+			PackageName:   lintConfig.PackageName,
+			OldClass:      args[0],
+			NewClass:      args[1],
+			MaxFiles:      lintConfig.MaxFiles,
+			Exclude:       lintConfig.Exclude,
+		})
+		if err != nil {
+			code := exitUsage
+			var pathErr *fs.PathError
+			if errors.As(err, &pathErr) {
+				code = exitIO
+			}
+			return withExitCode(code, fmt.Errorf("rename failed: %w", err))
+		}
+
+		if !getBoolWithFallback("quiet", "quiet", false) {
+			fmt.Printf("Renamed %s -> %s (ui.%s -> ui.%s)\n", args[0], args[1], result.OldConst, result.NewConst)
+			fmt.Printf("  Files changed: %d\n", result.FilesChanged)
+			fmt.Printf("  Constant references: %d\n", result.ConstantsChanged)
+			fmt.Printf("  Hardcoded strings: %d\n", result.StringsChanged)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	f := renameCmd.Flags()
+	f.StringSlice("paths", nil, "File patterns to rewrite (defaults to lint paths)")
+	f.String("output-dir", "internal/web/ui", "Output directory containing generated files")
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var reportCmd = &cobra.Command{
+	Use:   "report --compare old.json new.json",
+	Short: "Compare two JSON lint reports as Markdown",
+	Long: `Read two reports written with "lint --output-format json" and print a Markdown
+delta for PR comments: adoption change, new vs resolved issues (matched by
+fingerprint), and Quick Win changes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		oldPath, _ := cmd.Flags().GetString("compare")
+		if oldPath == "" {
+			return withExitCode(exitUsage, fmt.Errorf("report requires --compare <old.json>"))
+		}
+
+		before, err := cssgen.LoadJSONOutput(oldPath)
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("reading old report: %w", err))
+		}
+		after, err := cssgen.LoadJSONOutput(args[0])
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("reading new report: %w", err))
+		}
+
+		cssgen.WriteComparisonMarkdown(os.Stdout, cssgen.CompareReports(*before, *after))
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.Flags().String("compare", "", "Older JSON report to compare the given report against")
+}
@@ -29,11 +29,22 @@ func init() {
 	rootCmd.PersistentFlags().Bool("quiet", false, "Suppress all output (exit code only)")
 	rootCmd.PersistentFlags().String("package", "ui", "Go package name")
 	rootCmd.PersistentFlags().Bool("color", false, "Force color output")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable color output (also set by the NO_COLOR env var)")
 	rootCmd.PersistentFlags().String("config", ".cssgen.yaml", "Config file path")
+	rootCmd.PersistentFlags().String("preset", "", "Config bundle applied over the config file; explicit flags still win (ci)")
 
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(renameCmd)
+	rootCmd.AddCommand(trendCmd)
+	rootCmd.AddCommand(coverageCmd)
+	rootCmd.AddCommand(reportCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(hashCmd)
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(schemaCmd)
+	rootCmd.AddCommand(explainCmd)
 	rootCmd.AddCommand(completionCmd)
 	rootCmd.AddCommand(versionCmd)
 }
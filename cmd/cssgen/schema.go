@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var schemaCmd = &cobra.Command{
+	Use:       "schema [json]",
+	Short:     "Print the schema of machine-readable output",
+	Long:      `Print the JSON Schema (draft-07) describing "lint --output-format json" output.`,
+	ValidArgs: []string{"json"},
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return cssgen.WriteJSONSchema(os.Stdout)
+	},
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var trendCmd = &cobra.Command{
+	Use:   "trend [history-file]",
+	Short: "Show constant adoption over time",
+	Long: `Read the history file written by "cssgen lint --append-history" and print
+a sparkline and table of adoption, errors and hardcoded classes per run.`,
+	Args: cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := getStringWithFallback("append-history", "lint.append-history", ".cssgen-history.jsonl")
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		records, err := cssgen.LoadHistory(path)
+		if err != nil {
+			return withExitCode(exitIO, err)
+		}
+
+		last, _ := cmd.Flags().GetInt("last")
+		if last > 0 && len(records) > last {
+			records = records[len(records)-last:]
+		}
+
+		useColors := forceColors()
+		cssgen.PrintTrend(os.Stdout, records, useColors)
+		return nil
+	},
+}
+
+func init() {
+	trendCmd.Flags().Int("last", 20, "Show only the last N runs (0=all)")
+}
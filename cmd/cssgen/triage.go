@@ -0,0 +1,191 @@
+//go:build tui
+
+// The terminal UI pulls in Bubble Tea; build it in with: go install -tags tui
+
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var triageCmd = &cobra.Command{
+	Use:   "triage",
+	Short: "Interactively migrate Quick Wins to constants",
+	Long: `Open a terminal UI listing the most frequently hardcoded class strings that
+map entirely to generated constants. Pressing enter on one rewrites every
+occurrence to its constant (the same edits as lint --fix, for that class only)
+and re-lints, so the list always reflects the files on disk.
+
+Only occurrences lint --fix could rewrite are counted; class strings in other
+quotes, struct tags, data files or :class expressions are left for manual edits.
+
+Keys: up/down (k/j) move, enter (f) applies, r re-lints, q quits.`,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(_ *cobra.Command, _ []string) error {
+		outputDir := getStringWithFallback("output-dir", "generate.output-dir", "internal/web/ui")
+		config := buildLintConfig(filepath.Join(outputDir, "styles.gen.go"))
+
+		model, err := newTriageModel(func() (*cssgen.LintResult, error) {
+			return cssgen.Lint(config)
+		}, cssgen.ShouldUseColors(config))
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("lint failed: %w", err))
+		}
+
+		final, err := tea.NewProgram(model).Run()
+		if err != nil {
+			return withExitCode(exitIO, err)
+		}
+		if m, ok := final.(triageModel); ok && m.err != nil {
+			return withExitCode(exitIO, m.err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(triageCmd)
+
+	f := triageCmd.Flags()
+	f.StringSlice("paths", nil, "File patterns to scan for class references (default: **/*.templ and **/*.go under the go.mod root, minus vendor)")
+	f.String("paths-from", "", "File listing extra paths to scan, one per line (no glob expansion)")
+	f.String("output-dir", "internal/web/ui", "Output directory containing generated files")
+	f.Int("quick-win-min-occurrences", 1, "Hide Quick Wins seen fewer than N times")
+}
+
+// triageModel is the Bubble Tea model behind `cssgen triage`
+type triageModel struct {
+	lint      func() (*cssgen.LintResult, error) // Re-run after every applied fix
+	useColors bool
+
+	result *cssgen.LintResult
+	wins   []cssgen.QuickWin // Fixable wins, single-class first; Occurrences counts fixable ones
+	cursor int
+	status string // Outcome of the last action
+	err    error  // Fatal error; the program quits with it
+}
+
+// newTriageModel lints once so the first frame already lists the Quick Wins
+func newTriageModel(lint func() (*cssgen.LintResult, error), useColors bool) (triageModel, error) {
+	m := triageModel{lint: lint, useColors: useColors}
+	if err := m.refresh(); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+func (m triageModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m triageModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch key.String() {
+	case "q", "esc", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.wins)-1 {
+			m.cursor++
+		}
+	case "enter", "f":
+		if err := m.apply(); err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+	case "r":
+		if err := m.refresh(); err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+		m.status = "Re-linted"
+	}
+	return m, nil
+}
+
+// apply rewrites every occurrence of the selected Quick Win, then re-lints
+func (m *triageModel) apply() error {
+	if len(m.wins) == 0 {
+		return nil
+	}
+	win := m.wins[m.cursor]
+
+	fixResult, err := cssgen.ApplyFixes(cssgen.QuickWinIssues(m.result.Issues, win), false)
+	if err != nil {
+		return err
+	}
+	if err := m.refresh(); err != nil {
+		return err
+	}
+
+	m.status = fmt.Sprintf("Replaced %d × %q with %s", len(fixResult.Applied), win.ClassName, win.Suggestion)
+	if skipped := len(fixResult.Skipped); skipped > 0 {
+		m.status += fmt.Sprintf(" (%d skipped)", skipped)
+	}
+	return nil
+}
+
+// refresh re-lints and keeps the cursor on the list
+func (m *triageModel) refresh() error {
+	result, err := m.lint()
+	if err != nil {
+		return err
+	}
+	m.result = result
+
+	// A win stays listed until every occurrence --fix can rewrite is gone
+	m.wins = nil
+	for _, win := range append(append([]cssgen.QuickWin(nil), result.QuickWins.SingleClass...), result.QuickWins.MultiClass...) {
+		if fixable := len(cssgen.QuickWinIssues(result.Issues, win)); fixable > 0 {
+			win.Occurrences = fixable
+			m.wins = append(m.wins, win)
+		}
+	}
+	if m.cursor >= len(m.wins) {
+		m.cursor = max(len(m.wins)-1, 0)
+	}
+	return nil
+}
+
+func (m triageModel) View() string {
+	var b strings.Builder
+	b.WriteString(cssgen.RenderStyle(cssgen.StyleCyan, "Quick Wins", m.useColors))
+	b.WriteString("\n\n")
+
+	if len(m.wins) == 0 {
+		b.WriteString(cssgen.RenderStyle(cssgen.StyleGreen, "No Quick Wins left", m.useColors))
+		b.WriteString("\n")
+	}
+	for i, win := range m.wins {
+		line := fmt.Sprintf("%4d× %q -> %s", win.Occurrences, win.ClassName, win.Suggestion)
+		if i == m.cursor {
+			b.WriteString(cssgen.RenderStyle(cssgen.StyleGreen, "> "+line, m.useColors))
+		} else {
+			b.WriteString("  " + line)
+		}
+		b.WriteString("\n")
+	}
+
+	if m.status != "" {
+		b.WriteString("\n" + m.status + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(cssgen.RenderStyle(cssgen.StyleGray, "↑/↓ move • enter apply • r re-lint • q quit", m.useColors))
+	b.WriteString("\n")
+	return b.String()
+}
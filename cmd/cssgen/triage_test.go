@@ -0,0 +1,53 @@
+//go:build tui
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+func TestTriageApplyQuickWin(t *testing.T) {
+	fx := newLintFixture(t)
+	page := filepath.Join(fx.dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package test\n\ntempl P() {\n\t<div class=\"btn\"></div>\n\t<a class=\"btn\"></a>\n\t<p class='btn'></p>\n}\n"), 0644))
+
+	config := cssgen.LintConfig{
+		GeneratedFile: filepath.Join(fx.outputDir, "styles.gen.go"),
+		PackageName:   "ui",
+		ScanPaths:     []string{page},
+	}
+	model, err := newTriageModel(func() (*cssgen.LintResult, error) {
+		return cssgen.Lint(config)
+	}, false)
+	require.NoError(t, err)
+	require.Len(t, model.wins, 1)
+	assert.Equal(t, "btn", model.wins[0].ClassName)
+	assert.Equal(t, 2, model.wins[0].Occurrences, "only occurrences --fix can rewrite count")
+	assert.Contains(t, model.View(), `>    2× "btn" -> ui.Btn`)
+
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	m := updated.(triageModel)
+	require.NoError(t, m.err)
+
+	content, err := os.ReadFile(page)
+	require.NoError(t, err)
+	assert.Equal(t, "package test\n\ntempl P() {\n\t<div class={ ui.Btn }></div>\n\t<a class={ ui.Btn }></a>\n\t<p class='btn'></p>\n}\n", string(content))
+
+	// The list is refreshed from the rewritten files; what's left can't be fixed
+	require.Len(t, m.result.QuickWins.SingleClass, 1)
+	assert.Empty(t, m.wins)
+	assert.Contains(t, m.status, `Replaced 2 × "btn" with ui.Btn`)
+	assert.Contains(t, m.View(), "No Quick Wins left")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.Equal(t, tea.Quit(), cmd())
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yacobolo/cssgen/internal/cssgen"
+)
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "Check that committed generated files are up to date",
+	Long: `Regenerate into a temporary directory and compare the result with the
+styles*.gen.go files in the output directory. Prints a diff and exits 1 when
+they differ, like "go generate && git diff --exit-code" without touching the tree.
+The "// Generated:" timestamp in the header is ignored.`,
+	PreRunE: func(cmd *cobra.Command, _ []string) error {
+		return loadConfig(cmd)
+	},
+	RunE: func(_ *cobra.Command, _ []string) error {
+		config := buildGenerateConfig()
+
+		result, err := cssgen.Verify(config)
+		if err != nil {
+			return withExitCode(exitIO, fmt.Errorf("verify failed: %w", err))
+		}
+
+		quiet := getBoolWithFallback("quiet", "quiet", false)
+		if result.UpToDate() {
+			if !quiet {
+				fmt.Printf("Generated files in %s are up to date\n", config.OutputDir)
+			}
+			return nil
+		}
+
+		if !quiet {
+			cssgen.PrintVerifyResult(os.Stdout, result)
+			fmt.Fprintf(os.Stderr, "\n%d generated file(s) out of date (run: cssgen generate)\n", len(result.Stale))
+		}
+		return &exitError{code: exitPolicy}
+	},
+}
+
+func init() {
+	f := verifyCmd.Flags()
+	f.String("source", "web/ui/src/styles", "Source CSS directory")
+	f.String("output-dir", "internal/web/ui", "Directory holding the committed generated files")
+	f.StringSlice("include", nil, "Glob patterns for CSS files to include")
+}
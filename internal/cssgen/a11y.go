@@ -0,0 +1,82 @@
+package cssgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// screenReaderClassMarkers identify classes meant to hide content visually only
+var screenReaderClassMarkers = []string{"sr-only", "visually-hidden"}
+
+// hiddenDeclarations remove an element from the accessibility tree
+var hiddenDeclarations = [][2]string{
+	{"display", "none"},
+	{"visibility", "hidden"},
+}
+
+// a11yHiddenIssues reports screen-reader-only classes combined with a class that
+// hides the element entirely, so screen readers skip it too (experimental)
+func a11yHiddenIssues(ref ClassReference, properties map[string]map[string]string) []Issue {
+	tokens := uniqueTokens(ref.FullClassValue)
+	if len(tokens) < 2 || len(properties) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	for _, srClass := range tokens {
+		if !isScreenReaderClass(srClass) {
+			continue
+		}
+
+		for _, other := range tokens {
+			if other == srClass {
+				continue
+			}
+			decl := hidingDeclaration(properties[other])
+			if decl == "" {
+				continue
+			}
+
+			column := findClassColumn(ref.Location.Text, srClass)
+			if column == 0 {
+				column = ref.Location.Column
+			}
+			issues = append(issues, Issue{
+				FromLinter:  "csslint",
+				Rule:        RuleA11yHidden,
+				Text:        fmt.Sprintf(IssueA11yHidden, srClass, other, decl),
+				Severity:    SeverityInfo,
+				SourceLines: []string{ref.Location.Text},
+				Pos: IssuePos{
+					Filename: ref.Location.File,
+					Line:     ref.Location.Line,
+					Column:   column,
+				},
+			})
+			break
+		}
+	}
+
+	return issues
+}
+
+// isScreenReaderClass reports whether class names a visually-hidden utility
+func isScreenReaderClass(class string) bool {
+	for _, marker := range screenReaderClassMarkers {
+		if strings.Contains(class, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// hidingDeclaration returns the declaration in props that hides an element from
+// assistive technology, e.g. "display: none" ("" if none)
+func hidingDeclaration(props map[string]string) string {
+	for _, decl := range hiddenDeclarations {
+		if value, ok := props[decl[0]]; ok && strings.TrimSpace(value) == decl[1] {
+			return decl[0] + ": " + decl[1]
+		}
+	}
+	return ""
+}
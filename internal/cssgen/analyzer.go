@@ -10,6 +10,11 @@ import (
 
 // AnalyzeClasses builds inheritance graph and resolves full class names
 func AnalyzeClasses(classes []*CSSClass) error {
+	return analyzeClasses(classes, defaultBEM)
+}
+
+// analyzeClasses is AnalyzeClasses with a custom BEM naming convention
+func analyzeClasses(classes []*CSSClass, bem bemConvention) error {
 	// Build a map for quick lookup
 	classMap := make(map[string]*CSSClass)
 	for _, class := range classes {
@@ -18,7 +23,8 @@ func AnalyzeClasses(classes []*CSSClass) error {
 
 	// Detect BEM patterns and link parent classes
 	for _, class := range classes {
-		base, isModifier := detectBEMPattern(class.Name)
+		base, kind := bem.detect(class.Name)
+		isModifier := kind != bemNone
 
 		if isModifier && base != "" {
 			// Link to parent class
@@ -96,28 +102,72 @@ func DiffProperties(modifier, base *CSSClass) *PropertyDiff {
 	return diff
 }
 
-// detectBEMPattern identifies base class from modifier naming
-func detectBEMPattern(className string) (base string, isModifier bool) {
-	// Standard BEM modifier: btn--primary
-	if strings.Contains(className, "--") {
-		parts := strings.Split(className, "--")
-		return parts[0], true
+// bemKind is how a class name relates to its base class
+type bemKind int
+
+const (
+	bemNone     bemKind = iota // Utility or base class
+	bemModifier                // btn--primary
+	bemElement                 // card__header
+)
+
+// bemConvention holds the separators that mark modifiers and elements
+type bemConvention struct {
+	modifier string // "--"
+	element  string // "__"
+}
+
+// defaultBEM is standard BEM naming: block__element--modifier
+var defaultBEM = bemConvention{modifier: "--", element: "__"}
+
+// newBEMConvention applies defaults to empty separators and rejects identical ones
+func newBEMConvention(modifier, element string) (bemConvention, error) {
+	bem := defaultBEM
+	if modifier != "" {
+		bem.modifier = modifier
+	}
+	if element != "" {
+		bem.element = element
+	}
+	if bem.modifier == bem.element {
+		return bem, fmt.Errorf("modifier and element separators must differ (both %q)", bem.modifier)
+	}
+	return bem, nil
+}
+
+// detect splits className at the first separator it contains
+// The longer separator is checked first (so _ modifiers don't swallow __ elements);
+// on equal length modifiers win, so card__header--active is a modifier
+func (b bemConvention) detect(className string) (base string, kind bemKind) {
+	type separator struct {
+		sep  string
+		kind bemKind
+	}
+	order := []separator{{b.modifier, bemModifier}, {b.element, bemElement}}
+	if len(b.element) > len(b.modifier) {
+		order[0], order[1] = order[1], order[0]
 	}
 
-	// BEM element: card__header
-	if strings.Contains(className, "__") {
-		parts := strings.Split(className, "__")
-		return parts[0], true
+	for _, s := range order {
+		if idx := strings.Index(className, s.sep); idx != -1 {
+			return className[:idx], s.kind
+		}
 	}
 
 	// No BEM pattern: utility class
-	return "", false
+	return "", bemNone
+}
+
+// detectBEMPattern identifies base class from modifier naming (standard BEM)
+func detectBEMPattern(className string) (base string, isModifier bool) {
+	base, kind := defaultBEM.detect(className)
+	return base, kind != bemNone
 }
 
 // mergeConflicts handles duplicate class names across files
-func mergeConflicts(classes []*CSSClass) ([]*CSSClass, []string) {
+func mergeConflicts(classes []*CSSClass) ([]*CSSClass, GenerateWarnings) {
 	classMap := make(map[string]*CSSClass)
-	warnings := []string{}
+	warnings := GenerateWarnings{}
 
 	for _, class := range classes {
 		existing, found := classMap[class.Name]
@@ -139,11 +189,57 @@ func mergeConflicts(classes []*CSSClass) ([]*CSSClass, []string) {
 			}
 		}
 
-		// Warn about conflict
-		warnings = append(warnings, fmt.Sprintf(
-			"Duplicate class '%s' found in %s and %s - properties merged",
-			class.Name, existing.SourceFile, class.SourceFile,
-		))
+		// Merge pseudo-elements
+		for _, pe := range class.PseudoElements {
+			if !contains(existing.PseudoElements, pe) {
+				existing.PseudoElements = append(existing.PseudoElements, pe)
+			}
+		}
+
+		// Merge container queries
+		for _, cs := range class.ContainerStates {
+			if !contains(existing.ContainerStates, cs) {
+				existing.ContainerStates = append(existing.ContainerStates, cs)
+			}
+		}
+
+		// Merge media queries
+		for _, ms := range class.MediaStates {
+			if !contains(existing.MediaStates, ms) {
+				existing.MediaStates = append(existing.MediaStates, ms)
+			}
+		}
+		existing.OutsideMedia = existing.OutsideMedia || class.OutsideMedia
+
+		// Merge compound-selector usage
+		existing.UsedAlone = existing.UsedAlone || class.UsedAlone
+		for _, partner := range class.CompoundWith {
+			if !contains(existing.CompoundWith, partner) {
+				existing.CompoundWith = append(existing.CompoundWith, partner)
+			}
+		}
+
+		// The same class in two layers: which rule wins depends on the cascade order
+		if class.Layer != "" && existing.Layer != "" && class.Layer != existing.Layer {
+			if !contains(existing.ConflictingLayers, class.Layer) {
+				existing.ConflictingLayers = append(existing.ConflictingLayers, class.Layer)
+			}
+			warnings = append(warnings, GenerateWarning{
+				Message: fmt.Sprintf("Class '%s' is in @layer %s here but @layer %s at %s - properties merged, keeping @layer %s",
+					class.Name, class.Layer, existing.Layer, sourceLocation(existing), existing.Layer),
+				File: class.SourceFile,
+				Line: class.SourceLine,
+			})
+			continue
+		}
+
+		// Warn about conflict at the duplicate, citing the first definition
+		warnings = append(warnings, GenerateWarning{
+			Message: fmt.Sprintf("Duplicate class '%s' (first defined at %s) - properties merged",
+				class.Name, sourceLocation(existing)),
+			File: class.SourceFile,
+			Line: class.SourceLine,
+		})
 	}
 
 	// Convert map back to slice
@@ -155,6 +251,44 @@ func mergeConflicts(classes []*CSSClass) ([]*CSSClass, []string) {
 	return result, warnings
 }
 
+// sourceLocation renders where a class is defined as file:line (file alone when the line is unknown)
+func sourceLocation(class *CSSClass) string {
+	if class.SourceLine > 0 {
+		return fmt.Sprintf("%s:%d", class.SourceFile, class.SourceLine)
+	}
+	return class.SourceFile
+}
+
+// mergeIDs deduplicates IDs across files and assigns ID-prefixed Go names
+func mergeIDs(ids []*CSSClass) []*CSSClass {
+	idMap := make(map[string]*CSSClass)
+	for _, id := range ids {
+		existing, found := idMap[id.Name]
+		if !found {
+			idMap[id.Name] = id
+			continue
+		}
+		for k, v := range id.Properties {
+			existing.Properties[k] = v
+		}
+	}
+
+	result := make([]*CSSClass, 0, len(idMap))
+	for _, id := range idMap {
+		id.GoName = "ID" + toGoName(id.Name)
+		result = append(result, id)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GoName < result[j].GoName
+	})
+
+	return result
+}
+
+// digitNamePrefix keeps constants for classes like 2xl exported and valid
+const digitNamePrefix = "C"
+
 // toGoName converts kebab-case to PascalCase
 func toGoName(className string) string {
 	// Remove leading dot if present
@@ -181,6 +315,11 @@ func toGoName(className string) string {
 
 	result := strings.Join(parts, "")
 
+	// Identifiers can't start with a digit (3d-flip -> C3dFlip)
+	if result != "" && unicode.IsDigit(rune(result[0])) {
+		result = digitNamePrefix + result
+	}
+
 	// Add underscore prefix back for internal classes
 	if isInternal {
 		result = "_" + result
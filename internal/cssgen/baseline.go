@@ -0,0 +1,114 @@
+package cssgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Baseline holds the fingerprints of previously accepted issues
+// Counts are kept per fingerprint so repeated identical issues are matched one-to-one
+type Baseline struct {
+	Fingerprints map[string]int
+}
+
+// Fingerprint returns a stable ID for the issue
+// Line and column are excluded so unrelated edits above an issue don't invalidate it
+func (i Issue) Fingerprint() string {
+	source := ""
+	if len(i.SourceLines) > 0 {
+		source = i.SourceLines[0]
+	}
+	return fingerprint(i.FromLinter, i.Pos.Filename, i.Text, source)
+}
+
+// fingerprint hashes the stable parts of an issue
+func fingerprint(linter, filename, text, source string) string {
+	h := sha256.New()
+	for _, part := range []string{
+		linter,
+		strings.ReplaceAll(filename, "\\", "/"),
+		text,
+		strings.TrimSpace(source),
+	} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// LoadBaseline reads a JSON report (from --output-format json) and collects its issue fingerprints
+func LoadBaseline(path string) (*Baseline, error) {
+	output, err := LoadJSONOutput(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	baseline := &Baseline{Fingerprints: make(map[string]int)}
+	for _, issue := range output.Issues {
+		baseline.Fingerprints[issue.ID()]++
+	}
+
+	return baseline, nil
+}
+
+// LoadJSONOutput reads a report written with --output-format json
+func LoadJSONOutput(path string) (*JSONOutput, error) {
+	// #nosec G304 - path comes from trusted configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var output JSONOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return &output, nil
+}
+
+// ID returns the issue's fingerprint
+// Older reports without fingerprints are recomputed from the stored fields
+func (i JSONIssue) ID() string {
+	if i.Fingerprint != "" {
+		return i.Fingerprint
+	}
+	return fingerprint(i.Linter, i.File, i.Message, i.Source)
+}
+
+// ApplyBaseline removes issues already present in the baseline
+// ErrorCount is recomputed so the exit code reflects only the new issues
+func ApplyBaseline(result *LintResult, baseline *Baseline) {
+	remaining := make(map[string]int, len(baseline.Fingerprints))
+	for id, count := range baseline.Fingerprints {
+		remaining[id] = count
+	}
+
+	var fresh []Issue
+	suppressed := 0
+	for _, issue := range result.Issues {
+		id := issue.Fingerprint()
+		if remaining[id] > 0 {
+			remaining[id]--
+			suppressed++
+			continue
+		}
+		fresh = append(fresh, issue)
+	}
+
+	result.Issues = fresh
+	result.BaselineApplied = true
+	result.BaselineSuppressed = suppressed
+
+	result.ErrorCount = 0
+	result.IssuesByCategory = make(map[string][]Issue)
+	for _, issue := range fresh {
+		if issue.Severity == SeverityError {
+			result.ErrorCount++
+		}
+		result.IssuesByCategory[issue.Severity] = append(result.IssuesByCategory[issue.Severity], issue)
+	}
+}
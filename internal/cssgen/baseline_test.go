@@ -0,0 +1,137 @@
+package cssgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueFingerprintIgnoresPosition(t *testing.T) {
+	issue := Issue{
+		FromLinter:  "csslint",
+		Text:        `invalid CSS class "btn--typo" not found in stylesheet`,
+		SourceLines: []string{`<div class="btn--typo">`},
+		Pos:         IssuePos{Filename: "page.templ", Line: 3, Column: 13},
+	}
+	moved := issue
+	moved.Pos.Line = 10
+	moved.SourceLines = []string{`		<div class="btn--typo">`}
+
+	assert.Equal(t, issue.Fingerprint(), moved.Fingerprint())
+
+	other := issue
+	other.Pos.Filename = "other.templ"
+	assert.NotEqual(t, issue.Fingerprint(), other.Fingerprint())
+}
+
+func TestLintNewOnlyWithBaseline(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+}
+
+const Btn = "btn"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	writeTempl := func(body string) {
+		require.NoError(t, os.WriteFile(templFile, []byte("package test\n\ntempl Page() {\n"+body+"}\n"), 0644))
+	}
+
+	cleanFile := filepath.Join(tmpDir, "clean.templ")
+	require.NoError(t, os.WriteFile(cleanFile, []byte("package test\n\ntempl Clean() {\n\t<div class={ ui.Btn }></div>\n}\n"), 0644))
+
+	config := LintConfig{
+		ScanPaths:     []string{templFile, cleanFile},
+		GeneratedFile: generatedFile,
+		PackageName:   "ui",
+	}
+
+	// Record the baseline with a single known issue
+	writeTempl("\t<div class=\"old-typo\"></div>\n")
+	before, err := Lint(config)
+	require.NoError(t, err)
+	require.Len(t, before.Issues, 1)
+
+	baselineFile := filepath.Join(tmpDir, "baseline.json")
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, before))
+	require.NoError(t, os.WriteFile(baselineFile, buf.Bytes(), 0644))
+
+	// Introduce a new issue next to the existing one
+	writeTempl("\t<span class=\"new-typo\"></span>\n\t<div class=\"old-typo\"></div>\n")
+	config.BaselineFile = baselineFile
+	config.NewOnly = true
+
+	result, err := Lint(config)
+	require.NoError(t, err)
+
+	require.Len(t, result.Issues, 1)
+	assert.Contains(t, result.Issues[0].Text, "new-typo")
+	assert.Equal(t, 1, result.BaselineSuppressed)
+	assert.Equal(t, 1, result.ErrorCount)
+
+	var out bytes.Buffer
+	NewReporter(&out, config).PrintBaselineHeader(*result)
+	assert.Equal(t, "1 new issue (1 suppressed by baseline)\n", out.String())
+
+	// Health is scored before the baseline drops accepted issues
+	config.NewOnly = false
+	full, err := Lint(config)
+	require.NoError(t, err)
+	assert.Equal(t, full.HealthScore, result.HealthScore)
+}
+
+func TestBaselineSurvivesMovedDefinition(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	require.NoError(t, os.MkdirAll(srcDir, 0755))
+	css := filepath.Join(srcDir, "button.css")
+	generate := func(content string) {
+		require.NoError(t, os.WriteFile(css, []byte(content), 0644))
+		_, err := Generate(Config{SourceDir: srcDir, OutputDir: dir, PackageName: "ui", Includes: []string{"*.css"}})
+		require.NoError(t, err)
+	}
+
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`<button class="btn--primray"></button>`+"\n"), 0644))
+	config := LintConfig{
+		GeneratedFile: filepath.Join(dir, "styles.gen.go"),
+		PackageName:   "ui",
+		ScanPaths:     []string{page},
+		Only:          []string{RuleInvalidClass},
+	}
+
+	// Record the baseline while the hint points at line 1
+	generate(".btn--primary { color: blue; }\n")
+	before, err := Lint(config)
+	require.NoError(t, err)
+	require.Len(t, before.Issues, 1)
+	assert.Contains(t, before.Issues[0].Message(), "defined at button.css:1")
+
+	baselineFile := filepath.Join(dir, "baseline.json")
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSON(&buf, before))
+	require.NoError(t, os.WriteFile(baselineFile, buf.Bytes(), 0644))
+	baseline, err := LoadBaseline(baselineFile)
+	require.NoError(t, err)
+
+	// A rule added above moves the definition; the issue is still the baselined one
+	generate(".btn { color: red; }\n\n.btn--primary { color: blue; }\n")
+	after, err := Lint(config)
+	require.NoError(t, err)
+	require.Len(t, after.Issues, 1)
+	assert.Contains(t, after.Issues[0].Message(), "defined at button.css:3")
+
+	ApplyBaseline(after, baseline)
+	assert.Empty(t, after.Issues)
+	assert.Equal(t, 1, after.BaselineSuppressed)
+}
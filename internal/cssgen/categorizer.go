@@ -1,6 +1,7 @@
 package cssgen
 
 import (
+	"fmt"
 	"sort"
 	"strings"
 )
@@ -150,18 +151,36 @@ var propertyCategories = map[string]PropertyCategory{
 	"mask":                       CategoryEffects,
 }
 
+// defaultInternalPrefixes mark vendor-prefixed properties as Internal
+var defaultInternalPrefixes = []string{"-webkit-", "-moz-", "-ms-", "-o-"}
+
 // categorizeProperty determines the category of a CSS property
-func categorizeProperty(name string) PropertyCategory {
+// Overrides (Config.CategoryOverrides) win over the built-in table and prefix rules
+func categorizeProperty(name string, config Config) PropertyCategory {
+	if cat, exists := config.CategoryOverrides[name]; exists {
+		return cat
+	}
+
 	// Check exact match
 	if cat, exists := propertyCategories[name]; exists {
 		return cat
 	}
 
 	// Check prefixes for internal properties
-	if strings.HasPrefix(name, "-webkit-") ||
-		strings.HasPrefix(name, "-moz-") ||
-		strings.HasPrefix(name, "-ms-") ||
-		strings.HasPrefix(name, "-o-") {
+	prefixes := config.InternalPrefixes
+	if prefixes == nil {
+		prefixes = defaultInternalPrefixes
+	}
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// -webkit-transform is a transform; unknown prefixed properties stay Internal
+		if config.UnprefixVendor {
+			if cat, exists := propertyCategories[strings.TrimPrefix(name, prefix)]; exists {
+				return cat
+			}
+		}
 		return CategoryInternal
 	}
 
@@ -184,17 +203,50 @@ func categorizeProperty(name string) PropertyCategory {
 	return CategoryLayout
 }
 
+// ParsePropertyCategory matches a category name case-insensitively ("layout" -> CategoryLayout)
+func ParsePropertyCategory(name string) (PropertyCategory, bool) {
+	for _, cat := range []PropertyCategory{
+		CategoryVisual, CategoryLayout, CategoryTypography,
+		CategoryEffects, CategoryTokens, CategoryInternal,
+	} {
+		if strings.EqualFold(name, string(cat)) {
+			return cat, true
+		}
+	}
+	return "", false
+}
+
+// validateCategoryOverrides rejects overrides pointing at unknown categories
+func validateCategoryOverrides(overrides map[string]PropertyCategory) error {
+	for prop, cat := range overrides {
+		if _, ok := ParsePropertyCategory(string(cat)); !ok {
+			return fmt.Errorf("category override for %q: unknown category %q", prop, cat)
+		}
+	}
+	return nil
+}
+
+// validateInternalPrefixes rejects prefixes that would mark every property Internal
+func validateInternalPrefixes(prefixes []string) error {
+	for _, prefix := range prefixes {
+		if strings.TrimSpace(prefix) == "" {
+			return fmt.Errorf("internal prefixes: empty prefix would hide every property")
+		}
+	}
+	return nil
+}
+
 // isTokenValue checks if a value uses design tokens
 func isTokenValue(value string) bool {
 	return strings.Contains(value, "var(--ui-")
 }
 
 // categorizeProperties groups properties by category
-func categorizeProperties(props map[string]string) map[PropertyCategory][]CategorizedProperty {
+func categorizeProperties(props map[string]string, config Config) map[PropertyCategory][]CategorizedProperty {
 	result := make(map[PropertyCategory][]CategorizedProperty)
 
 	for name, value := range props {
-		cat := categorizeProperty(name)
+		cat := categorizeProperty(name, config)
 		prop := CategorizedProperty{
 			Name:     name,
 			Value:    value,
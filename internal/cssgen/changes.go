@@ -0,0 +1,136 @@
+package cssgen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// GenerateChanges lists how the generated classes differ from the previous generation
+type GenerateChanges struct {
+	Added   []string      `json:"added"`
+	Removed []string      `json:"removed"`
+	Renamed []ClassRename `json:"renamed"`
+}
+
+// ClassRename pairs a removed class with the added class that most likely replaced it
+type ClassRename struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Empty reports whether nothing changed
+func (c *GenerateChanges) Empty() bool {
+	return c == nil || len(c.Added)+len(c.Removed)+len(c.Renamed) == 0
+}
+
+// Lines renders the changes as "+ added", "- removed" and "~ old -> new" lines
+func (c *GenerateChanges) Lines() []string {
+	if c == nil {
+		return nil
+	}
+	var lines []string
+	for _, class := range c.Added {
+		lines = append(lines, "+ "+class)
+	}
+	for _, class := range c.Removed {
+		lines = append(lines, "- "+class)
+	}
+	for _, rename := range c.Renamed {
+		lines = append(lines, fmt.Sprintf("~ %s -> %s", rename.From, rename.To))
+	}
+	return lines
+}
+
+// previousConstants reads the constants of the generated files in outputDir before
+// they are overwritten (nil if there is no previous generation)
+func previousConstants(outputDir string) (map[string]string, error) {
+	baseFile := filepath.Join(outputDir, "styles.gen.go")
+	if _, err := os.Stat(baseFile); os.IsNotExist(err) {
+		return nil, nil
+	}
+	constants, _, err := ParseGeneratedFile(baseFile)
+	var duplicates *DuplicateConstantsError
+	if errors.As(err, &duplicates) {
+		// Generating replaces the broken files; there is nothing sound to compare against
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading previous generation: %w", err)
+	}
+	return constants, nil
+}
+
+// diffConstants compares the previous constants (GoName -> class) with the classes
+// about to be generated. A removed class counts as renamed when an added class has
+// its constant name, or else is within maxSuggestionDistance edits of it.
+func diffConstants(previous map[string]string, classes []*CSSClass) *GenerateChanges {
+	oldClasses := make(map[string]string, len(previous)) // class -> GoName
+	for goName, class := range previous {
+		oldClasses[class] = goName
+	}
+	newClasses := make(map[string]string, len(classes))
+	for _, class := range classes {
+		newClasses[class.Name] = class.GoName
+	}
+
+	var added, removed []string
+	for class := range newClasses {
+		if _, ok := oldClasses[class]; !ok {
+			added = append(added, class)
+		}
+	}
+	for class := range oldClasses {
+		if _, ok := newClasses[class]; !ok {
+			removed = append(removed, class)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	changes := &GenerateChanges{Added: []string{}, Removed: []string{}, Renamed: []ClassRename{}}
+	paired := make(map[string]bool)
+
+	// Same constant, different class: the Go API is unchanged but the class moved
+	addedByGoName := make(map[string]string, len(added))
+	for _, class := range added {
+		addedByGoName[newClasses[class]] = class
+	}
+	var unpaired []string
+	for _, class := range removed {
+		if to, ok := addedByGoName[oldClasses[class]]; ok && !paired[to] {
+			changes.Renamed = append(changes.Renamed, ClassRename{From: class, To: to})
+			paired[to] = true
+			continue
+		}
+		unpaired = append(unpaired, class)
+	}
+
+	// Otherwise pair with the nearest added class
+	candidates := make(map[string]bool, len(added))
+	for _, class := range added {
+		if !paired[class] {
+			candidates[class] = true
+		}
+	}
+	idx := newClassIndex(candidates)
+	for _, class := range unpaired {
+		if to := idx.nearest(class); to != "" && !paired[to] {
+			changes.Renamed = append(changes.Renamed, ClassRename{From: class, To: to})
+			paired[to] = true
+			continue
+		}
+		changes.Removed = append(changes.Removed, class)
+	}
+
+	for _, class := range added {
+		if !paired[class] {
+			changes.Added = append(changes.Added, class)
+		}
+	}
+	sort.Slice(changes.Renamed, func(i, j int) bool { return changes.Renamed[i].From < changes.Renamed[j].From })
+
+	return changes
+}
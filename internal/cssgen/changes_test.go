@@ -0,0 +1,58 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateChanges(t *testing.T) {
+	tmpDir := t.TempDir()
+	cssFile := filepath.Join(tmpDir, "app.css")
+	config := Config{
+		SourceDir:     tmpDir,
+		OutputDir:     tmpDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "compact",
+		PropertyLimit: 5,
+	}
+
+	require.NoError(t, os.WriteFile(cssFile, []byte(`.btn { color: red; }
+.btn--primay { color: blue; }
+.card { padding: 1rem; }
+`), 0644))
+	result, err := Generate(config)
+	require.NoError(t, err)
+	assert.Nil(t, result.Changes, "first generation has nothing to compare against")
+
+	require.NoError(t, os.WriteFile(cssFile, []byte(`.btn { color: red; }
+.btn--primary { color: blue; }
+.badge { color: green; }
+`), 0644))
+	result, err = Generate(config)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Changes)
+	assert.Equal(t, []string{"badge"}, result.Changes.Added)
+	assert.Equal(t, []string{"card"}, result.Changes.Removed)
+	assert.Equal(t, []ClassRename{{From: "btn--primay", To: "btn--primary"}}, result.Changes.Renamed)
+	assert.Equal(t, []string{"+ badge", "- card", "~ btn--primay -> btn--primary"}, result.Changes.Lines())
+
+	result, err = Generate(config)
+	require.NoError(t, err)
+	assert.True(t, result.Changes.Empty())
+}
+
+func TestDiffConstantsSameGoName(t *testing.T) {
+	changes := diffConstants(
+		map[string]string{"BtnPrimary": "btn_primary"},
+		[]*CSSClass{{Name: "btn--primary", GoName: "BtnPrimary"}},
+	)
+	assert.Equal(t, []ClassRename{{From: "btn_primary", To: "btn--primary"}}, changes.Renamed)
+	assert.Empty(t, changes.Added)
+	assert.Empty(t, changes.Removed)
+}
@@ -0,0 +1,150 @@
+package cssgen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ReportComparison is the delta between two JSON lint reports
+type ReportComparison struct {
+	Old             JSONOutput
+	New             JSONOutput
+	NewIssues       []JSONIssue      // In the new report but not the old one (by fingerprint)
+	ResolvedIssues  []JSONIssue      // In the old report but not the new one
+	QuickWinChanges []QuickWinChange // Quick Wins whose occurrence count changed
+}
+
+// QuickWinChange tracks one Quick Win across two reports (0 = absent)
+type QuickWinChange struct {
+	Class      string
+	Suggestion string
+	Before     int
+	After      int
+}
+
+// CompareReports matches issues by fingerprint, counting duplicates separately
+func CompareReports(before, after JSONOutput) ReportComparison {
+	return ReportComparison{
+		Old:             before,
+		New:             after,
+		NewIssues:       subtractIssues(after.Issues, before.Issues),
+		ResolvedIssues:  subtractIssues(before.Issues, after.Issues),
+		QuickWinChanges: compareQuickWins(before.QuickWins, after.QuickWins),
+	}
+}
+
+// subtractIssues returns the issues in from that are not matched by one in other
+func subtractIssues(from, other []JSONIssue) []JSONIssue {
+	remaining := make(map[string]int, len(other))
+	for _, issue := range other {
+		remaining[issue.ID()]++
+	}
+
+	var diff []JSONIssue
+	for _, issue := range from {
+		id := issue.ID()
+		if remaining[id] > 0 {
+			remaining[id]--
+			continue
+		}
+		diff = append(diff, issue)
+	}
+	return diff
+}
+
+// compareQuickWins lists Quick Wins that appeared, disappeared, or changed count
+func compareQuickWins(before, after JSONQuickWins) []QuickWinChange {
+	changes := make(map[string]*QuickWinChange)
+	entry := func(win JSONQuickWin) *QuickWinChange {
+		change, ok := changes[win.Class]
+		if !ok {
+			change = &QuickWinChange{Class: win.Class, Suggestion: win.Suggestion}
+			changes[win.Class] = change
+		}
+		return change
+	}
+
+	for _, wins := range [][]JSONQuickWin{before.SingleClass, before.MultiClass} {
+		for _, win := range wins {
+			entry(win).Before = win.Occurrences
+		}
+	}
+	for _, wins := range [][]JSONQuickWin{after.SingleClass, after.MultiClass} {
+		for _, win := range wins {
+			change := entry(win)
+			change.After = win.Occurrences
+			change.Suggestion = win.Suggestion
+		}
+	}
+
+	var result []QuickWinChange
+	for _, change := range changes {
+		if change.Before != change.After {
+			result = append(result, *change)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Class < result[j].Class
+	})
+	return result
+}
+
+// WriteComparisonMarkdown renders the comparison as a Markdown PR comment
+func WriteComparisonMarkdown(w io.Writer, cmp ReportComparison) {
+	oldStats, newStats := cmp.Old.Stats, cmp.New.Stats
+	oldSummary, newSummary := cmp.Old.Summary, cmp.New.Summary
+
+	fmt.Fprintf(w, "# CSS Linter Report Comparison\n\n")
+	fmt.Fprintf(w, "**%s, %s**\n\n",
+		pluralizeCount(len(cmp.ResolvedIssues), "issue resolved", "issues resolved"),
+		pluralizeCount(len(cmp.NewIssues), "new issue", "new issues"))
+
+	fmt.Fprintf(w, "| Metric | Before | After | Change |\n")
+	fmt.Fprintf(w, "|--------|--------|-------|--------|\n")
+	fmt.Fprintf(w, "| **Adoption Rate** | %.1f%% | %.1f%% | %+.1f pts |\n",
+		oldStats.UsagePercentage, newStats.UsagePercentage, newStats.UsagePercentage-oldStats.UsagePercentage)
+	fmt.Fprintf(w, "| **Errors** | %d | %d | %+d |\n", oldSummary.Errors, newSummary.Errors, newSummary.Errors-oldSummary.Errors)
+	fmt.Fprintf(w, "| **Warnings** | %d | %d | %+d |\n", oldSummary.Warnings, newSummary.Warnings, newSummary.Warnings-oldSummary.Warnings)
+	fmt.Fprintf(w, "| **Hardcoded Classes** | %d | %d | %+d |\n",
+		oldStats.HardcodedClasses, newStats.HardcodedClasses, newStats.HardcodedClasses-oldStats.HardcodedClasses)
+	fmt.Fprintf(w, "| **Constants Used** | %d / %d | %d / %d | %+d |\n",
+		oldStats.ActuallyUsed, oldStats.TotalConstants, newStats.ActuallyUsed, newStats.TotalConstants,
+		newStats.ActuallyUsed-oldStats.ActuallyUsed)
+	fmt.Fprintf(w, "\n")
+
+	writeIssueTable(w, "## ✅ Resolved Issues", cmp.ResolvedIssues)
+	writeIssueTable(w, "## 🆕 New Issues", cmp.NewIssues)
+
+	if len(cmp.QuickWinChanges) > 0 {
+		fmt.Fprintf(w, "## 🎯 Quick Win Changes\n\n")
+		fmt.Fprintf(w, "| Class | Before | After | Suggestion |\n")
+		fmt.Fprintf(w, "|-------|--------|-------|------------|\n")
+		for _, change := range cmp.QuickWinChanges {
+			fmt.Fprintf(w, "| `%s` | %d | %d | `%s` |\n",
+				change.Class, change.Before, change.After, strings.ReplaceAll(change.Suggestion, "|", "\\|"))
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+// writeIssueTable writes a titled table of issues (nothing when empty)
+func writeIssueTable(w io.Writer, title string, issues []JSONIssue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n\n", title)
+	fmt.Fprintf(w, "| Location | Severity | Message |\n")
+	fmt.Fprintf(w, "|----------|----------|---------|\n")
+	for _, issue := range issues {
+		severity := issue.Severity
+		if severity == SeverityInfo {
+			severity = "info"
+		}
+		fmt.Fprintf(w, "| `%s:%d` | %s | %s |\n",
+			issue.File, issue.Line, severity, strings.ReplaceAll(issue.Message, "|", "\\|"))
+	}
+	fmt.Fprintf(w, "\n")
+}
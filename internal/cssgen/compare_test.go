@@ -0,0 +1,62 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareReports(t *testing.T) {
+	typo := JSONIssue{File: "a.templ", Line: 3, Column: 5, Severity: SeverityError,
+		Message: `invalid CSS class "btn--typo" not found in stylesheet`, Linter: "csslint", Fingerprint: "aaaa"}
+	hardcoded := JSONIssue{File: "b.templ", Line: 7, Column: 9, Severity: SeverityWarning,
+		Message: `hardcoded CSS class "btn" should use ui.Btn constant`, Linter: "csslint", Fingerprint: "bbbb"}
+
+	old := JSONOutput{
+		Summary:   JSONSummary{TotalIssues: 2, Errors: 1, Warnings: 1},
+		Stats:     JSONStats{TotalConstants: 10, ActuallyUsed: 4, UsagePercentage: 40},
+		Issues:    []JSONIssue{typo, hardcoded},
+		QuickWins: JSONQuickWins{SingleClass: []JSONQuickWin{{Class: "btn", Occurrences: 3, Suggestion: "ui.Btn"}}},
+	}
+	updated := JSONOutput{
+		Summary:   JSONSummary{TotalIssues: 1, Warnings: 1},
+		Stats:     JSONStats{TotalConstants: 10, ActuallyUsed: 5, UsagePercentage: 50},
+		Issues:    []JSONIssue{hardcoded},
+		QuickWins: JSONQuickWins{SingleClass: []JSONQuickWin{{Class: "btn", Occurrences: 1, Suggestion: "ui.Btn"}}},
+	}
+
+	// Round-trip through files like the CLI does
+	dir := t.TempDir()
+	paths := make([]string, 2)
+	for i, report := range []JSONOutput{old, updated} {
+		data, err := json.Marshal(report)
+		require.NoError(t, err)
+		paths[i] = filepath.Join(dir, []string{"old.json", "new.json"}[i])
+		require.NoError(t, os.WriteFile(paths[i], data, 0644))
+	}
+	loadedOld, err := LoadJSONOutput(paths[0])
+	require.NoError(t, err)
+	loadedNew, err := LoadJSONOutput(paths[1])
+	require.NoError(t, err)
+
+	cmp := CompareReports(*loadedOld, *loadedNew)
+	assert.Equal(t, []JSONIssue{typo}, cmp.ResolvedIssues)
+	assert.Empty(t, cmp.NewIssues)
+	assert.Equal(t, []QuickWinChange{{Class: "btn", Suggestion: "ui.Btn", Before: 3, After: 1}}, cmp.QuickWinChanges)
+
+	var buf bytes.Buffer
+	WriteComparisonMarkdown(&buf, cmp)
+	out := buf.String()
+	assert.Contains(t, out, "1 issue resolved, 0 new issues")
+	assert.Contains(t, out, "| **Adoption Rate** | 40.0% | 50.0% | +10.0 pts |")
+	assert.Contains(t, out, "| **Errors** | 1 | 0 | -1 |")
+	assert.Contains(t, out, "## ✅ Resolved Issues")
+	assert.Contains(t, out, "btn--typo")
+	assert.NotContains(t, out, "## 🆕 New Issues")
+	assert.Contains(t, out, "| `btn` | 3 | 1 | `ui.Btn` |")
+}
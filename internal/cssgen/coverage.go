@@ -0,0 +1,210 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// unlayeredLabel groups classes without a known @layer in coverage reports
+const unlayeredLabel = "unlayered"
+
+// layerCommentPattern finds the layer badge in a generated constant's comment
+var layerCommentPattern = regexp.MustCompile(`@layer (\S+)`)
+
+// CoverageReport is the stylesheet-centric view of usage: how many CSS classes
+// are referenced anywhere in scanned code (hardcoded or via constants)
+type CoverageReport struct {
+	TotalClasses int             `json:"total_classes"`
+	UsedClasses  int             `json:"used_classes"`
+	Percentage   float64         `json:"percentage"`
+	Layers       []LayerCoverage `json:"layers"`
+	Unused       []string        `json:"unused_classes"`
+	Usage        map[string]int  `json:"usage"` // References per used class (read by generate --usage-file)
+}
+
+// LayerCoverage is the used/total count for one @layer
+type LayerCoverage struct {
+	Layer      string  `json:"layer"`
+	Total      int     `json:"total"`
+	Used       int     `json:"used"`
+	Percentage float64 `json:"percentage"`
+}
+
+// Coverage scans config.ScanPaths and reports which AllCSSClasses entries are referenced
+func Coverage(config LintConfig) (*CoverageReport, error) {
+	constants, allCSSClasses, _, _, err := parseGeneratedFiles(config.GeneratedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+
+	layers, err := parseClassLayers(config.GeneratedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+
+	literalPaths, err := lintLiteralPaths(config)
+	if err != nil {
+		return nil, err
+	}
+
+	references, _, err := scanFiles(config.ScanPaths, literalPaths, lintScanOptions(config), config.Verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	return computeCoverage(allCSSClasses, constants, layers, references), nil
+}
+
+// computeCoverage marks each CSS class referenced by a hardcoded token or a constant
+func computeCoverage(allCSSClasses map[string]bool, constants map[string]string, layers map[string]string, references []ClassReference) *CoverageReport {
+	used := make(map[string]int)
+	for _, ref := range references {
+		if ref.IsConstant {
+			if value, ok := constants[ref.ConstName]; ok {
+				used[value]++
+			}
+			continue
+		}
+		for _, token := range strings.Fields(ref.FullClassValue) {
+			used[token]++
+		}
+	}
+
+	report := &CoverageReport{Unused: []string{}, Usage: make(map[string]int)}
+	byLayer := make(map[string]*LayerCoverage)
+	for className := range allCSSClasses {
+		layer := layers[className]
+		if layer == "" {
+			layer = unlayeredLabel
+		}
+		lc, ok := byLayer[layer]
+		if !ok {
+			lc = &LayerCoverage{Layer: layer}
+			byLayer[layer] = lc
+		}
+
+		report.TotalClasses++
+		lc.Total++
+		if count := used[className]; count > 0 {
+			report.UsedClasses++
+			lc.Used++
+			report.Usage[className] = count
+		} else {
+			report.Unused = append(report.Unused, className)
+		}
+	}
+
+	report.Percentage = percentage(report.UsedClasses, report.TotalClasses)
+	for _, lc := range byLayer {
+		lc.Percentage = percentage(lc.Used, lc.Total)
+		report.Layers = append(report.Layers, *lc)
+	}
+	sort.Slice(report.Layers, func(i, j int) bool {
+		return report.Layers[i].Layer < report.Layers[j].Layer
+	})
+	sort.Strings(report.Unused)
+
+	return report
+}
+
+// percentage returns part/total as a percentage (0 when total is 0)
+func percentage(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+// parseClassLayers maps each constant's CSS class to the @layer noted in its comment
+func parseClassLayers(path string) (map[string]string, error) {
+	files, err := generatedFilePaths(path)
+	if err != nil {
+		return nil, err
+	}
+
+	layers := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, filePath := range files {
+		file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
+		if err != nil {
+			// Skip files that can't be parsed (might be in progress)
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST || genDecl.Doc == nil {
+				continue
+			}
+			match := layerCommentPattern.FindStringSubmatch(genDecl.Doc.Text())
+			if match == nil {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				vspec, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vspec.Values) == 0 {
+					continue
+				}
+				if lit, ok := vspec.Values[0].(*ast.BasicLit); ok {
+					if value, err := strconv.Unquote(lit.Value); err == nil {
+						layers[value] = match[1]
+					}
+				}
+			}
+		}
+	}
+
+	return layers, nil
+}
+
+// PrintCoverage writes the coverage report as a table
+func PrintCoverage(w io.Writer, report *CoverageReport, useColors bool) {
+	fmt.Fprintf(w, "%s %.1f%% (%d/%d CSS classes referenced)\n\n",
+		RenderStyle(StyleCyan, "CSS Coverage:", useColors),
+		report.Percentage, report.UsedClasses, report.TotalClasses)
+
+	fmt.Fprintf(w, "%-20s %7s %7s %9s\n", "Layer", "Used", "Total", "Coverage")
+	for _, lc := range report.Layers {
+		fmt.Fprintf(w, "%-20s %7d %7d %8.1f%%\n", lc.Layer, lc.Used, lc.Total, lc.Percentage)
+	}
+
+	if len(report.Unused) > 0 {
+		fmt.Fprintf(w, "\n%s\n", RenderStyle(StyleYellow, fmt.Sprintf("Unreferenced classes (%d):", len(report.Unused)), useColors))
+		for _, className := range report.Unused {
+			fmt.Fprintf(w, "  .%s\n", className)
+		}
+	}
+}
+
+// LoadUsageFile reads per-class reference counts from a coverage JSON report
+func LoadUsageFile(path string) (map[string]int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading usage file: %w", err)
+	}
+
+	var report CoverageReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("parsing usage file %s: %w", path, err)
+	}
+	if report.Usage == nil {
+		return nil, fmt.Errorf("usage file %s has no usage counts (write it with: cssgen coverage --output-format json)", path)
+	}
+	return report.Usage, nil
+}
+
+// WriteCoverageJSON writes the coverage report as indented JSON
+func WriteCoverageJSON(w io.Writer, report *CoverageReport) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
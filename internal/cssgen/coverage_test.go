@@ -0,0 +1,70 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverage(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer components {
+		.btn { color: red; }
+		.card { padding: 1rem; }
+	}
+	@layer utilities {
+		.hidden { display: none; }
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	_, err := Generate(Config{
+		SourceDir:     tmpDir,
+		OutputDir:     tmpDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "markdown",
+		PropertyLimit: 5,
+	})
+	require.NoError(t, err)
+
+	page := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`package ui
+
+templ Page() {
+	<button class="btn">Save</button>
+	<div class={ ui.Hidden }></div>
+}
+`), 0644))
+
+	report, err := Coverage(LintConfig{
+		GeneratedFile: filepath.Join(tmpDir, "styles.gen.go"),
+		ScanPaths:     []string{page},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, report.TotalClasses)
+	assert.Equal(t, 2, report.UsedClasses)
+	assert.InDelta(t, 66.7, report.Percentage, 0.05)
+	assert.Equal(t, []string{"card"}, report.Unused)
+	assert.Equal(t, map[string]int{"btn": 1, "hidden": 1}, report.Usage)
+	assert.Equal(t, []LayerCoverage{
+		{Layer: "components", Total: 2, Used: 1, Percentage: 50},
+		{Layer: "utilities", Total: 1, Used: 1, Percentage: 100},
+	}, report.Layers)
+
+	var table bytes.Buffer
+	PrintCoverage(&table, report, false)
+	assert.Contains(t, table.String(), "CSS Coverage: 66.7% (2/3 CSS classes referenced)")
+	assert.Contains(t, table.String(), ".card")
+
+	var out bytes.Buffer
+	require.NoError(t, WriteCoverageJSON(&out, report))
+	var decoded CoverageReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, *report, decoded)
+}
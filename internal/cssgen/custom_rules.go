@@ -0,0 +1,152 @@
+package cssgen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// CustomRule is a user-defined lint rule (lint.custom-rules in .cssgen.yaml)
+type CustomRule struct {
+	Name         string // Rule ID for --only and cssgen:ignore, e.g. "no-debug-classes"
+	ClassPattern string // Regex matched against each referenced class, e.g. "^debug-"
+	Message      string // Go template for the issue text; {{.Class}} and {{.Rule}} are available
+	Severity     string // "error", "warning" (default) or "info"
+}
+
+// compiledRule is a CustomRule ready to run
+type compiledRule struct {
+	name     string
+	pattern  *regexp.Regexp
+	message  *template.Template
+	severity string
+}
+
+// customRuleData is what a custom rule's message template sees
+type customRuleData struct {
+	Class string
+	Rule  string
+}
+
+// ValidateCustomRules reports the first invalid custom rule, for rejecting a
+// config before linting; Lint performs the same checks
+func ValidateCustomRules(rules []CustomRule) error {
+	_, err := compileCustomRules(rules)
+	return err
+}
+
+// compileCustomRules validates and compiles custom rules
+func compileCustomRules(rules []CustomRule) ([]*compiledRule, error) {
+	compiled := make([]*compiledRule, 0, len(rules))
+	seen := make(map[string]bool)
+
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("custom rule with class-pattern %q has no name", rule.ClassPattern)
+		}
+		if knownRules[rule.Name] || seen[rule.Name] {
+			return nil, fmt.Errorf("custom rule %q: name already in use", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		if rule.ClassPattern == "" {
+			return nil, fmt.Errorf("custom rule %q: class-pattern is required", rule.Name)
+		}
+		pattern, err := regexp.Compile(rule.ClassPattern)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %q: invalid class-pattern: %w", rule.Name, err)
+		}
+
+		message := rule.Message
+		if message == "" {
+			message = "class {{.Class}} violates {{.Rule}}"
+		}
+		tmpl, err := template.New(rule.Name).Option("missingkey=error").Parse(message)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %q: invalid message template: %w", rule.Name, err)
+		}
+
+		severity, err := customRuleSeverity(rule.Severity)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %q: %w", rule.Name, err)
+		}
+
+		compiled = append(compiled, &compiledRule{
+			name:     rule.Name,
+			pattern:  pattern,
+			message:  tmpl,
+			severity: severity,
+		})
+	}
+
+	return compiled, nil
+}
+
+// customRuleSeverity maps a configured severity to an issue severity ("" = warning)
+func customRuleSeverity(severity string) (string, error) {
+	switch severity {
+	case "", "warning":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "info":
+		return SeverityInfo, nil
+	}
+	return "", fmt.Errorf("invalid severity %q (want error, warning or info)", severity)
+}
+
+// customRuleIssues runs the enabled custom rules against the classes of one reference
+func customRuleIssues(ref ClassReference, rules []*compiledRule, lookup *CSSLookup, only []string) []Issue {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	classes := uniqueTokens(ref.FullClassValue)
+	if ref.IsConstant {
+		classes = nil
+		if class, ok := lookup.AllConstants[ref.ConstName]; ok {
+			classes = []string{class}
+		}
+	}
+
+	var issues []Issue
+	for _, rule := range rules {
+		if !ruleEnabled(only, rule.name) || isSuppressed(ref.Location.Text, rule.name) {
+			continue
+		}
+
+		for _, class := range classes {
+			if !rule.pattern.MatchString(class) {
+				continue
+			}
+
+			var text strings.Builder
+			if err := rule.message.Execute(&text, customRuleData{Class: class, Rule: rule.name}); err != nil {
+				text.Reset()
+				fmt.Fprintf(&text, "class %q violates %s", class, rule.name)
+			}
+
+			column := ref.Location.Column
+			if !ref.IsConstant {
+				if c := findClassColumn(ref.Location.Text, class); c != 0 {
+					column = c
+				}
+			}
+			issues = append(issues, Issue{
+				FromLinter:  "csslint",
+				Rule:        rule.name,
+				Text:        text.String(),
+				Severity:    rule.severity,
+				SourceLines: []string{ref.Location.Text},
+				Pos: IssuePos{
+					Filename: ref.Location.File,
+					Line:     ref.Location.Line,
+					Column:   column,
+				},
+			})
+		}
+	}
+
+	return issues
+}
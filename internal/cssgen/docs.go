@@ -0,0 +1,78 @@
+package cssgen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// docsCategoryOrder is the order property categories appear in the docs catalog
+var docsCategoryOrder = []PropertyCategory{
+	CategoryVisual,
+	CategoryLayout,
+	CategoryTypography,
+	CategoryEffects,
+	CategoryTokens,
+	CategoryInternal,
+}
+
+// buildDocs renders a Markdown catalog of classes: Go constant, layer, intent and properties
+func buildDocs(classes []*CSSClass, config Config) string {
+	sorted := make([]*CSSClass, len(classes))
+	copy(sorted, classes)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	pkg := config.PackageName
+	if pkg == "" {
+		pkg = "ui"
+	}
+
+	var b strings.Builder
+	b.WriteString("# CSS Class Catalog\n\n")
+	fmt.Fprintf(&b, "Generated by cssgen. %d classes.\n", len(sorted))
+
+	for _, class := range sorted {
+		fmt.Fprintf(&b, "\n## `.%s`\n\n", class.Name)
+
+		layer := class.Layer
+		if layer == "" {
+			layer = unlayeredLabel
+		}
+		fmt.Fprintf(&b, "- **Go:** `%s.%s`\n", pkg, class.GoName)
+		fmt.Fprintf(&b, "- **Layer:** %s\n", layer)
+		if class.Intent != "" {
+			fmt.Fprintf(&b, "- **Intent:** %s\n", class.Intent)
+		}
+		if class.ParentClass != nil {
+			fmt.Fprintf(&b, "- **Base:** `.%s`\n", class.ParentClass.Name)
+		}
+
+		categorized := categorizeProperties(class.Properties, config)
+		for _, cat := range docsCategoryOrder {
+			props := categorized[cat]
+			if len(props) == 0 || (cat == CategoryInternal && !config.ShowInternal) {
+				continue
+			}
+			fmt.Fprintf(&b, "\n**%s:**\n\n", cat)
+			for _, prop := range props {
+				value := strings.Join(strings.Fields(prop.Value), " ")
+				fmt.Fprintf(&b, "- `%s: %s`\n", prop.Name, value)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// writeDocsFile writes the Markdown class catalog, creating parent dirs
+func writeDocsFile(path string, classes []*CSSClass, config Config) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating docs directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(buildDocs(classes, config)), 0644); err != nil {
+		return fmt.Errorf("writing docs: %w", err)
+	}
+	return nil
+}
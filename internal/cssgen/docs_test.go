@@ -0,0 +1,44 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitDocs(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer components {
+		/* @intent Primary call-to-action button */
+		.btn { color: red; padding: 1rem; }
+		.btn--primary { background: blue; }
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	docsFile := filepath.Join(tmpDir, "docs", "classes.md")
+	_, err := Generate(Config{
+		SourceDir:     tmpDir,
+		OutputDir:     tmpDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "compact",
+		PropertyLimit: 5,
+		ExtractIntent: true,
+		DocsFile:      docsFile,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(docsFile)
+	require.NoError(t, err)
+	docs := string(data)
+
+	assert.Contains(t, docs, "## `.btn`\n\n- **Go:** `ui.Btn`\n- **Layer:** components\n- **Intent:** Primary call-to-action button\n")
+	assert.Contains(t, docs, "**Visual:**\n\n- `color: red`")
+	assert.Contains(t, docs, "**Layout:**\n\n- `padding: 1rem`")
+	assert.Contains(t, docs, "- **Base:** `.btn`")
+	assert.Less(t, strings.Index(docs, "`.btn`"), strings.Index(docs, "`.btn--primary`"))
+}
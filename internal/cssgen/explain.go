@@ -0,0 +1,103 @@
+package cssgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ruleExplanations describes each built-in rule for `cssgen explain`
+var ruleExplanations = map[string]string{
+	RuleInvalidClass: `invalid-class (error)
+
+A class referenced in a template or Go file does not exist in any scanned
+stylesheet, so it styles nothing. It usually means a typo, a class that was
+renamed or deleted in CSS, or a stylesheet missing from generate's include
+patterns. When a real class is within two edits, the issue suggests it and
+--export-fixes / --fix can apply the correction (lint.suggest-limit lists more
+candidates; a hint naming several is not applied).
+
+To fix: correct the name, or add the class to your CSS and regenerate. Classes
+built at runtime ("btn--" + size) can be declared valid with
+lint.dynamic-patterns regexes. Silence a single line with
+// cssgen:ignore invalid-class.`,
+
+	RuleHardcodedClass: `hardcoded-class (warning)
+
+A class is written as a string literal although cssgen generated a constant
+for it, e.g. class="btn" instead of class={ ui.Btn }. Generated constants are
+checked by the Go compiler, so renaming or deleting the class in CSS breaks
+the build instead of silently breaking the page.
+
+To fix: use the constant named in the message (--fix rewrites it for you when
+every class on the element has one). Classes starting with _ are internal:
+they get no constant and are never reported. Silence a single line with
+// cssgen:ignore hardcoded-class.`,
+
+	RuleUnusedConstant: `unused-constant (statistics only; error with --error-on-unused)
+
+A generated constant is never referenced, neither as ui.Const nor as a
+hardcoded string it could replace. The CSS behind it is likely dead.
+
+To fix: delete the class from your stylesheet and regenerate, or remove the
+declaration with --prune-unused. Constants used only from code outside the
+scan paths (e.g. API handlers) can be counted with lint.usage-paths. To keep a
+constant anyway, write /* cssgen:ignore unused-constant */ above the class's
+rule in the CSS; generate copies it onto the constant.`,
+
+	RuleRedundantClass: `redundant-class (info)
+
+Every property a class sets is also set, with the same value, by another class
+on the same element, so removing it changes nothing.
+
+To fix: drop the redundant class from the element, or silence the line with
+// cssgen:ignore redundant-class if the duplication is intentional.`,
+
+	RuleStaleConstant: `stale-constant (warning)
+
+A generated constant holds a class that the same generated package does not
+list in AllCSSClasses. The generated files disagree with each other, usually
+because one was edited by hand or a split file from an older generation was
+left behind after a CSS refactor.
+
+To fix: run cssgen generate (cssgen verify checks that the generated files
+are up to date in CI).`,
+
+	RuleEmptyClass: `empty-class (warning)
+
+A class attribute names no classes at all, e.g. class="" or class="   ". This
+is almost always a bug: a conditional or template helper that was meant to
+produce a class produced nothing.
+
+To fix: fill in the class, or drop the attribute (templ omits class={ }
+entirely when templ.Classes(...) renders empty). Silence a single line with
+// cssgen:ignore empty-class.`,
+
+	RuleA11yHidden: `a11y-hidden (info, experimental)
+
+A screen-reader-only class (sr-only, visually-hidden) is combined with a class
+that sets display: none or visibility: hidden, which hides the content from
+assistive technology too. Only reported with --check-a11y.
+
+To fix: remove the hiding class, or drop the screen-reader class if the
+content really should be hidden from everyone.`,
+}
+
+// ExplainRule returns the description of a built-in rule
+func ExplainRule(rule string) (string, error) {
+	text, ok := ruleExplanations[rule]
+	if !ok {
+		return "", fmt.Errorf("unknown rule %q (known: %s)", rule, strings.Join(ExplainableRules(), ", "))
+	}
+	return text, nil
+}
+
+// ExplainableRules lists the rules ExplainRule describes, sorted
+func ExplainableRules() []string {
+	rules := make([]string, 0, len(ruleExplanations))
+	for rule := range ruleExplanations {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	return rules
+}
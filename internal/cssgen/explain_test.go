@@ -0,0 +1,19 @@
+package cssgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainRuleCoversKnownRules(t *testing.T) {
+	for rule := range knownRules {
+		text, err := ExplainRule(rule)
+		require.NoError(t, err, rule)
+		assert.Contains(t, text, rule)
+	}
+
+	_, err := ExplainRule("nope")
+	assert.ErrorContains(t, err, `unknown rule "nope"`)
+}
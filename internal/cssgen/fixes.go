@@ -0,0 +1,262 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// FixExport is the --export-fixes schema: byte-offset edits a generic patch applier can replay
+type FixExport struct {
+	Version string `json:"version"`
+	Fixes   []Fix  `json:"fixes"`
+}
+
+// Fix replaces the bytes [StartOffset, EndOffset) of File with NewText
+type Fix struct {
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	OldText     string `json:"old_text"`
+	NewText     string `json:"new_text"`
+	Unsafe      string `json:"-"` // Why applying changes rendered output ("" = safe)
+}
+
+// SkippedFix is a fix --fix left alone, with the reason
+type SkippedFix struct {
+	Fix
+	Reason string
+}
+
+// FixResult reports what ApplyFixes changed and what it skipped
+type FixResult struct {
+	Applied []Fix
+	Skipped []SkippedFix
+}
+
+// canUseConstants reports whether constants could replace a hardcoded reference
+// YAML/JSON data holds plain strings, :class expressions are JavaScript and struct
+// tags must stay string literals
+func canUseConstants(ref ClassReference) bool {
+	return !isDataFile(ref.Location.File) && !ref.InExpression && !ref.InStructTag
+}
+
+// computeReplacement builds the fix for a hardcoded class string, or nil if it isn't mechanical
+// class="btn btn--brand" becomes class={ ui.Btn, ui.BtnBrand }; other "btn" literals become ui.Btn
+// Classes without a constant are dropped, so such fixes are marked Unsafe
+func computeReplacement(line, classValue string, s ConstantSuggestion, alias string) *Replacement {
+	if len(s.Constants) == 0 || s.HasInvalid {
+		return nil
+	}
+
+	quoted := `"` + classValue + `"`
+	parts := qualify(alias, s.Constants)
+	unsafe := droppedClassesReason(s)
+
+	// templ attribute: class="..." -> class={ ... }
+	if idx := strings.Index(line, "class="+quoted); idx != -1 {
+		return &Replacement{
+			NewText:      "{ " + strings.Join(parts, ", ") + " }",
+			InlineLength: len(quoted),
+			OldText:      quoted,
+			StartColumn:  idx + len("class=") + 1,
+			Unsafe:       unsafe,
+		}
+	}
+
+	// Go expression: only a single constant can replace a string literal
+	if idx := strings.Index(line, quoted); idx != -1 && len(parts) == 1 {
+		return &Replacement{
+			NewText:      parts[0],
+			InlineLength: len(quoted),
+			OldText:      quoted,
+			StartColumn:  idx + 1,
+			Unsafe:       unsafe,
+		}
+	}
+
+	return nil
+}
+
+// droppedClassesReason describes the classes a fix for s would drop ("" if none)
+func droppedClassesReason(s ConstantSuggestion) string {
+	var dropped []string
+	seen := make(map[string]bool)
+	for _, a := range s.Analysis {
+		if a.Match != MatchExact && !seen[a.ClassName] {
+			seen[a.ClassName] = true
+			dropped = append(dropped, a.ClassName)
+		}
+	}
+	if len(dropped) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("would drop %s (no constant)", strings.Join(dropped, ", "))
+}
+
+// BuildFixes resolves each issue's Replacement to byte offsets in its file
+// Replacements whose text no longer matches the file are skipped
+func BuildFixes(issues []Issue) ([]Fix, error) {
+	lineCache := make(map[string][]string)
+	var fixes []Fix
+
+	for _, issue := range issues {
+		r := issue.Replacement
+		if r == nil || r.OldText == "" {
+			continue
+		}
+
+		lines, ok := lineCache[issue.Pos.Filename]
+		if !ok {
+			// #nosec G304 - path comes from scanned files
+			content, err := os.ReadFile(issue.Pos.Filename)
+			if err != nil {
+				return nil, fmt.Errorf("read %s: %w", issue.Pos.Filename, err)
+			}
+			lines = strings.SplitAfter(string(content), "\n")
+			lineCache[issue.Pos.Filename] = lines
+		}
+		if issue.Pos.Line < 1 || issue.Pos.Line > len(lines) {
+			continue
+		}
+
+		lineStart := 0
+		for _, l := range lines[:issue.Pos.Line-1] {
+			lineStart += len(l)
+		}
+
+		// StartColumn is relative to the trimmed line the scanner reports
+		line := lines[issue.Pos.Line-1]
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		start := indent + r.StartColumn - 1
+		end := start + len(r.OldText)
+		if start < 0 || end > len(line) || line[start:end] != r.OldText || !endsAtClassBoundary(line, start, end) {
+			continue
+		}
+
+		fixes = append(fixes, Fix{
+			File:        issue.Pos.Filename,
+			Line:        issue.Pos.Line,
+			StartOffset: lineStart + start,
+			EndOffset:   lineStart + end,
+			OldText:     r.OldText,
+			NewText:     r.NewText,
+			Unsafe:      r.Unsafe,
+		})
+	}
+
+	return fixes, nil
+}
+
+// endsAtClassBoundary reports whether line[start:end] is not part of a longer class
+// name: a fix for "fake" must not rewrite the start of "fake-class"
+func endsAtClassBoundary(line string, start, end int) bool {
+	text := line[start:end]
+	if !isClassBoundary(text[0]) && start > 0 && !isClassBoundary(line[start-1]) {
+		return false
+	}
+	if isClassBoundary(text[len(text)-1]) || end == len(line) {
+		return true
+	}
+	return isClassBoundary(line[end]) || line[end] == '\n' || line[end] == '\r'
+}
+
+// WriteFixes writes every safe fix as JSON without modifying any files
+func WriteFixes(w io.Writer, issues []Issue) error {
+	all, err := BuildFixes(issues)
+	if err != nil {
+		return err
+	}
+	fixes := []Fix{}
+	for _, fix := range all {
+		if fix.Unsafe == "" {
+			fixes = append(fixes, fix)
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(FixExport{Version: "1.0", Fixes: fixes})
+}
+
+// ApplyFixes rewrites the scanned files with the computable fixes
+// Unsafe fixes (ones that drop classes) are skipped unless allowUnsafe is set
+func ApplyFixes(issues []Issue, allowUnsafe bool) (*FixResult, error) {
+	fixes, err := BuildFixes(issues)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &FixResult{}
+	byFile := make(map[string][]Fix)
+	var files []string
+	for _, fix := range fixes {
+		if fix.Unsafe != "" && !allowUnsafe {
+			result.Skipped = append(result.Skipped, SkippedFix{Fix: fix, Reason: fix.Unsafe + "; use --fix-unsafe"})
+			continue
+		}
+		if _, ok := byFile[fix.File]; !ok {
+			files = append(files, fix.File)
+		}
+		byFile[fix.File] = append(byFile[fix.File], fix)
+	}
+
+	for _, file := range files {
+		fileFixes := byFile[file]
+		sort.SliceStable(fileFixes, func(i, j int) bool { return fileFixes[i].StartOffset < fileFixes[j].StartOffset })
+
+		// Drop fixes that overlap an earlier one
+		var apply []Fix
+		end := -1
+		for _, fix := range fileFixes {
+			if fix.StartOffset < end {
+				result.Skipped = append(result.Skipped, SkippedFix{Fix: fix, Reason: "overlaps another fix"})
+				continue
+			}
+			apply = append(apply, fix)
+			end = fix.EndOffset
+		}
+
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("stat %s: %w", file, err)
+		}
+		// #nosec G304 - path comes from scanned files
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", file, err)
+		}
+
+		// Apply back to front so earlier offsets stay valid
+		for i := len(apply) - 1; i >= 0; i-- {
+			fix := apply[i]
+			content = append(content[:fix.StartOffset], append([]byte(fix.NewText), content[fix.EndOffset:]...)...)
+		}
+		if err := os.WriteFile(file, content, info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("write %s: %w", file, err)
+		}
+		result.Applied = append(result.Applied, apply...)
+	}
+
+	return result, nil
+}
+
+// QuickWinIssues returns the fixable hardcoded-class issues for one Quick Win,
+// so ApplyFixes can migrate just that class string (cssgen triage)
+func QuickWinIssues(issues []Issue, win QuickWin) []Issue {
+	quoted := `"` + win.ClassName + `"`
+	var matched []Issue
+	for _, issue := range issues {
+		if issue.Rule != RuleHardcodedClass || issue.Replacement == nil {
+			continue
+		}
+		if issue.Replacement.OldText == quoted {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
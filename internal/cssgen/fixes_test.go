@@ -0,0 +1,186 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFixesTemplAttribute(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+	"btn--brand": true,
+	"custom": true,
+}
+
+const Btn = "btn"
+
+const BtnBrand = "btn--brand"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	content := "package test\n\ntempl Page() {\n\t<button class=\"btn\">Go</button>\n\t<a class=\"btn btn--brand\"></a>\n\t<p class=\"btn custom\"></p>\n}\n"
+	require.NoError(t, os.WriteFile(templFile, []byte(content), 0644))
+
+	result, err := Lint(LintConfig{
+		ScanPaths:     []string{templFile},
+		GeneratedFile: generatedFile,
+		PackageName:   "ui",
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteFixes(&buf, result.Issues))
+
+	var export FixExport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &export))
+
+	// "btn custom" has an unmatched class and gets no fix
+	require.Len(t, export.Fixes, 2)
+
+	fix := export.Fixes[0]
+	assert.Equal(t, templFile, fix.File)
+	assert.Equal(t, 4, fix.Line)
+	assert.Equal(t, `"btn"`, fix.OldText)
+	assert.Equal(t, "{ ui.Btn }", fix.NewText)
+	assert.Equal(t, `"btn"`, content[fix.StartOffset:fix.EndOffset])
+
+	multi := export.Fixes[1]
+	assert.Equal(t, "{ ui.Btn, ui.BtnBrand }", multi.NewText)
+	assert.Equal(t, `"btn btn--brand"`, content[multi.StartOffset:multi.EndOffset])
+
+	// Files are not modified
+	after, err := os.ReadFile(templFile)
+	require.NoError(t, err)
+	assert.Equal(t, content, string(after))
+}
+
+func TestLintLiteralOnlyReferences(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		config  LintConfig
+	}{
+		{
+			name:    "data file",
+			file:    "button.json",
+			content: `{"class": "btn", "label": "Go"}` + "\n" + `{"class": "btn--typo"}` + "\n",
+			config:  LintConfig{DataKeys: []string{"class"}},
+		},
+		{
+			name:    "struct tag",
+			file:    "props.go",
+			content: "package ui\n\ntype Props struct {\n\tA string `class:\"btn\"`\n\tB string `class:\"btn--typo\"`\n}\n",
+			config:  LintConfig{StructTags: true},
+		},
+		{
+			name:    "class toggle",
+			file:    "page.html",
+			content: `<div :class="{ 'btn': on }"></div>` + "\n" + `<div :class="{ 'btn--typo': on }"></div>` + "\n",
+			config:  LintConfig{ToggleAttributes: []string{":class"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+			require.NoError(t, os.WriteFile(generatedFile, []byte("package ui\n\nvar AllCSSClasses = map[string]bool{\n\t\"btn\": true,\n}\n\nconst Btn = \"btn\"\n"), 0644))
+			file := filepath.Join(tmpDir, tt.file)
+			require.NoError(t, os.WriteFile(file, []byte(tt.content), 0644))
+
+			config := tt.config
+			config.ScanPaths = []string{file}
+			config.GeneratedFile = generatedFile
+			config.PackageName = "ui"
+			config.ErrorOnUnused = true
+			result, err := Lint(config)
+			require.NoError(t, err)
+
+			// Constants can't go there: only the invalid class is reported, and "btn"
+			// neither warns, makes a Quick Win nor leaves Btn unused
+			require.Len(t, result.Issues, 1)
+			assert.Equal(t, RuleInvalidClass, result.Issues[0].Rule)
+			assert.Contains(t, result.Issues[0].Text, `"btn--typo"`)
+			assert.Empty(t, result.QuickWins.SingleClass)
+			assert.Empty(t, result.UnusedClasses)
+			assert.Zero(t, result.CompletelyUnused)
+		})
+	}
+}
+
+func TestApplyFixesSafeOnly(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+	"btn--brand": true,
+	"custom": true,
+}
+
+const Btn = "btn"
+
+const BtnBrand = "btn--brand"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	content := "package test\n\ntempl Page() {\n\t<a class=\"btn btn--brand\"></a>\n\t<p class=\"btn custom\"></p>\n\t<i class=\"btn--brnd\"></i>\n}\n"
+
+	lint := func() []Issue {
+		result, err := Lint(LintConfig{
+			ScanPaths:     []string{templFile},
+			GeneratedFile: generatedFile,
+			PackageName:   "ui",
+		})
+		require.NoError(t, err)
+		return result.Issues
+	}
+
+	t.Run("safe only by default", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(templFile, []byte(content), 0644))
+
+		result, err := ApplyFixes(lint(), false)
+		require.NoError(t, err)
+
+		require.Len(t, result.Applied, 1)
+		assert.Equal(t, "{ ui.Btn, ui.BtnBrand }", result.Applied[0].NewText)
+		require.Len(t, result.Skipped, 2)
+		assert.Equal(t, `"btn custom"`, result.Skipped[0].OldText)
+		assert.Contains(t, result.Skipped[0].Reason, "would drop custom")
+		// A "did you mean" guess changes the rendered class too
+		assert.Equal(t, "btn--brnd", result.Skipped[1].OldText)
+		assert.Contains(t, result.Skipped[1].Reason, "guesses btn--brand")
+
+		after, err := os.ReadFile(templFile)
+		require.NoError(t, err)
+		assert.Equal(t, "package test\n\ntempl Page() {\n\t<a class={ ui.Btn, ui.BtnBrand }></a>\n\t<p class=\"btn custom\"></p>\n\t<i class=\"btn--brnd\"></i>\n}\n", string(after))
+	})
+
+	t.Run("unsafe allowed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(templFile, []byte(content), 0644))
+
+		result, err := ApplyFixes(lint(), true)
+		require.NoError(t, err)
+
+		assert.Len(t, result.Applied, 3)
+		assert.Empty(t, result.Skipped)
+
+		after, err := os.ReadFile(templFile)
+		require.NoError(t, err)
+		assert.Equal(t, "package test\n\ntempl Page() {\n\t<a class={ ui.Btn, ui.BtnBrand }></a>\n\t<p class={ ui.Btn }></p>\n\t<i class=\"btn--brand\"></i>\n}\n", string(after))
+	})
+}
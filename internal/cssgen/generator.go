@@ -2,7 +2,9 @@ package cssgen
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
 )
@@ -11,23 +13,33 @@ import (
 func Generate(config Config) (*GenerateResult, error) {
 	result := &GenerateResult{}
 
-	// 1. Scan CSS files
-	files, err := scanCSSFiles(config.SourceDir, config.Includes)
+	if err := ValidateConfig(config); err != nil {
+		return nil, err
+	}
+	bem, err := newBEMConvention(config.ModifierSeparator, config.ElementSeparator)
 	if err != nil {
-		return nil, fmt.Errorf("scan failed: %w", err)
+		return nil, err
 	}
-	result.FilesScanned = len(files)
+
+	// 1. Scan and read CSS files
+	sources, err := readCSSSources(config)
+	if err != nil {
+		return nil, err
+	}
+	result.FilesScanned = len(sources)
 
 	if config.Verbose {
-		fmt.Printf("Found %d CSS files\n", len(files))
+		fmt.Printf("Found %d CSS files\n", len(sources))
 	}
 
 	// 2. Parse all files
-	classes, warnings, err := processFiles(files, config)
+	parsed, warnings, err := processFiles(sources, config)
 	if err != nil {
 		return nil, fmt.Errorf("parse failed: %w", err)
 	}
+	classes := parsed.classes
 	result.Warnings = warnings
+	result.LayerOrder = parsed.layerOrder
 
 	// Count intents extracted
 	for _, class := range classes {
@@ -41,7 +53,7 @@ func Generate(config Config) (*GenerateResult, error) {
 	}
 
 	// 3. Analyze BEM patterns and build inheritance
-	if err := AnalyzeClasses(classes); err != nil {
+	if err := analyzeClasses(classes, bem); err != nil {
 		return nil, fmt.Errorf("analyze failed: %w", err)
 	}
 
@@ -49,6 +61,16 @@ func Generate(config Config) (*GenerateResult, error) {
 	classes, conflicts := mergeConflicts(classes)
 	result.Warnings = append(result.Warnings, conflicts...)
 
+	// Cross-layer overrides (opt-in, needs declared @layer order)
+	if config.DetectLayerOverrides {
+		result.Warnings = append(result.Warnings, detectLayerOverrides(classes, result.LayerOrder)...)
+	}
+
+	// Don't ship a generated file from partially broken CSS
+	if config.FailOnWarning && len(result.Warnings) > 0 {
+		return nil, &WarningsError{Warnings: result.Warnings}
+	}
+
 	// 5. Filter internal classes
 	publicClasses := make([]*CSSClass, 0, len(classes))
 	for _, class := range classes {
@@ -56,22 +78,175 @@ func Generate(config Config) (*GenerateResult, error) {
 			publicClasses = append(publicClasses, class)
 		}
 	}
+
+	// Prune the long tail of rarely used classes (opt-in, needs a prior coverage report)
+	if config.UsageFile != "" {
+		usage, err := LoadUsageFile(config.UsageFile)
+		if err != nil {
+			return nil, err
+		}
+		var pruned int
+		publicClasses, pruned = pruneByUsage(publicClasses, usage, config.MinUsage)
+		result.ClassesPruned = pruned
+
+		if config.Verbose {
+			fmt.Printf("Pruned %d classes used fewer than %d times\n", pruned, max(config.MinUsage, 1))
+		}
+	}
 	result.ClassesGenerated = len(publicClasses)
+	result.IntentCoverage = intentCoverage(publicClasses)
+
+	if config.EmitTypedGroups {
+		assignTypedGroups(publicClasses)
+	}
 
 	if config.Verbose {
 		fmt.Printf("Generated %d public constants (%d internal classes filtered)\n",
 			len(publicClasses), len(classes)-len(publicClasses))
 	}
 
+	// Diff against the previous generation before it is overwritten
+	previous, err := previousConstants(config.OutputDir)
+	if err != nil {
+		return nil, err
+	}
+	if previous != nil {
+		result.Changes = diffConstants(previous, publicClasses)
+	}
+
 	// 6. Generate Go file
 	// Pass both public classes for constants AND all classes for AllCSSClasses map
 	if err := WriteGoFile(publicClasses, classes, config, *result); err != nil {
 		return nil, fmt.Errorf("write failed: %w", err)
 	}
 
+	// 7. Generate ID constants (opt-in)
+	if config.ExtractIDs && len(parsed.ids) > 0 {
+		ids := mergeIDs(parsed.ids)
+		result.IDsGenerated = len(ids)
+		if err := writeIDsFile(filepath.Join(config.OutputDir, idsFileName), ids, config); err != nil {
+			return nil, fmt.Errorf("write ids failed: %w", err)
+		}
+	}
+
+	// 8. Emit BEM manifest for documentation tooling (opt-in)
+	if config.ManifestFile != "" {
+		if err := writeManifestFile(config.ManifestFile, publicClasses, bem); err != nil {
+			return nil, fmt.Errorf("write manifest failed: %w", err)
+		}
+	}
+
+	// 9. Emit Markdown class catalog (opt-in)
+	if config.DocsFile != "" {
+		if err := writeDocsFile(config.DocsFile, publicClasses, config); err != nil {
+			return nil, fmt.Errorf("write docs failed: %w", err)
+		}
+	}
+
+	// 10. Emit @intent index for a style guide (opt-in)
+	if config.IntentsFile != "" {
+		if err := writeIntentsFile(config.IntentsFile, publicClasses, result.LayerOrder); err != nil {
+			return nil, fmt.Errorf("write intents failed: %w", err)
+		}
+	}
+
 	return result, nil
 }
 
+// withoutSideOutputs disables every file Generate writes outside OutputDir, for runs
+// that must not touch the tree (verify). New opt-in outputs belong here too
+func withoutSideOutputs(config Config) Config {
+	config.ManifestFile = ""
+	config.DocsFile = ""
+	config.IntentsFile = ""
+	return config
+}
+
+// String renders the warning as "file:line: message" (location parts omitted when unknown)
+func (w GenerateWarning) String() string {
+	switch {
+	case w.File != "" && w.Line > 0:
+		return fmt.Sprintf("%s:%d: %s", w.File, w.Line, w.Message)
+	case w.File != "":
+		return fmt.Sprintf("%s: %s", w.File, w.Message)
+	}
+	return w.Message
+}
+
+// ValidateConfig rejects settings Generate can't work with (bad category
+// overrides, internal prefixes, line ending or BEM separators) before any file is read
+func ValidateConfig(config Config) error {
+	if err := validateCategoryOverrides(config.CategoryOverrides); err != nil {
+		return err
+	}
+	if err := validateInternalPrefixes(config.InternalPrefixes); err != nil {
+		return err
+	}
+	if err := validateLineEnding(config.LineEnding); err != nil {
+		return err
+	}
+	_, err := newBEMConvention(config.ModifierSeparator, config.ElementSeparator)
+	return err
+}
+
+// WarningsError is returned by Generate with FailOnWarning when warnings were produced
+type WarningsError struct {
+	Warnings GenerateWarnings
+}
+
+func (e *WarningsError) Error() string {
+	return fmt.Sprintf("%d warning(s) with fail-on-warning: %s", len(e.Warnings), strings.Join(e.Warnings.Strings(), "; "))
+}
+
+// Strings renders each warning with String
+func (ws GenerateWarnings) Strings() []string {
+	out := make([]string, len(ws))
+	for i, w := range ws {
+		out[i] = w.String()
+	}
+	return out
+}
+
+// pruneByUsage keeps classes referenced at least minUsage times (minimum 1)
+func pruneByUsage(classes []*CSSClass, usage map[string]int, minUsage int) ([]*CSSClass, int) {
+	minUsage = max(minUsage, 1)
+
+	kept := make([]*CSSClass, 0, len(classes))
+	for _, class := range classes {
+		if usage[class.Name] >= minUsage {
+			kept = append(kept, class)
+		}
+	}
+	return kept, len(classes) - len(kept)
+}
+
+// cssSource is a CSS file generation reads, with its content; InputHash hashes
+// the same list so the cache key covers exactly what Generate parses
+type cssSource struct {
+	path    string
+	content []byte
+	err     error // Read failure, a warning in Generate
+}
+
+// readCSSSources scans config's CSS files and reads each one
+func readCSSSources(config Config) ([]cssSource, error) {
+	files, err := scanCSSFiles(config.SourceDir, config.Includes)
+	if err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+
+	sources := make([]cssSource, len(files))
+	for i, file := range files {
+		// #nosec G304 - path comes from trusted configuration
+		content, err := os.ReadFile(file)
+		if err != nil {
+			err = fmt.Errorf("read file: %w", err)
+		}
+		sources[i] = cssSource{path: file, content: content, err: err}
+	}
+	return sources, nil
+}
+
 // scanCSSFiles finds all CSS files matching includes
 func scanCSSFiles(sourceDir string, includes []string) ([]string, error) {
 	var files []string
@@ -103,23 +278,30 @@ func scanCSSFiles(sourceDir string, includes []string) ([]string, error) {
 }
 
 // processFiles parses all CSS files
-func processFiles(files []string, config Config) ([]*CSSClass, []string, error) {
-	var allClasses []*CSSClass
-	var warnings []string
+// Layer order is merged across files in the order layers are first declared
+func processFiles(sources []cssSource, config Config) (*parseResult, GenerateWarnings, error) {
+	merged := &parseResult{}
+	var warnings GenerateWarnings
 
-	for _, file := range files {
+	for _, source := range sources {
 		if config.Verbose {
-			fmt.Printf("Parsing %s\n", file)
+			fmt.Printf("Parsing %s\n", source.path)
 		}
 
-		classes, err := parseFile(file, config)
+		parsed, err := parseSource(source, config)
 		if err != nil {
-			warnings = append(warnings, fmt.Sprintf("Failed to parse %s: %v", file, err))
+			warnings = append(warnings, GenerateWarning{Message: fmt.Sprintf("failed to parse: %v", err), File: source.path})
 			continue
 		}
 
-		allClasses = append(allClasses, classes...)
+		merged.classes = append(merged.classes, parsed.classes...)
+		merged.ids = append(merged.ids, parsed.ids...)
+		for _, layer := range parsed.layerOrder {
+			if !contains(merged.layerOrder, layer) {
+				merged.layerOrder = append(merged.layerOrder, layer)
+			}
+		}
 	}
 
-	return allClasses, warnings, nil
+	return merged, warnings, nil
 }
@@ -1,8 +1,14 @@
 package cssgen
 
 import (
+	"go/ast"
+	"go/build/constraint"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -197,9 +203,28 @@ func TestEndToEnd(t *testing.T) {
 
 	outputStr := string(output)
 	assert.Contains(t, outputStr, "package ui")
-	assert.Contains(t, outputStr, `"btn": true`)
+	assert.Regexp(t, `"btn":\s+true`, outputStr) // gofmt aligns map values
 	assert.Contains(t, outputStr, `"btn--primary": true`)
 
+	// IsValidClass is emitted and looks up AllCSSClasses
+	file, err := parser.ParseFile(token.NewFileSet(), outputFile, output, 0)
+	require.NoError(t, err)
+	var isValid *ast.FuncDecl
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Name.Name == "IsValidClass" {
+			isValid = fn
+		}
+	}
+	require.NotNil(t, isValid, "IsValidClass not generated")
+	var referencesMap bool
+	ast.Inspect(isValid.Body, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "AllCSSClasses" {
+			referencesMap = true
+		}
+		return true
+	})
+	assert.True(t, referencesMap, "IsValidClass should reference AllCSSClasses")
+
 	// Constants are in split file (styles_test.gen.go)
 	splitFile := filepath.Join(tmpDir, "styles_test.gen.go")
 	splitOutput, err := os.ReadFile(splitFile)
@@ -212,6 +237,13 @@ func TestEndToEnd(t *testing.T) {
 	assert.Contains(t, splitStr, `Btn = "btn"`)
 	assert.Contains(t, splitStr, `BtnPrimary = "btn--primary"`) // 1:1 mapping
 	assert.Contains(t, splitStr, "@layer components")
+
+	// Generated files are gofmt-clean
+	for _, src := range [][]byte{output, splitOutput} {
+		formatted, err := format.Source(src)
+		require.NoError(t, err)
+		assert.Equal(t, string(formatted), string(src))
+	}
 }
 
 func TestBEMDetection(t *testing.T) {
@@ -236,6 +268,58 @@ func TestBEMDetection(t *testing.T) {
 	}
 }
 
+func TestCustomBEMConvention(t *testing.T) {
+	bem, err := newBEMConvention("_", "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		className string
+		wantBase  string
+		wantKind  bemKind
+	}{
+		{"btn_primary", "btn", bemModifier},
+		{"card__header", "card", bemElement}, // Longer separator checked first
+		{"btn", "", bemNone},
+		{"btn--primary", "", bemNone}, // Standard BEM modifiers no longer apply
+	}
+	for _, tt := range tests {
+		t.Run(tt.className, func(t *testing.T) {
+			base, kind := bem.detect(tt.className)
+			assert.Equal(t, tt.wantBase, base)
+			assert.Equal(t, tt.wantKind, kind)
+		})
+	}
+
+	_, err = newBEMConvention("__", "")
+	require.ErrorContains(t, err, "must differ")
+
+	// Parent linking follows the convention; constant names stay 1:1
+	tmpDir := t.TempDir()
+	css := `.btn { padding: 0.5rem; color: black; }
+.btn_primary { color: white; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "buttons.css"), []byte(css), 0644))
+	_, err = Generate(Config{
+		SourceDir:         tmpDir,
+		OutputDir:         tmpDir,
+		PackageName:       "ui",
+		Includes:          []string{"*.css"},
+		Format:            "markdown",
+		PropertyLimit:     5,
+		ModifierSeparator: "_",
+		ManifestFile:      filepath.Join(tmpDir, "bem.json"),
+	})
+	require.NoError(t, err)
+
+	output, err := os.ReadFile(filepath.Join(tmpDir, "styles_buttons.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "// **Base:** .btn")
+	assert.Contains(t, string(output), `const BtnPrimary = "btn_primary"`)
+
+	manifest, err := os.ReadFile(filepath.Join(tmpDir, "bem.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"btn": {"modifiers": ["btn_primary"], "elements": []}}`, string(manifest))
+}
+
 func TestToGoName(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -246,12 +330,18 @@ func TestToGoName(t *testing.T) {
 		{"card__header", "CardHeader"},
 		{"flex-center", "FlexCenter"},
 		{"_internal", "_Internal"},
+		{"3d-flip", "C3dFlip"},
+		{"2xl", "C2xl"},
+		{"1-of-2", "C1Of2"},
+		{"_2col", "_C2col"},
+		{"text-2xl", "Text2xl"},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
 			result := toGoName(tt.input)
 			assert.Equal(t, tt.expected, result)
+			assert.True(t, token.IsIdentifier(result), "%q is not a valid identifier", result)
 		})
 	}
 }
@@ -274,7 +364,8 @@ func TestMergeConflicts(t *testing.T) {
 
 	assert.Len(t, merged, 1)
 	assert.Len(t, warnings, 1)
-	assert.Contains(t, warnings[0], "Duplicate class")
+	assert.Contains(t, warnings[0].Message, "Duplicate class")
+	assert.Equal(t, "file2.css", warnings[0].File)
 
 	// Properties should be merged
 	btn := merged[0]
@@ -282,6 +373,125 @@ func TestMergeConflicts(t *testing.T) {
 	assert.Equal(t, "blue", btn.Properties["background"])
 }
 
+func TestMergeConflictsLayerDivergence(t *testing.T) {
+	classes := []*CSSClass{
+		{
+			Name:       "btn",
+			Layer:      "base",
+			Properties: map[string]string{"color": "red"},
+			SourceFile: "base.css",
+			SourceLine: 3,
+		},
+		{
+			Name:       "btn",
+			Layer:      "components",
+			Properties: map[string]string{"background": "blue"},
+			SourceFile: "button.css",
+			SourceLine: 7,
+		},
+	}
+
+	merged, warnings := mergeConflicts(classes)
+
+	require.Len(t, merged, 1)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, GenerateWarning{
+		Message: "Class 'btn' is in @layer components here but @layer base at base.css:3 - properties merged, keeping @layer base",
+		File:    "button.css",
+		Line:    7,
+	}, warnings[0])
+
+	btn := merged[0]
+	assert.Equal(t, "base", btn.Layer)
+	assert.Equal(t, []string{"components"}, btn.ConflictingLayers)
+	assert.Equal(t, "blue", btn.Properties["background"])
+}
+
+func TestDuplicateClassWarningLocations(t *testing.T) {
+	tmpDir := t.TempDir()
+	first := filepath.Join(tmpDir, "a.css")
+	second := filepath.Join(tmpDir, "b.css")
+	require.NoError(t, os.WriteFile(first, []byte(".card { color: red; }\n\n.btn { color: red; }\n"), 0644))
+	require.NoError(t, os.WriteFile(second, []byte("/* buttons */\n.btn {\n\tbackground: blue;\n}\n"), 0644))
+
+	result, err := Generate(Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.Warnings, 1)
+	warning := result.Warnings[0]
+	assert.Equal(t, second, warning.File)
+	assert.Equal(t, 2, warning.Line)
+	assert.Equal(t, []string{
+		second + ":2: Duplicate class 'btn' (first defined at " + first + ":3) - properties merged",
+	}, result.Warnings.Strings())
+}
+
+func TestGenerateFailOnWarning(t *testing.T) {
+	srcDir := t.TempDir()
+	outDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "a.css"), []byte(".btn { color: red; }\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "b.css"), []byte(".btn { color: blue; }\n"), 0644))
+
+	result, err := Generate(Config{
+		SourceDir:     srcDir,
+		OutputDir:     outDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "markdown",
+		FailOnWarning: true,
+	})
+	require.Error(t, err)
+	assert.Nil(t, result)
+
+	var warningsErr *WarningsError
+	require.ErrorAs(t, err, &warningsErr)
+	require.Len(t, warningsErr.Warnings, 1)
+	assert.Contains(t, warningsErr.Warnings[0].Message, "Duplicate class 'btn'")
+
+	_, statErr := os.Stat(filepath.Join(outDir, "styles.gen.go"))
+	assert.True(t, os.IsNotExist(statErr), "nothing is written when warnings fail generation")
+}
+
+func TestParseCSSHonorSourceComments(t *testing.T) {
+	bundle := `/* src: button.css */
+.btn {
+	color: red;
+}
+
+/* src: components/card.css */
+/* A card */
+.card {
+	padding: 1rem;
+}
+`
+	bundlePath := filepath.Join("dist", "app.css")
+
+	classes, err := ParseCSS(bundle, bundlePath, "", Config{HonorSourceComments: true})
+	require.NoError(t, err)
+
+	sources := make(map[string]string)
+	for _, class := range classes {
+		sources[class.Name] = sourceLocation(class)
+	}
+	assert.Equal(t, map[string]string{
+		"btn":  filepath.Join("dist", "button.css") + ":1",
+		"card": filepath.Join("dist", "components", "card.css") + ":2",
+	}, sources)
+
+	// Off by default: everything is attributed to the bundle
+	classes, err = ParseCSS(bundle, bundlePath, "", Config{})
+	require.NoError(t, err)
+	for _, class := range classes {
+		assert.Equal(t, bundlePath, class.SourceFile)
+	}
+}
+
 func TestParserWithTestdata(t *testing.T) {
 	testFiles := []struct {
 		file          string
@@ -301,9 +511,9 @@ func TestParserWithTestdata(t *testing.T) {
 				LayerInferFromPath: false,
 				ExtractIntent:      false,
 			}
-			classes, err := parseFile(path, config)
+			parsed, err := parseFile(path, config)
 			require.NoError(t, err)
-			assert.Len(t, classes, tt.expectedCount, "Expected %d classes in %s", tt.expectedCount, tt.file)
+			assert.Len(t, parsed.classes, tt.expectedCount, "Expected %d classes in %s", tt.expectedCount, tt.file)
 		})
 	}
 }
@@ -331,6 +541,303 @@ func TestLayerInference(t *testing.T) {
 	}
 }
 
+func TestGenerateAllCSSClassList(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := ".zebra { color: red; }\n.alpha { color: blue; }\n.mid--on { color: green; }\n.alpha { margin: 0; }\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "base.css"), []byte(css), 0644))
+
+	_, err := Generate(Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+	})
+	require.NoError(t, err)
+
+	outputFile := filepath.Join(tmpDir, "styles.gen.go")
+	file, err := parser.ParseFile(token.NewFileSet(), outputFile, nil, 0)
+	require.NoError(t, err)
+
+	var list []string
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		vspec, ok := n.(*ast.ValueSpec)
+		if !ok || vspec.Names[0].Name != "AllCSSClassList" {
+			return true
+		}
+		found = true
+		for _, elt := range vspec.Values[0].(*ast.CompositeLit).Elts {
+			list = append(list, strings.Trim(elt.(*ast.BasicLit).Value, `"`))
+		}
+		return false
+	})
+	require.True(t, found, "AllCSSClassList not generated")
+	assert.Equal(t, []string{"alpha", "mid--on", "zebra"}, list)
+
+	// The slice lists exactly the AllCSSClasses keys
+	_, allCSS, err := ParseGeneratedFile(outputFile)
+	require.NoError(t, err)
+	assert.Len(t, allCSS, len(list))
+}
+
+func TestClassSourceLocation(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		class    CSSClass
+		config   Config
+		expected string
+	}{
+		{"relative source dir keeps the project path",
+			CSSClass{SourceFile: filepath.Join("web", "styles", "button.css"), SourceLine: 4},
+			Config{SourceDir: filepath.Join("web", "styles")}, "web/styles/button.css:4"},
+		{"absolute source dir outside the working directory is trimmed",
+			CSSClass{SourceFile: filepath.Join(string(filepath.Separator), "repo", "styles", "card.css"), SourceLine: 2},
+			Config{SourceDir: filepath.Join(string(filepath.Separator), "repo", "styles")}, "card.css:2"},
+		{"unknown line",
+			CSSClass{SourceFile: filepath.Join("styles", "base.css")},
+			Config{SourceDir: "styles"}, "styles/base.css"},
+		{"absolute source dir inside the working directory keeps the project path",
+			CSSClass{SourceFile: filepath.Join(wd, "web", "styles", "button.css"), SourceLine: 4},
+			Config{SourceDir: filepath.Join(wd, "web", "styles")}, "web/styles/button.css:4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, classSourceLocation(&tt.class, tt.config))
+		})
+	}
+}
+
+func TestDefaultLayer(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "custom.css")
+	require.NoError(t, os.WriteFile(path, []byte(".promo { color: red; }"), 0644))
+
+	tests := []struct {
+		name         string
+		defaultLayer string
+		expected     string
+	}{
+		{"unset falls back to base", "", "base"},
+		{"configured layer", "theme", "theme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := Config{
+				SourceDir:          tmpDir,
+				LayerInferFromPath: true,
+				DefaultLayer:       tt.defaultLayer,
+			}
+			parsed, err := parseFile(path, config)
+			require.NoError(t, err)
+			require.Len(t, parsed.classes, 1)
+			assert.Equal(t, tt.expected, parsed.classes[0].Layer)
+		})
+	}
+}
+
+func TestLayerOrderDeclaration(t *testing.T) {
+	css := `@layer reset, components, theme;
+
+@layer theme {
+	.mt-1 { margin-top: 1rem; }
+}
+
+@layer components {
+	.btn { color: red; }
+}
+
+@layer overrides {
+	.force { color: blue; }
+}`
+
+	parsed, err := parseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+	classes, order := parsed.classes, parsed.layerOrder
+	assert.Len(t, classes, 3)
+	assert.Equal(t, []string{"reset", "components", "theme", "overrides"}, order)
+
+	// Classes are ranked by declared layer order before name
+	for _, c := range classes {
+		c.GoName = toGoName(c.Name)
+	}
+	grouped := groupClassesByComponent(classes, Config{}, order)
+	require.Len(t, grouped["test"], 3)
+	assert.Equal(t, "btn", grouped["test"][0].Name)
+	assert.Equal(t, "mt-1", grouped["test"][1].Name)
+	assert.Equal(t, "force", grouped["test"][2].Name)
+}
+
+func TestDetectLayerOverrides(t *testing.T) {
+	css := `@layer components, utilities;
+
+@layer components {
+	.card { color: red; padding: 1rem; -webkit-appearance: none; }
+}
+
+@layer utilities {
+	.text-blue { color: blue; -webkit-appearance: none; }
+}`
+
+	parsed, err := parseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	warnings := detectLayerOverrides(parsed.classes, parsed.layerOrder)
+	require.Len(t, warnings, 1, "only color overlaps; vendor prefixes are ignored")
+	assert.Contains(t, warnings[0].Message, "color")
+	assert.Contains(t, warnings[0].Message, ".text-blue (@layer utilities) wins over .card (@layer components)")
+	assert.Empty(t, warnings[0].File, "overrides span several classes")
+
+	// Without a declared order the winner is unknown
+	assert.Empty(t, detectLayerOverrides(parsed.classes, nil))
+}
+
+func TestScopeRule(t *testing.T) {
+	css := `@layer components {
+	@scope (.card) to (.card__footer) {
+		.title { font-weight: bold; }
+		.body.body--muted { color: gray; }
+	}
+}
+
+.after { display: block; }`
+
+	classes, err := ParseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*CSSClass)
+	for _, c := range classes {
+		byName[c.Name] = c
+	}
+	require.ElementsMatch(t,
+		[]string{"card", "card__footer", "title", "body", "body--muted", "after"},
+		mapKeys(byName))
+
+	assert.Equal(t, "components", byName["card"].Layer)
+	assert.Equal(t, "components", byName["title"].Layer)
+	assert.Equal(t, "bold", byName["title"].Properties["font-weight"])
+	assert.Empty(t, byName["card"].Properties, "scope roots carry no declarations")
+	assert.Equal(t, "block", byName["after"].Properties["display"])
+}
+
+func TestContainerQuery(t *testing.T) {
+	css := `.nav-item { display: block; }
+
+@container sidebar (min-width: 400px) {
+	.nav-item { display: flex; }
+	.nav-item__label { font-size: 1rem; }
+	@media (hover: hover) {
+		div { color: red; }
+	}
+}
+
+.outside { color: blue; }`
+
+	classes, err := ParseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*CSSClass)
+	for _, c := range classes {
+		byName[c.Name] = c
+	}
+	require.ElementsMatch(t, []string{"nav-item", "nav-item__label", "outside"}, mapKeys(byName))
+
+	assert.Equal(t, []string{"sidebar (min-width: 400px)"}, byName["nav-item"].ContainerStates)
+	assert.Equal(t, []string{"sidebar (min-width: 400px)"}, byName["nav-item__label"].ContainerStates)
+	assert.Empty(t, byName["outside"].ContainerStates, "block closed before .outside")
+
+	byName["nav-item__label"].GoName = "NavItemLabel"
+	out := formatConstant(byName["nav-item__label"], Config{Format: "markdown"})
+	assert.Contains(t, out, "// - `@container sidebar (min-width: 400px)`")
+}
+
+func TestPseudoElements(t *testing.T) {
+	css := `.icon { display: inline-block; }
+.icon::before { content: "*"; display: block; }
+.tooltip { position: relative; }
+.tooltip:hover::after { content: attr(data-tip); }
+.tooltip::after { opacity: 0; }
+.legacy:before { content: ""; }
+.tab::part(label) { color: red; }`
+
+	classes, err := ParseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*CSSClass)
+	for _, c := range classes {
+		byName[c.Name] = c
+	}
+
+	icon := byName["icon"]
+	require.NotNil(t, icon)
+	assert.Equal(t, []string{"::before"}, icon.PseudoElements)
+	assert.Equal(t, map[string]string{"display": "inline-block"}, icon.Properties, "::before properties stay off the element")
+	assert.Empty(t, icon.PseudoStates)
+
+	tooltip := byName["tooltip"]
+	require.NotNil(t, tooltip)
+	assert.Equal(t, []string{"::after"}, tooltip.PseudoElements)
+	assert.Equal(t, map[string]string{"position": "relative"}, tooltip.Properties)
+	assert.Empty(t, tooltip.PseudoStateProperties, ":hover::after styles the pseudo-element, not the tooltip")
+
+	assert.Equal(t, []string{"::before"}, byName["legacy"].PseudoElements)
+	assert.Empty(t, byName["legacy"].PseudoStates)
+	assert.Equal(t, []string{"::part"}, byName["tab"].PseudoElements)
+
+	icon.GoName = "Icon"
+	assert.Contains(t, formatConstant(icon, Config{Format: "markdown"}), "// **Pseudo-elements:** `::before`")
+}
+
+func TestMediaPrintAnnotation(t *testing.T) {
+	css := `.btn { color: red; }
+
+@media print {
+	.no-print { display: none; }
+	.btn { color: black; }
+}
+
+@media (min-width: 600px) {
+	.wide { width: 100%; }
+}`
+
+	classes, err := ParseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*CSSClass)
+	for _, c := range classes {
+		byName[c.Name] = c
+	}
+
+	noPrint := byName["no-print"]
+	require.NotNil(t, noPrint)
+	assert.Equal(t, []string{"print"}, noPrint.MediaStates)
+	assert.True(t, noPrint.MediaOnly())
+	assert.False(t, byName["btn"].MediaOnly(), ".btn also has a rule outside @media")
+	assert.Equal(t, []string{"(min-width: 600px)"}, byName["wide"].MediaStates)
+
+	noPrint.GoName = "NoPrint"
+	for _, format := range []string{"markdown", "compact"} {
+		out := formatConstant(noPrint, Config{Format: format})
+		assert.Contains(t, out, "// only under @media print", format)
+	}
+
+	byName["btn"].GoName = "Btn"
+	assert.NotContains(t, formatConstant(byName["btn"], Config{Format: "markdown"}), "only under")
+}
+
+// mapKeys returns the keys of a class map
+func mapKeys(m map[string]*CSSClass) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
 func TestLayerInferenceWindowsPaths(t *testing.T) {
 	// Test that Windows-style paths (with backslashes) work correctly
 	// filepath.ToSlash should convert them
@@ -392,12 +899,63 @@ func TestPropertyCategorization(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.property, func(t *testing.T) {
-			result := categorizeProperty(tt.property)
+			result := categorizeProperty(tt.property, Config{})
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
 
+func TestCategoryOverrides(t *testing.T) {
+	props := map[string]string{
+		"scroll-snap-type": "x mandatory",
+		"color":            "red",
+		"display":          "flex",
+	}
+	overrides := map[string]PropertyCategory{
+		"scroll-snap-type": CategoryEffects,
+		"color":            CategoryTypography,
+	}
+
+	categorized := categorizeProperties(props, Config{CategoryOverrides: overrides})
+
+	require.Len(t, categorized[CategoryEffects], 1)
+	assert.Equal(t, "scroll-snap-type", categorized[CategoryEffects][0].Name)
+	require.Len(t, categorized[CategoryTypography], 1)
+	assert.Equal(t, "color", categorized[CategoryTypography][0].Name)
+	assert.Empty(t, categorized[CategoryVisual])
+	require.Len(t, categorized[CategoryLayout], 1)
+	assert.Equal(t, "display", categorized[CategoryLayout][0].Name)
+
+	err := validateCategoryOverrides(map[string]PropertyCategory{"gap": "Spacing"})
+	assert.ErrorContains(t, err, `unknown category "Spacing"`)
+}
+
+func TestInternalPrefixes(t *testing.T) {
+	tests := []struct {
+		name     string
+		property string
+		config   Config
+		expected PropertyCategory
+	}{
+		{"custom prefix is internal", "-x-brand-glow", Config{InternalPrefixes: []string{"-x-"}}, CategoryInternal},
+		{"default prefix dropped from custom list", "-moz-appearance", Config{InternalPrefixes: []string{"-webkit-"}}, CategoryLayout},
+		{"prefixed known property stays internal by default", "-webkit-transform", Config{}, CategoryInternal},
+		{"unprefix maps to the unprefixed category", "-webkit-transform", Config{UnprefixVendor: true}, CategoryEffects},
+		{"unprefix maps typography", "-moz-hyphens", Config{UnprefixVendor: true}, CategoryTypography},
+		{"unprefix keeps unknown properties internal", "-webkit-font-smoothing", Config{UnprefixVendor: true}, CategoryInternal},
+		{"unprefix uses the custom prefixes", "-x-transform", Config{InternalPrefixes: []string{"-x-"}, UnprefixVendor: true}, CategoryEffects},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, categorizeProperty(tt.property, tt.config))
+		})
+	}
+
+	assert.Error(t, validateInternalPrefixes([]string{"-webkit-", ""}))
+	assert.NoError(t, validateInternalPrefixes(nil))
+}
+
 func TestTokenDetection(t *testing.T) {
 	tests := []struct {
 		value    string
@@ -555,6 +1113,112 @@ func TestIntentExtraction(t *testing.T) {
 	assert.Equal(t, "Inline comment style", infoBadge.Intent)
 }
 
+func TestIntentPrefixedClassName(t *testing.T) {
+	css := `
+/* @intent Group wrapper */
+.btn-group {
+	display: flex;
+}
+
+/* @intent Primary action */
+.btn {
+	color: red;
+}
+
+.btn:hover, .btn--ghost {
+	color: blue;
+}
+`
+
+	classes, err := ParseCSS(css, "test.css", "components", Config{ExtractIntent: true})
+	require.NoError(t, err)
+
+	classMap := make(map[string]*CSSClass)
+	for _, c := range classes {
+		classMap[c.Name] = c
+	}
+
+	// .btn-group comes first but is a different class
+	require.Contains(t, classMap, "btn")
+	assert.Equal(t, "Primary action", classMap["btn"].Intent)
+	assert.Equal(t, 8, classMap["btn"].SourceLine)
+	assert.Equal(t, 3, classMap["btn-group"].SourceLine)
+	assert.Equal(t, 12, classMap["btn--ghost"].SourceLine)
+}
+
+func TestIntentMultiLine(t *testing.T) {
+	css := `
+/*
+ * @intent Primary call to action,
+ * one per page. see: tokens.md#primary
+ *
+ * Not part of the intent
+ */
+.btn {
+	color: red;
+}
+
+/* @intent Quiet action
+   for toolbars */
+/* unrelated note */
+.btn--ghost {
+	background: transparent;
+}
+
+// @intent Dense variant
+// see: spacing.md#compact
+.btn--compact {
+	padding: 0;
+}
+`
+
+	classes, err := ParseCSS(css, "test.css", "components", Config{ExtractIntent: true})
+	require.NoError(t, err)
+
+	intents := make(map[string]string)
+	for _, c := range classes {
+		intents[c.Name] = c.Intent
+	}
+	assert.Equal(t, "Primary call to action, one per page. see: tokens.md#primary", intents["btn"])
+	assert.Equal(t, "Quiet action for toolbars", intents["btn--ghost"])
+	assert.Equal(t, "Dense variant see: spacing.md#compact", intents["btn--compact"])
+}
+
+func TestInheritIntent(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `/* @intent Primary call to action */
+.btn { padding: 0.5rem; }
+.btn--ghost { background: transparent; }
+
+/* @intent Low-emphasis action */
+.btn--link { text-decoration: underline; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "buttons.css"), []byte(css), 0644))
+
+	generate := func(inherit bool) string {
+		_, err := Generate(Config{
+			SourceDir:     tmpDir,
+			OutputDir:     tmpDir,
+			PackageName:   "ui",
+			Includes:      []string{"*.css"},
+			Format:        "markdown",
+			PropertyLimit: 5,
+			ExtractIntent: true,
+			InheritIntent: inherit,
+		})
+		require.NoError(t, err)
+		output, err := os.ReadFile(filepath.Join(tmpDir, "styles_buttons.gen.go"))
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	output := generate(true)
+	assert.Contains(t, output, "// **Intent:** (inherited) Primary call to action\n")
+	assert.Contains(t, output, "// **Intent:** Low-emphasis action\n")
+	assert.Equal(t, 1, strings.Count(output, "(inherited)"), "only the modifier without its own intent inherits")
+
+	assert.NotContains(t, generate(false), "(inherited)")
+}
+
 // TestCompoundSelectors tests extraction of classes from compound selectors (.foo.bar)
 func TestCompoundSelectors(t *testing.T) {
 	tests := []struct {
@@ -611,6 +1275,34 @@ func TestCompoundSelectors(t *testing.T) {
 	}
 }
 
+func TestCompoundOnlyClasses(t *testing.T) {
+	css := `.nav-item--with-icon { display: flex; }
+.nav-item--with-icon.nav-item--active:hover { background: blue; }
+.card .card__title, .card > .card__body { margin: 0; }
+.foo.bar, .baz { color: red; }`
+
+	classes, err := ParseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+
+	byName := make(map[string]*CSSClass)
+	for _, c := range classes {
+		c.GoName = toGoName(c.Name)
+		byName[c.Name] = c
+	}
+
+	assert.True(t, byName["nav-item--active"].CompoundOnly())
+	assert.Equal(t, []string{"nav-item--with-icon"}, byName["nav-item--active"].CompoundWith)
+	assert.False(t, byName["nav-item--with-icon"].CompoundOnly(), "also used alone")
+	assert.False(t, byName["card__title"].CompoundOnly(), "descendant selectors are not compounds")
+	assert.False(t, byName["card__body"].CompoundOnly(), "child selectors are not compounds")
+	assert.True(t, byName["foo"].CompoundOnly())
+	assert.False(t, byName["baz"].CompoundOnly())
+
+	out := formatConstant(byName["nav-item--active"], Config{Format: "markdown"})
+	assert.Contains(t, out, "// only meaningful combined with: .nav-item--with-icon\nconst NavItemActive")
+	assert.NotContains(t, formatConstant(byName["baz"], Config{}), "only meaningful")
+}
+
 // TestFunctionalPseudoClasses tests extraction from :not(), :is(), :where()
 func TestFunctionalPseudoClasses(t *testing.T) {
 	tests := []struct {
@@ -733,3 +1425,208 @@ func TestRealWorldCSS(t *testing.T) {
 	// Note: Some classes appear multiple times in CSS but should only be extracted once
 	assert.GreaterOrEqual(t, len(classNames), 12, "Should extract at least 12 unique classes from real-world CSS")
 }
+
+func TestIDSelectorParsing(t *testing.T) {
+	css := `#main-nav { display: flex; }
+.btn { color: #fff; }
+body { background: #000; }
+#sidebar .link { color: red; }`
+
+	parsed, err := parseCSS(css, "test.css", "", Config{ExtractIDs: true})
+	require.NoError(t, err)
+
+	idNames := make(map[string]*CSSClass)
+	for _, id := range parsed.ids {
+		idNames[id.Name] = id
+	}
+	require.Len(t, idNames, 2)
+	require.Contains(t, idNames, "main-nav")
+	require.Contains(t, idNames, "sidebar")
+	assert.Equal(t, "flex", idNames["main-nav"].Properties["display"])
+
+	// Classes following an ID are still extracted; hex colors are not IDs
+	classNames := make(map[string]bool)
+	for _, c := range parsed.classes {
+		classNames[c.Name] = true
+	}
+	assert.True(t, classNames["btn"])
+	assert.True(t, classNames["link"])
+
+	// Disabled by default
+	parsed, err = parseCSS(css, "test.css", "", Config{})
+	require.NoError(t, err)
+	assert.Empty(t, parsed.ids)
+}
+
+func TestIDConstantGeneration(t *testing.T) {
+	tmpDir := t.TempDir()
+	cssContent := `#main-nav { display: flex; }
+.nav { color: red; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "nav.css"), []byte(cssContent), 0644))
+
+	config := Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+		ExtractIDs:  true,
+	}
+
+	result, err := Generate(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.IDsGenerated)
+	assert.Equal(t, 1, result.ClassesGenerated)
+
+	idsOutput, err := os.ReadFile(filepath.Join(tmpDir, "styles_ids.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(idsOutput), `const IDMainNav = "main-nav"`)
+
+	// The linter must not treat IDs as CSS classes
+	constants, allCSSClasses, err := ParseGeneratedFile(filepath.Join(tmpDir, "styles.gen.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, constants, "IDMainNav")
+	assert.False(t, allCSSClasses["main-nav"])
+	assert.Equal(t, "nav", constants["Nav"])
+}
+
+func TestGenerateWithUsageFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `.btn { color: red; }
+.card { padding: 1rem; }
+.legacy { display: none; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	usageFile := filepath.Join(tmpDir, "coverage.json")
+	require.NoError(t, os.WriteFile(usageFile, []byte(`{"usage": {"btn": 12, "card": 1}}`), 0644))
+
+	config := Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+		UsageFile:   usageFile,
+	}
+
+	result, err := Generate(config)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.ClassesGenerated)
+	assert.Equal(t, 1, result.ClassesPruned)
+
+	// The never-used class loses its constant but stays valid for the linter
+	constants, allCSSClasses, err := ParseGeneratedFile(filepath.Join(tmpDir, "styles.gen.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, constants, "Legacy")
+	assert.Equal(t, "btn", constants["Btn"])
+	assert.True(t, allCSSClasses["legacy"])
+
+	// A higher threshold also prunes rarely used classes
+	config.MinUsage = 5
+	result, err = Generate(config)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.ClassesGenerated)
+	assert.Equal(t, 2, result.ClassesPruned)
+
+	// A report without usage counts is rejected rather than pruning everything
+	require.NoError(t, os.WriteFile(usageFile, []byte(`{"unused_classes": []}`), 0644))
+	_, err = Generate(config)
+	require.ErrorContains(t, err, "no usage counts")
+}
+
+func TestGenerateLineEndings(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(`.btn { color: red; }`), 0644))
+
+	config := Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+		LineEnding:  LineEndingCRLF,
+	}
+	_, err := Generate(config)
+	require.NoError(t, err)
+
+	output, err := os.ReadFile(filepath.Join(tmpDir, "styles.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(output), "\r\n")
+	assert.Equal(t, strings.Count(string(output), "\n"), strings.Count(string(output), "\r\n"), "every line must end in CRLF")
+
+	// The linter still reads CRLF files
+	constants, _, err := ParseGeneratedFile(filepath.Join(tmpDir, "styles.gen.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "btn", constants["Btn"])
+
+	config.LineEnding = "cr"
+	_, err = Generate(config)
+	require.ErrorContains(t, err, "invalid line ending")
+}
+
+func TestBuildTagsInGeneratedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "buttons.css"), []byte(`.btn { color: red; }`), 0644))
+
+	config := Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+		BuildTags:   []string{"!prod", "linux || darwin"},
+	}
+
+	_, err := Generate(config)
+	require.NoError(t, err)
+
+	for _, name := range []string{"styles.gen.go", "styles_buttons.gen.go"} {
+		content, err := os.ReadFile(filepath.Join(tmpDir, name))
+		require.NoError(t, err)
+
+		firstLine, rest, _ := strings.Cut(string(content), "\n")
+		assert.Equal(t, "//go:build !prod && (linux || darwin)", firstLine, name)
+		assert.True(t, constraint.IsGoBuild(firstLine), name)
+		_, err = constraint.Parse(firstLine)
+		require.NoError(t, err, name)
+		assert.True(t, strings.HasPrefix(rest, "\n"), "constraint must be followed by a blank line")
+	}
+
+	// Invalid tags are rejected
+	config.BuildTags = []string{"foo &&"}
+	_, err = Generate(config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid build tag")
+}
+
+func TestCleanPropertiesWidth(t *testing.T) {
+	props := map[string]string{
+		"color":       "red",
+		"display":     "flex",
+		"padding":     "0.5rem 1rem",
+		"align-items": "center",
+	}
+
+	// Default width fits everything on one line
+	assert.Equal(t, []string{
+		"{ align-items: center; color: red; display: flex; padding: 0.5rem 1rem; }",
+	}, cleanProperties(props, 0, false))
+
+	truncated := cleanProperties(props, 40, false)
+	assert.Equal(t, []string{"{ align-items: center; color: red; di..."}, truncated)
+	assert.Len(t, truncated[0], 40)
+
+	wrapped := cleanProperties(props, 40, true)
+	assert.Equal(t, []string{
+		"{ align-items: center; color: red;",
+		"display: flex; padding: 0.5rem 1rem; }",
+	}, wrapped)
+	for _, line := range wrapped {
+		assert.LessOrEqual(t, len(line), 40)
+	}
+
+	class := &CSSClass{Name: "btn", Layer: "components", Properties: props}
+	assert.Equal(t,
+		"// @layer components | { align-items: center; color: red;\n//   display: flex; padding: 0.5rem 1rem; }",
+		formatCommentCompact(class, Config{CommentWidth: 40, WrapComments: true}))
+}
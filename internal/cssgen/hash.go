@@ -0,0 +1,55 @@
+package cssgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"path/filepath"
+	"sort"
+)
+
+// InputHash returns a hex SHA-256 over settings (e.g. the resolved config), the CSS
+// files generation would read and the styles*.gen.go files in config.OutputDir.
+// CSS paths are hashed relative to SourceDir and generated timestamps are ignored,
+// so the hash only changes with the inputs and is usable as a CI cache key
+func InputHash(config Config, settings []byte) (string, error) {
+	h := sha256.New()
+	writeHashPart(h, "settings", settings)
+
+	sources, err := readCSSSources(config)
+	if err != nil {
+		return "", err
+	}
+	sort.Slice(sources, func(i, j int) bool { return sources[i].path < sources[j].path })
+	for _, source := range sources {
+		if source.err != nil {
+			return "", fmt.Errorf("%s: %w", source.path, source.err)
+		}
+		rel, err := filepath.Rel(config.SourceDir, source.path)
+		if err != nil {
+			rel = source.path
+		}
+		writeHashPart(h, "css:"+filepath.ToSlash(rel), source.content)
+	}
+
+	names, err := generatedFileNames(config.OutputDir)
+	if err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		content, err := readGenerated(filepath.Join(config.OutputDir, name))
+		if err != nil {
+			return "", err
+		}
+		writeHashPart(h, "generated:"+name, []byte(content))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// writeHashPart adds a named, length-prefixed part so parts can't run into each other
+func writeHashPart(h hash.Hash, name string, data []byte) {
+	fmt.Fprintf(h, "%s\x00%d\x00", name, len(data))
+	h.Write(data)
+}
@@ -0,0 +1,52 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInputHash(t *testing.T) {
+	srcDir := t.TempDir()
+	outDir := t.TempDir()
+	cssFile := filepath.Join(srcDir, "app.css")
+	require.NoError(t, os.WriteFile(cssFile, []byte(".btn { color: red; }\n"), 0644))
+
+	config := Config{
+		SourceDir:   srcDir,
+		OutputDir:   outDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+	}
+	_, err := Generate(config)
+	require.NoError(t, err)
+
+	hashOf := func(settings string) string {
+		sum, err := InputHash(config, []byte(settings))
+		require.NoError(t, err)
+		return sum
+	}
+
+	first := hashOf("package: ui\n")
+	assert.Len(t, first, 64)
+	assert.Equal(t, first, hashOf("package: ui\n"), "stable for unchanged inputs")
+
+	// Regenerating only changes the timestamp, which is ignored
+	_, err = Generate(config)
+	require.NoError(t, err)
+	assert.Equal(t, first, hashOf("package: ui\n"))
+
+	assert.NotEqual(t, first, hashOf("package: css\n"), "settings are part of the hash")
+
+	require.NoError(t, os.WriteFile(cssFile, []byte(".btn { color: blue; }\n"), 0644))
+	changed := hashOf("package: ui\n")
+	assert.NotEqual(t, first, changed, "CSS contents are part of the hash")
+
+	_, err = Generate(config)
+	require.NoError(t, err)
+	assert.NotEqual(t, changed, hashOf("package: ui\n"), "generated files are part of the hash")
+}
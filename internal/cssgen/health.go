@@ -0,0 +1,40 @@
+package cssgen
+
+// Health score weights; they sum to 1 so the score stays within 0-100
+const (
+	healthWeightAdoption = 0.5 // Share of constants referenced via ui.Foo
+	healthWeightErrors   = 0.3 // 1 - errors per scanned file, floored at 0
+	healthWeightDeadCode = 0.2 // 1 - share of constants that are completely unused
+)
+
+// HealthScore blends adoption, error density and dead constants into one 0-100 number:
+//
+//	50% adoption + 30% (1 - errors per file) + 20% (1 - unused ratio)
+//
+// 80% adoption with no errors and no dead constants scores 90
+func HealthScore(result *LintResult) float64 {
+	adoption := result.UsagePercentage / 100
+
+	errorScore := 1.0
+	if result.ErrorCount > 0 {
+		errorScore = 1 - float64(result.ErrorCount)/float64(max(result.FilesScanned, 1))
+		errorScore = max(errorScore, 0)
+	}
+
+	deadScore := 1 - percentage(result.CompletelyUnused, result.TotalConstants)/100
+
+	score := healthWeightAdoption*adoption + healthWeightErrors*errorScore + healthWeightDeadCode*deadScore
+	return min(max(score*100, 0), 100)
+}
+
+// healthLabel rates a health score for reports
+func healthLabel(score float64) string {
+	switch {
+	case score >= 80:
+		return "healthy"
+	case score >= 50:
+		return "fair"
+	default:
+		return "poor"
+	}
+}
@@ -0,0 +1,49 @@
+package cssgen
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		result   LintResult
+		min, max float64
+	}{
+		{
+			name:   "80% adoption, no errors",
+			result: LintResult{TotalConstants: 10, ActuallyUsed: 8, UsagePercentage: 80, CompletelyUnused: 1, FilesScanned: 5},
+			min:    80, max: 90,
+		},
+		{
+			name:   "everything used",
+			result: LintResult{TotalConstants: 10, ActuallyUsed: 10, UsagePercentage: 100, FilesScanned: 5},
+			min:    100, max: 100,
+		},
+		{
+			name:   "errors on every file",
+			result: LintResult{TotalConstants: 10, ActuallyUsed: 8, UsagePercentage: 80, FilesScanned: 2, ErrorCount: 4},
+			min:    55, max: 65,
+		},
+		{
+			name:   "nothing used",
+			result: LintResult{TotalConstants: 10, CompletelyUnused: 10, FilesScanned: 1},
+			min:    25, max: 35,
+		},
+		{
+			name:   "empty project",
+			result: LintResult{},
+			min:    45, max: 55,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := HealthScore(&tt.result)
+			assert.GreaterOrEqual(t, score, tt.min)
+			assert.LessOrEqual(t, score, tt.max)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package cssgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// HistoryRecord is one line of the adoption history file (JSONL)
+type HistoryRecord struct {
+	Timestamp string `json:"timestamp"`
+	Errors    int    `json:"errors"`
+	Warnings  int    `json:"warnings"`
+	JSONStats
+}
+
+// AppendHistory appends the key stats of a lint run as one JSON line to path
+func AppendHistory(path string, result *LintResult) error {
+	output := buildJSONOutput(result)
+	record := HistoryRecord{
+		Timestamp: output.Timestamp,
+		Errors:    output.Summary.Errors,
+		Warnings:  output.Summary.Warnings,
+		JSONStats: output.Stats,
+	}
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("encode history record: %w", err)
+	}
+
+	// #nosec G304 - path comes from trusted configuration
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("open history: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("append history: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("append history: %w", err)
+	}
+	return nil
+}
+
+// LoadHistory reads all records from a history file, skipping blank lines
+func LoadHistory(path string) ([]HistoryRecord, error) {
+	// #nosec G304 - path comes from trusted configuration
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+	defer f.Close()
+
+	var records []HistoryRecord
+	scanner := bufio.NewScanner(f)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record HistoryRecord
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			return nil, fmt.Errorf("parse history %s:%d: %w", path, lineNum, err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read history: %w", err)
+	}
+
+	return records, nil
+}
+
+// sparkBlocks are the bar heights used by the adoption sparkline (0% -> 100%)
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders adoption percentages as a single line of block characters
+func Sparkline(records []HistoryRecord) string {
+	var sb strings.Builder
+	for _, record := range records {
+		pct := record.UsagePercentage
+		if pct < 0 {
+			pct = 0
+		}
+		if pct > 100 {
+			pct = 100
+		}
+		sb.WriteRune(sparkBlocks[int(pct/100*float64(len(sparkBlocks)-1)+0.5)])
+	}
+	return sb.String()
+}
+
+// PrintTrend outputs a sparkline and a table of adoption over time
+func PrintTrend(w io.Writer, records []HistoryRecord, useColors bool) {
+	if len(records) == 0 {
+		fmt.Fprintln(w, "No history recorded yet (run lint with --append-history)")
+		return
+	}
+
+	first, last := records[0], records[len(records)-1]
+	fmt.Fprintf(w, "%s %s\n",
+		RenderStyle(StyleCyan, "Adoption:", useColors),
+		RenderStyle(StyleGreen, Sparkline(records), useColors))
+	fmt.Fprintf(w, "%.1f%% -> %.1f%% over %s\n\n",
+		first.UsagePercentage, last.UsagePercentage, pluralizeCount(len(records), "run", "runs"))
+
+	fmt.Fprintf(w, "%-25s %9s %7s %9s %10s\n", "Timestamp", "Adoption", "Errors", "Warnings", "Hardcoded")
+	for _, record := range records {
+		fmt.Fprintf(w, "%-25s %8.1f%% %7d %9d %10d\n",
+			record.Timestamp, record.UsagePercentage, record.Errors, record.Warnings, record.HardcodedClasses)
+	}
+}
@@ -0,0 +1,48 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendHistoryWritesJSONL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".cssgen-history.jsonl")
+
+	first := &LintResult{
+		UsagePercentage: 25,
+		ClassesFound:    12,
+		Issues:          []Issue{{Severity: SeverityError}, {Severity: SeverityWarning}},
+	}
+	second := &LintResult{UsagePercentage: 75, ClassesFound: 4}
+
+	require.NoError(t, AppendHistory(path, first))
+	require.NoError(t, AppendHistory(path, second))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		assert.True(t, json.Valid([]byte(line)), "invalid JSONL record: %s", line)
+	}
+
+	records, err := LoadHistory(path)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, 1, records[0].Errors)
+	assert.Equal(t, 1, records[0].Warnings)
+	assert.Equal(t, 12, records[0].HardcodedClasses)
+	assert.InDelta(t, 75.0, records[1].UsagePercentage, 0.01)
+
+	var buf bytes.Buffer
+	PrintTrend(&buf, records, false)
+	assert.Contains(t, buf.String(), "▃▆")
+	assert.Contains(t, buf.String(), "25.0% -> 75.0% over 2 runs")
+}
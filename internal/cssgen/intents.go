@@ -0,0 +1,141 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// IntentIndex lists the classes documented with @intent, grouped by layer
+type IntentIndex struct {
+	Documented int           `json:"documented"`
+	Total      int           `json:"total"`
+	Coverage   float64       `json:"coverage"` // Documented / Total (0 with no classes)
+	Layers     []IntentLayer `json:"layers"`
+}
+
+// IntentLayer is one layer of the intent index
+type IntentLayer struct {
+	Layer      string        `json:"layer"`
+	Documented int           `json:"documented"`
+	Total      int           `json:"total"`
+	Classes    []IntentEntry `json:"classes"` // Documented classes, sorted by name
+}
+
+// IntentEntry is a class and its @intent text
+type IntentEntry struct {
+	Class  string `json:"class"`
+	Intent string `json:"intent"`
+}
+
+// intentCoverage returns the fraction of classes with their own @intent
+func intentCoverage(classes []*CSSClass) float64 {
+	if len(classes) == 0 {
+		return 0
+	}
+	var documented int
+	for _, class := range classes {
+		if class.Intent != "" {
+			documented++
+		}
+	}
+	return float64(documented) / float64(len(classes))
+}
+
+// buildIntentIndex groups classes by layer: declared layers in order, then other
+// layers alphabetically, then unlayered classes
+func buildIntentIndex(classes []*CSSClass, layerOrder []string) IntentIndex {
+	rank := make(map[string]int, len(layerOrder))
+	for i, layer := range layerOrder {
+		rank[layer] = i
+	}
+
+	byLayer := make(map[string]*IntentLayer)
+	for _, class := range classes {
+		layer := class.Layer
+		if layer == "" {
+			layer = unlayeredLabel
+		}
+		group, ok := byLayer[layer]
+		if !ok {
+			group = &IntentLayer{Layer: layer, Classes: []IntentEntry{}}
+			byLayer[layer] = group
+		}
+		group.Total++
+		if class.Intent != "" {
+			group.Documented++
+			group.Classes = append(group.Classes, IntentEntry{Class: class.Name, Intent: class.Intent})
+		}
+	}
+
+	index := IntentIndex{Total: len(classes), Coverage: intentCoverage(classes), Layers: []IntentLayer{}}
+	for _, group := range byLayer {
+		sort.Slice(group.Classes, func(i, j int) bool { return group.Classes[i].Class < group.Classes[j].Class })
+		index.Documented += group.Documented
+		index.Layers = append(index.Layers, *group)
+	}
+	sort.Slice(index.Layers, func(i, j int) bool {
+		a, b := index.Layers[i].Layer, index.Layers[j].Layer
+		ra, aDeclared := rank[a]
+		rb, bDeclared := rank[b]
+		switch {
+		case aDeclared && bDeclared:
+			return ra < rb
+		case aDeclared != bDeclared:
+			return aDeclared
+		case (a == unlayeredLabel) != (b == unlayeredLabel):
+			return b == unlayeredLabel
+		}
+		return a < b
+	})
+
+	return index
+}
+
+// renderIntentsMarkdown renders the intent index as a style guide outline
+func renderIntentsMarkdown(index IntentIndex) string {
+	var b strings.Builder
+	b.WriteString("# Class Intents\n\n")
+	fmt.Fprintf(&b, "Generated by cssgen. %d of %d classes documented (%.1f%%).\n",
+		index.Documented, index.Total, index.Coverage*100)
+
+	for _, layer := range index.Layers {
+		fmt.Fprintf(&b, "\n## %s (%d/%d)\n\n", layer.Layer, layer.Documented, layer.Total)
+		if len(layer.Classes) == 0 {
+			b.WriteString("_No documented classes._\n")
+			continue
+		}
+		for _, entry := range layer.Classes {
+			fmt.Fprintf(&b, "- `.%s` - %s\n", entry.Class, entry.Intent)
+		}
+	}
+
+	return b.String()
+}
+
+// writeIntentsFile writes the intent index as JSON (.json paths) or Markdown, creating parent dirs
+func writeIntentsFile(path string, classes []*CSSClass, layerOrder []string) error {
+	index := buildIntentIndex(classes, layerOrder)
+
+	var data []byte
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		encoded, err := json.MarshalIndent(index, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding intents: %w", err)
+		}
+		data = append(encoded, '\n')
+	} else {
+		data = []byte(renderIntentsMarkdown(index))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating intents directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("writing intents: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmitIntents(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer components, base;
+
+@layer components {
+	/* @intent Primary call-to-action button */
+	.btn { color: red; }
+	.btn--primary { background: blue; }
+}
+
+@layer base {
+	/* @intent Page wrapper with max width */
+	.page { max-width: 80rem; }
+}
+
+.stray { color: green; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	generate := func(intentsFile string) *GenerateResult {
+		result, err := Generate(Config{
+			SourceDir:     tmpDir,
+			OutputDir:     tmpDir,
+			PackageName:   "ui",
+			Includes:      []string{"*.css"},
+			Format:        "markdown",
+			ExtractIntent: true,
+			IntentsFile:   intentsFile,
+		})
+		require.NoError(t, err)
+		return result
+	}
+
+	jsonFile := filepath.Join(tmpDir, "docs", "intents.json")
+	result := generate(jsonFile)
+	assert.InDelta(t, 0.5, result.IntentCoverage, 1e-9)
+
+	data, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	var index IntentIndex
+	require.NoError(t, json.Unmarshal(data, &index))
+
+	assert.Equal(t, 2, index.Documented)
+	assert.Equal(t, 4, index.Total)
+	assert.InDelta(t, 0.5, index.Coverage, 1e-9)
+	assert.Equal(t, []IntentLayer{
+		{Layer: "components", Documented: 1, Total: 2, Classes: []IntentEntry{{Class: "btn", Intent: "Primary call-to-action button"}}},
+		{Layer: "base", Documented: 1, Total: 2, Classes: []IntentEntry{{Class: "page", Intent: "Page wrapper with max width"}}},
+	}, index.Layers)
+
+	mdFile := filepath.Join(tmpDir, "docs", "intents.md")
+	generate(mdFile)
+	md, err := os.ReadFile(mdFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(md), "2 of 4 classes documented (50.0%)")
+	assert.Contains(t, string(md), "## components (1/2)\n\n- `.btn` - Primary call-to-action button\n")
+
+	// Layers without documented classes still show their count
+	index = buildIntentIndex([]*CSSClass{{Name: "stray"}}, nil)
+	assert.Contains(t, renderIntentsMarkdown(index), "## unlayered (0/1)\n\n_No documented classes._\n")
+}
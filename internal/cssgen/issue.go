@@ -1,14 +1,27 @@
 package cssgen
 
+import "strings"
+
 // Issue represents a single linting violation in golangci-lint format
 type Issue struct {
 	FromLinter  string       `json:"FromLinter"`  // "csslint"
+	Rule        string       `json:"Rule"`        // Rule ID ("invalid-class") or custom rule name
 	Text        string       `json:"Text"`        // "invalid CSS class \"btn--outline\" not found in stylesheet"
 	Severity    string       `json:"Severity"`    // "", "warning", "error"
 	SourceLines []string     `json:"SourceLines"` // Lines of code with issue
 	Pos         IssuePos     `json:"Pos"`         // File location
 	LineRange   *LineRange   `json:"LineRange"`   // Optional range
 	Replacement *Replacement `json:"Replacement"` // Optional fix suggestion
+	DefinedAt   string       `json:"DefinedAt"`   // CSS file:line of the suggested class; kept out of Text so fingerprints survive stylesheet edits
+}
+
+// Message returns Text with the suggested class's definition, as reporters print it
+// The location goes inside the hint: (did you mean "btn"? defined at button.css:3)
+func (i Issue) Message() string {
+	if i.DefinedAt == "" {
+		return i.Text
+	}
+	return strings.TrimSuffix(i.Text, ")") + " defined at " + i.DefinedAt + ")"
 }
 
 // IssuePos specifies the exact location of an issue
@@ -24,10 +37,13 @@ type LineRange struct {
 	To   int `json:"To"`
 }
 
-// Replacement provides automated fix suggestion (future --fix flag)
+// Replacement provides automated fix suggestion (see --export-fixes)
 type Replacement struct {
 	NewText      string // "ui.Icon" or "btn--outlined"
 	InlineLength int    // Length of text to replace
+	OldText      string // Exact text being replaced ("\"btn\"")
+	StartColumn  int    // 1-based column of OldText in the trimmed source line
+	Unsafe       string // Why applying changes rendered output, e.g. dropped classes ("" = safe)
 }
 
 // IssueSeverity constants
@@ -39,7 +55,24 @@ const (
 
 // IssueType constants matching linter categories
 const (
-	IssueInvalidClass   = "invalid CSS class %q not found in stylesheet"
-	IssueHardcodedClass = "hardcoded CSS class %q should use %s constant"
-	IssueUnusedConstant = "exported constant %s is unused"
+	IssueInvalidClass     = "invalid CSS class %q not found in stylesheet"
+	IssueInvalidClassHint = "invalid CSS class %q not found in stylesheet (did you mean %q?)"
+	IssueInvalidClassAlts = "invalid CSS class %q not found in stylesheet (did you mean %s?)"
+	IssueHardcodedClass   = "hardcoded CSS class %q should use %s constant"
+	IssueUnusedConstant   = "exported constant %s is unused"
+	IssueRedundantClass   = "class %q is redundant: %q already sets %s"
+	IssueA11yHidden       = "class %q is for screen readers, but %q sets %s and hides the element from them too"
+	IssueStaleConstant    = "constant %s references class %q, which is missing from AllCSSClasses (regenerate)"
+	IssueEmptyClass       = "empty class attribute"
+)
+
+// Rule IDs accepted by cssgen:ignore directives and LintConfig.Only
+const (
+	RuleInvalidClass   = "invalid-class"
+	RuleHardcodedClass = "hardcoded-class"
+	RuleUnusedConstant = "unused-constant"
+	RuleRedundantClass = "redundant-class"
+	RuleStaleConstant  = "stale-constant"
+	RuleEmptyClass     = "empty-class"
+	RuleA11yHidden     = "a11y-hidden" // Experimental, only with LintConfig.CheckA11y
 )
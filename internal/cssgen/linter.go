@@ -40,37 +40,108 @@ import (
 	"go/parser"
 	"go/token"
 	"io"
+	"os"
+	"path"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 )
 
+// ignoreDirective suppresses issues reported on the line that contains it
+const ignoreDirective = "cssgen:ignore"
+
+// knownRules are the rule IDs a cssgen:ignore directive may name
+var knownRules = map[string]bool{
+	RuleInvalidClass:   true,
+	RuleHardcodedClass: true,
+	RuleUnusedConstant: true,
+	RuleRedundantClass: true,
+	RuleStaleConstant:  true,
+	RuleEmptyClass:     true,
+	RuleA11yHidden:     true,
+}
+
 // LintConfig holds linting configuration
 type LintConfig struct {
-	ScanPaths     []string // Patterns to scan (e.g., "internal/web/features/**/*.templ")
-	GeneratedFile string   // Path to styles.gen.go
-	PackageName   string   // "ui"
-	Verbose       bool
-	Strict        bool    // Exit with code 1 if issues found
-	Threshold     float64 // Minimum adoption percentage (for -strict mode)
+	ScanPaths        []string // Patterns to scan (e.g., "internal/web/features/**/*.templ")
+	PathsFrom        string   // File listing extra paths to scan, one per line (no glob expansion)
+	LiteralPaths     []string // Extra paths to scan as-is (no glob expansion), e.g. staged files
+	UsagePaths       []string // Extra patterns scanned only for constant references, e.g. handler code
+	Exclude          []string // Glob patterns of matched files to skip, e.g. "**/legacy/**"
+	Attributes       []string // Attributes holding class strings (default: ["class"]), e.g. "data-class"
+	StructTags       bool     // Also scan class:"..." in Go struct tags
+	DataKeys         []string // Keys holding class strings in scanned .yaml/.json files (nil = off), e.g. "class"
+	ToggleAttributes []string // Attributes holding class toggle expressions (nil = off), e.g. ":class", "x-bind:class"
+	GeneratedFile    string   // Path to styles.gen.go
+	PackageName      string   // "ui"
+	PackageAlias     string   // Qualifier used in suggestions, e.g. "css" for css.Btn (default: "ui")
+	ImportPath       string   // Import path shown in the import hint, e.g. "example.com/app/web/ui"
+	DefaultLayer     string   // Layer guessed for unused constants matching no heuristic (default: "base")
+	Verbose          bool
+	Strict           bool    // Exit with code 1 if issues found
+	Threshold        float64 // Minimum adoption percentage (for -strict mode)
+
+	// LayerThresholds sets a minimum adoption percentage per @layer (for -strict mode)
+	LayerThresholds map[string]float64 // {"components": 80}
+
+	// DynamicPatterns are regexes for classes built at runtime, treated as valid
+	DynamicPatterns []string // ["^btn--.*$"]
+
+	// Only restricts issues to these rule IDs (empty = all rules)
+	Only []string // ["invalid-class"]
+
+	// CustomRules flag referenced classes matching a regex (lint.custom-rules)
+	CustomRules []CustomRule
 
 	// New golangci-style configuration
-	MaxIssuesPerLinter int  // 0 = unlimited (default)
-	MaxSameIssues      int  // 0 = unlimited (default)
-	ShowStats          bool // Show statistics summary (auto-enabled with Verbose)
-	PrintIssuedLines   bool // Show source lines with issues (default: true)
-	PrintLinterName    bool // Show (csslint) suffix (default: true)
-	UseColors          bool // Enable color output (default: auto-detect)
+	MaxIssuesPerLinter int     // Issues printed by the issues and full formats; 0 = unlimited (default)
+	MaxSameIssues      int     // Repeats of one message printed by the issues and full formats; 0 = unlimited (default)
+	ShowStats          bool    // Show statistics summary (auto-enabled with Verbose)
+	PrintIssuedLines   bool    // Show source lines with issues (default: true)
+	PrintLinterName    bool    // Show (csslint) suffix (default: true)
+	UseColors          bool    // Enable color output (default: auto-detect)
+	NoColor            bool    // Disable color output; wins over UseColors and auto-detection
+	ProgressThreshold  int     // Print "Scanning complete" above this many files (0 = disabled)
+	ComponentScope     bool    // Group hardcoded classes per templ component in Quick Wins
+	GroupBy            GroupBy // Group issues under headers (default: none)
+	PathFilter         string  // Only print issues in files matching this glob (stats stay complete)
+	MaxFiles           int     // Abort if the scan paths match more files (0 = unlimited, default)
+
+	// Quick Wins configuration
+	QuickWinMinOccurrences int // Drop Quick Wins below this count (default: 1)
+	SuggestLimit           int // Most "did you mean" candidates per invalid class (default: 1)
+
+	// Suggestion thresholds, taken literally: 0 suggests removal on any unused constant
+	// and turns the low-adoption check off
+	UnusedWarnThreshold  int     // Suggest removing unused constants above this many (CLI default: 50; 0 suggests on any)
+	LowAdoptionThreshold float64 // Suggest Quick Wins below this usage percentage (CLI default: 20; 0 never suggests)
+
+	// Dead-code enforcement
+	ErrorOnUnused bool // Report completely unused constants as errors
+
+	// Experimental accessibility checks (e.g. sr-only combined with display: none)
+	CheckA11y bool
+
+	// Scanner diagnostics
+	DebugScan bool // List each skipped file and the reason
+
+	// Baseline configuration
+	BaselineFile string // JSON report with previously accepted issues
+	NewOnly      bool   // Report only issues missing from the baseline
 }
 
 // LintResult contains linting analysis results
 type LintResult struct {
 	// Statistics
-	TotalConstants        int     // 229
-	ActuallyUsed          int     // Constants referenced via ui.ConstName (e.g., 0)
-	AvailableForMigration int     // Constants that match hardcoded strings (e.g., 111)
-	CompletelyUnused      int     // No usage, no matches (e.g., 118)
-	UsagePercentage       float64 // Percentage of actually used constants (e.g., 0%)
+	TotalConstants        int             // 229
+	ActuallyUsed          int             // Constants referenced via ui.ConstName (e.g., 0)
+	AvailableForMigration int             // Constants that match hardcoded strings (e.g., 111)
+	CompletelyUnused      int             // No usage, no matches (e.g., 118)
+	UsagePercentage       float64         // Percentage of actually used constants (e.g., 0%)
+	HealthScore           float64         // 0-100 blend of adoption, error density and dead constants
+	LayerAdoption         []LayerCoverage // Used/total constants per @layer, sorted by layer
+	PackageAlias          string          // Qualifier used in suggestions (e.g., "ui")
 
 	// Issues in golangci-lint format
 	Issues           []Issue            // All issues found
@@ -84,7 +155,11 @@ type LintResult struct {
 	ClassesFound     int // Total hardcoded classes found
 	ConstantsFound   int // Total ui.Foo references found
 	ErrorCount       int // Count of invalid classes
-	TruncatedCount   int // Issues removed due to limits
+	TruncatedCount   int // Issues left unprinted due to limits (set by WriteOutput)
+
+	// Baseline
+	BaselineApplied    bool // True if issues were filtered against a baseline
+	BaselineSuppressed int  // Issues removed because they exist in the baseline
 
 	// Summary
 	Warnings    []string
@@ -98,6 +173,10 @@ type UnusedClass struct {
 	CSSClass  string // "app-sidebar"
 	Layer     string // "components"
 	DefinedIn string // "styles.gen.go:123"
+
+	// Where the declaration (with its doc comment) sits, for pruning
+	File        string    // "internal/web/ui/styles.gen.go"
+	Declaration LineRange // {From: 110, To: 123}
 }
 
 // InvalidClass represents a class that doesn't exist in CSS
@@ -125,6 +204,7 @@ type HardcodedString struct {
 	Suggestion     ConstantSuggestion // Smart suggestion with analysis
 	Location       FileLocation
 	LineContent    string // Full line for context
+	Component      string // Enclosing templ component ("" outside one)
 }
 
 // MatchType indicates how a class was matched to a constant
@@ -163,8 +243,20 @@ type QuickWin struct {
 
 // QuickWinsSummary categorizes quick wins by refactoring pattern
 type QuickWinsSummary struct {
-	SingleClass []QuickWin // Single class: "btn" -> ui.Btn
-	MultiClass  []QuickWin // Multiple classes: "btn btn--brand" -> { ui.Btn, ui.BtnBrand }
+	SingleClass []QuickWin       // Single class: "btn" -> ui.Btn
+	MultiClass  []QuickWin       // Multiple classes: "btn btn--brand" -> { ui.Btn, ui.BtnBrand }
+	Components  []ComponentScope // Hardcoded classes per templ component (only with LintConfig.ComponentScope)
+}
+
+// ComponentScope collects the hardcoded classes spread over the elements of one
+// templ component, e.g. class="btn" on one line and class="btn--primary" on the
+// next, which per-element Quick Wins can't relate. Grouping is per file (best-effort)
+type ComponentScope struct {
+	File       string   // "internal/web/components/button.templ"
+	Component  string   // "Button"
+	Elements   int      // Hardcoded class attributes grouped
+	Classes    []string // Distinct hardcoded classes with a constant, sorted
+	Suggestion string   // "ui.Btn, ui.BtnPrimary"
 }
 
 // CSSLookup provides fast lookups for CSS class -> constant mapping
@@ -182,12 +274,36 @@ type CSSLookup struct {
 	// AllCSSClasses: All classes found in CSS (for static analysis)
 	// Used to detect invalid class references (typos)
 	AllCSSClasses map[string]bool
+
+	// Definitions: Where each constant is declared in the generated files
+	Definitions map[string]constDefinition
+
+	// Layers: CSS class -> @layer noted in the generated comment
+	Layers map[string]string
+
+	// Properties: CSS class -> properties it sets (from CSSClassProperties)
+	Properties map[string]map[string]string
+
+	// Sources: CSS class -> file:line defining it (from CSSClassSources)
+	Sources map[string]string
+
+	// DynamicPatterns: Classes matching these are valid even when missing from CSS
+	DynamicPatterns []*regexp.Regexp
+
+	// CustomRules: Compiled LintConfig.CustomRules
+	CustomRules []*compiledRule
+
+	// ClassIndex: Trigram index over AllCSSClasses for "did you mean" suggestions
+	ClassIndex *classIndex
+
+	// UsedElsewhere: Constants referenced from LintConfig.UsagePaths (count as used)
+	UsedElsewhere map[string]bool
 }
 
 // Lint performs linting analysis on the codebase
 func Lint(config LintConfig) (*LintResult, error) {
 	// Step 1: Parse generated constants file
-	constants, allCSSClasses, err := ParseGeneratedFile(config.GeneratedFile)
+	constants, allCSSClasses, definitions, sources, err := parseGeneratedFiles(config.GeneratedFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse generated file: %w", err)
 	}
@@ -195,54 +311,104 @@ func Lint(config LintConfig) (*LintResult, error) {
 	// Step 2: Build lookup maps
 	lookup := buildLookupMaps(constants)
 	lookup.AllCSSClasses = allCSSClasses
+	lookup.ClassIndex = newClassIndex(allCSSClasses)
+	lookup.Definitions = definitions
+	lookup.Sources = sources
+
+	lookup.Layers, err = parseClassLayers(config.GeneratedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+	lookup.Properties, err = parseClassProperties(config.GeneratedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+	lookup.DynamicPatterns, err = CompileDynamicPatterns(config.DynamicPatterns)
+	if err != nil {
+		return nil, err
+	}
+	lookup.CustomRules, err = compileCustomRules(config.CustomRules)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateRules(config.Only, config.CustomRules...); err != nil {
+		return nil, err
+	}
 
 	// Step 3: Scan files for class references
-	references, stats, err := ScanFiles(config.ScanPaths, config.Verbose)
+	literalPaths, err := lintLiteralPaths(config)
+	if err != nil {
+		return nil, err
+	}
+
+	references, stats, err := scanFiles(config.ScanPaths, literalPaths, lintScanOptions(config), config.Verbose)
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan files: %w", err)
 	}
-	_ = stats // Stats are printed in ScanFiles if verbose
+	if config.DebugScan {
+		PrintSkippedFiles(os.Stderr, stats)
+	}
+
+	// Constants used outside the style files only count as used
+	if len(config.UsagePaths) > 0 {
+		lookup.UsedElsewhere, err = scanConstantUsage(config)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Step 4: Count unique files
 	filesScanned := countUniqueFiles(references)
 
 	// Step 5: Analyze usage
-	result := analyzeUsage(constants, references, lookup)
+	result := analyzeUsage(constants, references, lookup, config)
 	result.FilesScanned = filesScanned
 
 	// Step 6: Generate suggestions
-	result.Suggestions = generateSuggestions(result)
+	result.Suggestions = generateSuggestions(result, config)
 
-	// Step 7: Apply issue limiting if configured
-	if config.MaxIssuesPerLinter > 0 || config.MaxSameIssues > 0 {
-		result.Issues, result.TruncatedCount = limitIssues(result.Issues, config)
+	// Step 7: Score health over every issue, accepted or not
+	result.HealthScore = HealthScore(result)
+
+	// Step 8: Drop issues already recorded in the baseline
+	if config.NewOnly && config.BaselineFile != "" {
+		baseline, err := LoadBaseline(config.BaselineFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load baseline: %w", err)
+		}
+		ApplyBaseline(result, baseline)
 	}
 
+	// Issue limits only trim what is printed (see WriteOutput), so --fix,
+	// exports and counts see every issue
 	return result, nil
 }
 
 // ParseGeneratedFile reads styles.gen.go and all related split files (styles_*.gen.go)
-// and extracts constant definitions and AllCSSClasses
+// and extracts constant definitions and AllCSSClasses (or AllCSSClassList when only it is present)
 func ParseGeneratedFile(path string) (map[string]string, map[string]bool, error) {
+	constants, allCSSClasses, _, _, err := parseGeneratedFiles(path)
+	return constants, allCSSClasses, err
+}
+
+// parseGeneratedFiles is ParseGeneratedFile plus the declaration position of each constant
+// and the CSSClassSources map (class -> file:line), empty for files generated before it existed
+func parseGeneratedFiles(path string) (map[string]string, map[string]bool, map[string]constDefinition, map[string]string, error) {
 	constants := make(map[string]string)
 	allCSSClasses := make(map[string]bool)
+	definitions := make(map[string]constDefinition)
+	sources := make(map[string]string)
+	duplicates := make(map[string][]IssuePos) // Name -> every declaration, once declared twice
 
-	// Parse main file and all split files in the same directory
-	dir := filepath.Dir(path)
-	pattern := filepath.Join(dir, "styles*.gen.go")
-	files, err := filepath.Glob(pattern)
+	files, err := generatedFilePaths(path)
 	if err != nil {
-		return nil, nil, fmt.Errorf("glob pattern error: %w", err)
-	}
-
-	// If no files found via glob, try the provided path directly
-	if len(files) == 0 {
-		files = []string{path}
+		return nil, nil, nil, nil, err
 	}
 
 	fset := token.NewFileSet()
 	for _, filePath := range files {
-		file, err := parser.ParseFile(fset, filePath, nil, 0)
+		// Comments are kept so declaration ranges include doc comments
+		file, err := parser.ParseFile(fset, filePath, nil, parser.ParseComments)
 		if err != nil {
 			// Skip files that can't be parsed (might be in progress)
 			continue
@@ -260,6 +426,20 @@ func ParseGeneratedFile(path string) (map[string]string, map[string]bool, error)
 								if lit, ok := vspec.Values[0].(*ast.BasicLit); ok {
 									value := strings.Trim(lit.Value, `"`)
 									constants[name] = value
+
+									pos := fset.Position(vspec.Names[0].Pos())
+									declared := IssuePos{Filename: filePath, Line: pos.Line, Column: pos.Column}
+									if previous, ok := definitions[name]; ok {
+										if len(duplicates[name]) == 0 {
+											duplicates[name] = []IssuePos{previous.IssuePos}
+										}
+										duplicates[name] = append(duplicates[name], declared)
+									}
+									definitions[name] = constDefinition{
+										IssuePos:  declared,
+										Lines:     declarationLines(fset, genDecl, vspec),
+										Directive: declarationDirective(genDecl, vspec),
+									}
 								}
 							}
 						}
@@ -283,6 +463,30 @@ func ParseGeneratedFile(path string) (map[string]string, map[string]bool, error)
 									}
 								}
 							}
+							// The sorted slice lists the same classes; reading it keeps
+							// files with only AllCSSClassList checkable
+							if len(vspec.Names) > 0 && vspec.Names[0].Name == "AllCSSClassList" && len(vspec.Values) > 0 {
+								if comp, ok := vspec.Values[0].(*ast.CompositeLit); ok {
+									for _, elt := range comp.Elts {
+										if lit, ok := elt.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+											allCSSClasses[strings.Trim(lit.Value, `"`)] = true
+										}
+									}
+								}
+							}
+							if len(vspec.Names) > 0 && vspec.Names[0].Name == "CSSClassSources" && len(vspec.Values) > 0 {
+								if comp, ok := vspec.Values[0].(*ast.CompositeLit); ok {
+									for _, elt := range comp.Elts {
+										if kv, ok := elt.(*ast.KeyValueExpr); ok {
+											className, okName := stringLiteral(kv.Key)
+											location, okLocation := stringLiteral(kv.Value)
+											if okName && okLocation {
+												sources[className] = location
+											}
+										}
+									}
+								}
+							}
 						}
 					}
 				}
@@ -291,7 +495,110 @@ func ParseGeneratedFile(path string) (map[string]string, map[string]bool, error)
 		})
 	}
 
-	return constants, allCSSClasses, nil
+	if len(duplicates) > 0 {
+		return nil, nil, nil, nil, newDuplicateConstantsError(duplicates)
+	}
+
+	return constants, allCSSClasses, definitions, sources, nil
+}
+
+// DuplicateConstantsError reports constants declared more than once across the
+// generated files (e.g. split files left over from a naming change), which won't compile
+type DuplicateConstantsError struct {
+	Duplicates []DuplicateConstant // Sorted by name
+}
+
+// DuplicateConstant is a constant name with every position declaring it
+type DuplicateConstant struct {
+	Name      string
+	Positions []IssuePos
+}
+
+// newDuplicateConstantsError sorts duplicates (name -> positions) into an error
+func newDuplicateConstantsError(duplicates map[string][]IssuePos) *DuplicateConstantsError {
+	err := &DuplicateConstantsError{}
+	for name, positions := range duplicates {
+		err.Duplicates = append(err.Duplicates, DuplicateConstant{Name: name, Positions: positions})
+	}
+	sort.Slice(err.Duplicates, func(i, j int) bool { return err.Duplicates[i].Name < err.Duplicates[j].Name })
+	return err
+}
+
+func (e *DuplicateConstantsError) Error() string {
+	parts := make([]string, len(e.Duplicates))
+	for i, dup := range e.Duplicates {
+		positions := make([]string, len(dup.Positions))
+		for j, pos := range dup.Positions {
+			positions[j] = fmt.Sprintf("%s:%d:%d", pos.Filename, pos.Line, pos.Column)
+		}
+		parts[i] = fmt.Sprintf("constant %s declared more than once: %s", dup.Name, strings.Join(positions, ", "))
+	}
+	return "duplicate constants in generated files (regenerate to remove stale files): " + strings.Join(parts, "; ")
+}
+
+// constDefinition locates a constant's name and its whole declaration
+type constDefinition struct {
+	IssuePos
+	Lines     LineRange // Doc comment through value, 1-based inclusive
+	Directive string    // cssgen:ignore line from the doc comment ("" if none)
+}
+
+// declarationLines returns the lines of spec including its doc comment
+// A lone `const X = "x"` keeps its doc on the GenDecl; grouped specs carry their own
+func declarationLines(fset *token.FileSet, decl *ast.GenDecl, spec *ast.ValueSpec) LineRange {
+	var start, end token.Pos = spec.Pos(), spec.End()
+	doc := spec.Doc
+	if !decl.Lparen.IsValid() {
+		start, end = decl.Pos(), decl.End()
+		doc = decl.Doc
+	}
+	if doc != nil {
+		start = doc.Pos()
+	}
+	return LineRange{From: fset.Position(start).Line, To: fset.Position(end).Line}
+}
+
+// declarationDirective returns the cssgen:ignore line in spec's doc comment ("" if none)
+func declarationDirective(decl *ast.GenDecl, spec *ast.ValueSpec) string {
+	doc := spec.Doc
+	if !decl.Lparen.IsValid() {
+		doc = decl.Doc
+	}
+	if doc == nil {
+		return ""
+	}
+	for _, comment := range doc.List {
+		if strings.Contains(comment.Text, ignoreDirective) {
+			return comment.Text
+		}
+	}
+	return ""
+}
+
+// generatedFilePaths returns styles.gen.go and its split files (styles_*.gen.go),
+// excluding the ID constants file
+func generatedFilePaths(path string) ([]string, error) {
+	// Parse main file and all split files in the same directory
+	dir := filepath.Dir(path)
+	pattern := filepath.Join(dir, "styles*.gen.go")
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("glob pattern error: %w", err)
+	}
+
+	// If no files found via glob, try the provided path directly
+	if len(matches) == 0 {
+		return []string{path}, nil
+	}
+
+	files := make([]string, 0, len(matches))
+	for _, filePath := range matches {
+		// ID constants are not CSS classes
+		if filepath.Base(filePath) != idsFileName {
+			files = append(files, filePath)
+		}
+	}
+	return files, nil
 }
 
 // buildLookupMaps creates reverse lookup maps for fast searching
@@ -314,22 +621,71 @@ func buildLookupMaps(constants map[string]string) *CSSLookup {
 	return lookup
 }
 
+// scanConstantUsage scans config.UsagePaths for constant references only
+func scanConstantUsage(config LintConfig) (map[string]bool, error) {
+	references, _, err := scanFiles(config.UsagePaths, nil, lintScanOptions(config), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan usage paths: %w", err)
+	}
+	used := make(map[string]bool)
+	for _, ref := range references {
+		if ref.IsConstant {
+			used[ref.ConstName] = true
+		}
+	}
+	return used, nil
+}
+
 // analyzeUsage compares constants with found references
-func analyzeUsage(constants map[string]string, references []ClassReference, lookup *CSSLookup) *LintResult {
+func analyzeUsage(constants map[string]string, references []ClassReference, lookup *CSSLookup, config LintConfig) *LintResult {
 	result := &LintResult{
 		TotalConstants: len(constants),
+		PackageAlias:   aliasOrDefault(config.PackageAlias),
 	}
 
 	// Track which constants are actually used (via ui.ConstName)
 	actuallyUsed := make(map[string]bool)
+	for constName := range lookup.UsedElsewhere {
+		if _, ok := constants[constName]; ok {
+			actuallyUsed[constName] = true
+		}
+	}
 	// Track which constants have migration opportunities (match hardcoded strings)
 	availableForMigration := make(map[string]bool)
+	// Constants whose classes are referenced only where constants can't be used
+	literalOnly := make(map[string]bool)
 
 	var hardcodedStrings []HardcodedString
 	var invalidClasses []InvalidClass
 	var issues []Issue
 
 	for _, ref := range references {
+		// class="" names no classes, usually a conditional that produced nothing
+		if ref.IsEmpty {
+			if ruleEnabled(config.Only, RuleEmptyClass) && !isSuppressed(ref.Location.Text, RuleEmptyClass) {
+				issues = append(issues, Issue{
+					FromLinter:  "csslint",
+					Rule:        RuleEmptyClass,
+					Text:        IssueEmptyClass,
+					Severity:    SeverityWarning,
+					SourceLines: []string{ref.Location.Text},
+					Pos: IssuePos{
+						Filename: ref.Location.File,
+						Line:     ref.Location.Line,
+						Column:   ref.Location.Column,
+					},
+				})
+			}
+			continue
+		}
+
+		for _, issue := range customRuleIssues(ref, lookup.CustomRules, lookup, config.Only) {
+			if issue.Severity == SeverityError {
+				result.ErrorCount++
+			}
+			issues = append(issues, issue)
+		}
+
 		if ref.IsConstant {
 			// This is a ui.Foo reference - actually used in code
 			actuallyUsed[ref.ConstName] = true
@@ -341,8 +697,18 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 			// Use smart solver with full class value
 			suggestion := ResolveBestConstants(ref.FullClassValue, lookup)
 
+			// Informational: classes whose properties another class on the element already sets
+			if ruleEnabled(config.Only, RuleRedundantClass) && !isSuppressed(ref.Location.Text, RuleRedundantClass) {
+				issues = append(issues, redundantClassIssues(ref, lookup.Properties)...)
+			}
+			if config.CheckA11y && ruleEnabled(config.Only, RuleA11yHidden) && !isSuppressed(ref.Location.Text, RuleA11yHidden) {
+				issues = append(issues, a11yHiddenIssues(ref, lookup.Properties)...)
+			}
+
 			// Track invalid classes and create error issues
-			if suggestion.HasInvalid {
+			if suggestion.HasInvalid && ruleEnabled(config.Only, RuleInvalidClass) &&
+				!isSuppressed(ref.Location.Text, RuleInvalidClass) {
+				cursor := newClassCursor(ref.Location.Text, ref.Location.textColumn())
 				for _, invalidClass := range suggestion.InvalidClasses {
 					invalidClasses = append(invalidClasses, InvalidClass{
 						ClassName:   invalidClass,
@@ -351,16 +717,40 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 					})
 					result.ErrorCount++
 
-					// Find the exact column for this specific invalid class
-					column := findClassColumn(ref.Location.Text, invalidClass)
+					// Find the exact column for this specific invalid class, after the previous one
+					column := cursor.next(invalidClass)
 					if column == 0 {
-						column = ref.Location.Column // fallback to original column
+						column = findClassColumn(ref.Location.Text, invalidClass)
+					}
+					if column == 0 {
+						column = ref.Location.textColumn() // fallback to the attribute's column
 					}
 
-					// Create error issue
+					// Create error issue, offering the closest real class as a fix
+					// Several candidates are listed, but only a sole one becomes a fix, and an
+					// unsafe one: the guess changes what is rendered
+					text := fmt.Sprintf(IssueInvalidClass, invalidClass)
+					var replacement *Replacement
+					definedAt := ""
+					switch near := lookup.ClassIndex.nearestN(invalidClass, config.SuggestLimit); len(near) {
+					case 0:
+					case 1:
+						text = fmt.Sprintf(IssueInvalidClassHint, invalidClass, near[0])
+						definedAt = lookup.Sources[near[0]]
+						replacement = &Replacement{
+							NewText:      near[0],
+							InlineLength: len(invalidClass),
+							OldText:      invalidClass,
+							StartColumn:  column,
+							Unsafe:       fmt.Sprintf("guesses %s for unknown class %s", near[0], invalidClass),
+						}
+					default:
+						text = fmt.Sprintf(IssueInvalidClassAlts, invalidClass, quotedAlternatives(near))
+					}
 					issues = append(issues, Issue{
 						FromLinter:  "csslint",
-						Text:        fmt.Sprintf(IssueInvalidClass, invalidClass),
+						Rule:        RuleInvalidClass,
+						Text:        text,
 						Severity:    SeverityError,
 						SourceLines: []string{ref.Location.Text},
 						Pos: IssuePos{
@@ -368,11 +758,19 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 							Line:     ref.Location.Line,
 							Column:   column,
 						},
+						Replacement: replacement,
+						DefinedAt:   definedAt,
 					})
 				}
 			}
 
-			if len(suggestion.Constants) > 0 {
+			// Only checked for invalid classes where constants can't be used;
+			// the classes are referenced all the same, so their constants aren't unused
+			if len(suggestion.Constants) > 0 && !canUseConstants(ref) {
+				for _, constName := range suggestion.Constants {
+					literalOnly[constName] = true
+				}
+			} else if len(suggestion.Constants) > 0 {
 				// Mark suggested constants as "available for migration"
 				// but NOT as "actually used"
 				for _, constName := range suggestion.Constants {
@@ -384,20 +782,23 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 					Suggestion:     suggestion,
 					Location:       ref.Location,
 					LineContent:    ref.LineContent,
+					Component:      ref.Component,
 				}
 				hardcodedStrings = append(hardcodedStrings, hs)
 
 				// NEW: Create WARNING issue for hardcoded strings (unless internal class or has invalid classes)
 				// Skip warning if the suggestion contains invalid classes (already reported as error)
-				if !hasInternalClasses(ref.FullClassValue) && !suggestion.HasInvalid {
+				if !hasInternalClasses(ref.FullClassValue) && !suggestion.HasInvalid &&
+					ruleEnabled(config.Only, RuleHardcodedClass) && !isSuppressed(ref.Location.Text, RuleHardcodedClass) {
 					column := findClassColumn(ref.Location.Text, ref.FullClassValue)
 					if column == 0 {
 						column = ref.Location.Column // fallback to original column
 					}
 
-					suggestionText := formatSuggestion(suggestion)
+					suggestionText := formatSuggestion(suggestion, aliasOrDefault(config.PackageAlias))
 					issues = append(issues, Issue{
 						FromLinter:  "csslint",
+						Rule:        RuleHardcodedClass,
 						Text:        fmt.Sprintf(IssueHardcodedClass, ref.FullClassValue, suggestionText),
 						Severity:    SeverityWarning,
 						SourceLines: []string{ref.Location.Text},
@@ -406,6 +807,7 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 							Line:     ref.Location.Line,
 							Column:   column,
 						},
+						Replacement: computeReplacement(ref.Location.Text, ref.FullClassValue, suggestion, aliasOrDefault(config.PackageAlias)),
 					})
 				}
 			}
@@ -415,12 +817,18 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 	result.ActuallyUsed = len(actuallyUsed)
 	result.AvailableForMigration = len(availableForMigration)
 	result.CompletelyUnused = result.TotalConstants - result.ActuallyUsed - result.AvailableForMigration
+	for constName := range literalOnly {
+		if !actuallyUsed[constName] && !availableForMigration[constName] {
+			result.CompletelyUnused--
+		}
+	}
 	result.HardcodedStrings = hardcodedStrings
 	result.InvalidClasses = invalidClasses
 
 	if result.TotalConstants > 0 {
 		result.UsagePercentage = float64(result.ActuallyUsed) / float64(result.TotalConstants) * 100
 	}
+	result.LayerAdoption = layerAdoption(constants, actuallyUsed, lookup.Layers)
 
 	// Combine actually used and available for migration to find what's used/referenced
 	allUsedOrReferenced := make(map[string]bool)
@@ -430,12 +838,58 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 	for k := range availableForMigration {
 		allUsedOrReferenced[k] = true
 	}
+	for k := range literalOnly {
+		allUsedOrReferenced[k] = true
+	}
+
+	// cssgen:ignore unused-constant on a declaration keeps the constant out of
+	// the unused list, so it is neither reported nor pruned
+	for name, def := range lookup.Definitions {
+		if _, ok := constants[name]; ok && !allUsedOrReferenced[name] && isSuppressed(def.Directive, RuleUnusedConstant) {
+			allUsedOrReferenced[name] = true
+			result.CompletelyUnused--
+		}
+	}
 
 	// Find unused constants (constants with no usage and no migration opportunities)
-	result.UnusedClasses = findUnusedConstants(constants, allUsedOrReferenced)
+	result.UnusedClasses = findUnusedConstants(constants, allUsedOrReferenced, config.DefaultLayer)
+	for i, unused := range result.UnusedClasses {
+		if pos, ok := lookup.Definitions[unused.ConstName]; ok {
+			result.UnusedClasses[i].DefinedIn = fmt.Sprintf("%s:%d", pos.Filename, pos.Line)
+			result.UnusedClasses[i].File = pos.Filename
+			result.UnusedClasses[i].Declaration = pos.Lines
+		}
+	}
+
+	// Optionally turn dead constants into errors pointing at their definition
+	if config.ErrorOnUnused && ruleEnabled(config.Only, RuleUnusedConstant) {
+		for _, unused := range result.UnusedClasses {
+			pos, ok := lookup.Definitions[unused.ConstName]
+			if !ok {
+				continue
+			}
+			result.ErrorCount++
+			issues = append(issues, Issue{
+				FromLinter:  "csslint",
+				Rule:        RuleUnusedConstant,
+				Text:        fmt.Sprintf(IssueUnusedConstant, unused.ConstName),
+				Severity:    SeverityError,
+				SourceLines: []string{fmt.Sprintf("const %s = %q", unused.ConstName, unused.CSSClass)},
+				Pos:         pos.IssuePos,
+			})
+		}
+	}
+
+	// Constants pointing at classes the CSS no longer has mean the generation is stale
+	if ruleEnabled(config.Only, RuleStaleConstant) {
+		issues = append(issues, staleConstantIssues(constants, lookup)...)
+	}
 
 	// Generate quick wins
-	result.QuickWins = generateQuickWins(hardcodedStrings)
+	result.QuickWins = generateQuickWins(hardcodedStrings, config.QuickWinMinOccurrences, aliasOrDefault(config.PackageAlias))
+	if config.ComponentScope {
+		result.QuickWins.Components = groupComponentScopes(hardcodedStrings, lookup, aliasOrDefault(config.PackageAlias))
+	}
 
 	// Store issues
 	result.Issues = issues
@@ -449,6 +903,72 @@ func analyzeUsage(constants map[string]string, references []ClassReference, look
 	return result
 }
 
+// staleConstantIssues warns about constants whose class is missing from AllCSSClasses,
+// e.g. after hand edits or a split file left behind by an older generation
+// Generated files without AllCSSClasses can't be checked
+func staleConstantIssues(constants map[string]string, lookup *CSSLookup) []Issue {
+	if len(lookup.AllCSSClasses) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(constants))
+	for name := range constants {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []Issue
+	for _, name := range names {
+		class := constants[name]
+		if lookup.AllCSSClasses[class] {
+			continue
+		}
+		pos, ok := lookup.Definitions[name]
+		if !ok {
+			continue
+		}
+		issues = append(issues, Issue{
+			FromLinter:  "csslint",
+			Rule:        RuleStaleConstant,
+			Text:        fmt.Sprintf(IssueStaleConstant, name, class),
+			Severity:    SeverityWarning,
+			SourceLines: []string{fmt.Sprintf("const %s = %q", name, class)},
+			Pos:         pos.IssuePos,
+		})
+	}
+	return issues
+}
+
+// layerAdoption counts used/total constants per layer (unknown layers are "unlayered")
+func layerAdoption(constants map[string]string, actuallyUsed map[string]bool, layers map[string]string) []LayerCoverage {
+	byLayer := make(map[string]*LayerCoverage)
+	for constName, cssValue := range constants {
+		layer := layers[cssValue]
+		if layer == "" {
+			layer = unlayeredLabel
+		}
+		lc, ok := byLayer[layer]
+		if !ok {
+			lc = &LayerCoverage{Layer: layer}
+			byLayer[layer] = lc
+		}
+		lc.Total++
+		if actuallyUsed[constName] {
+			lc.Used++
+		}
+	}
+
+	adoption := make([]LayerCoverage, 0, len(byLayer))
+	for _, lc := range byLayer {
+		lc.Percentage = percentage(lc.Used, lc.Total)
+		adoption = append(adoption, *lc)
+	}
+	sort.Slice(adoption, func(i, j int) bool {
+		return adoption[i].Layer < adoption[j].Layer
+	})
+	return adoption
+}
+
 // findConstantSuggestion finds the best constant match for a CSS class
 // With 1:1 mapping, this is a simple exact lookup
 func findConstantSuggestion(className string, lookup *CSSLookup) string {
@@ -464,6 +984,9 @@ func findConstantSuggestion(className string, lookup *CSSLookup) string {
 func classifyClass(className string, lookup *CSSLookup) ClassificationResult {
 	// Check if class exists in CSS
 	if !lookup.AllCSSClasses[className] {
+		if matchesDynamicPattern(className, lookup.DynamicPatterns) {
+			return ClassBypassed // Built at runtime, declared valid
+		}
 		return ClassZombie // ERROR: Class doesn't exist
 	}
 
@@ -476,6 +999,29 @@ func classifyClass(className string, lookup *CSSLookup) ClassificationResult {
 	return ClassBypassed
 }
 
+// CompileDynamicPatterns compiles the lint.dynamic-patterns regexes
+func CompileDynamicPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dynamic pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// matchesDynamicPattern reports whether className matches any dynamic pattern
+func matchesDynamicPattern(className string, dynamicPatterns []*regexp.Regexp) bool {
+	for _, re := range dynamicPatterns {
+		if re.MatchString(className) {
+			return true
+		}
+	}
+	return false
+}
+
 // ResolveBestConstants analyzes a full class string and returns the optimal constant combination.
 //
 // Algorithm (Greedy Token Matching):
@@ -555,21 +1101,47 @@ func ResolveBestConstants(classString string, lookup *CSSLookup) ConstantSuggest
 }
 
 // formatSuggestion converts a ConstantSuggestion to a human-readable string
-func formatSuggestion(s ConstantSuggestion) string {
+func formatSuggestion(s ConstantSuggestion, alias string) string {
 	if len(s.Constants) == 0 {
 		return "(no suggestion)"
 	}
 
 	if len(s.Constants) == 1 {
-		return "ui." + s.Constants[0]
+		return alias + "." + s.Constants[0]
 	}
 
 	// Multiple constants: { ui.Btn, ui.BtnBrand }
-	parts := make([]string, len(s.Constants))
-	for i, c := range s.Constants {
-		parts[i] = "ui." + c
+	return "{ " + strings.Join(qualify(alias, s.Constants), ", ") + " }"
+}
+
+// qualify prefixes constant names with the package alias: Btn -> ui.Btn
+func qualify(alias string, constants []string) []string {
+	parts := make([]string, len(constants))
+	for i, c := range constants {
+		parts[i] = alias + "." + c
 	}
-	return "{ " + strings.Join(parts, ", ") + " }"
+	return parts
+}
+
+// aliasOrDefault returns the qualifier for suggested constants ("ui" when unset)
+func aliasOrDefault(alias string) string {
+	if alias == "" {
+		return "ui"
+	}
+	return alias
+}
+
+// importHint tells users how to import the constants package
+// An alias that differs from the import path's last element is written as a named import
+func importHint(config LintConfig) string {
+	alias := aliasOrDefault(config.PackageAlias)
+	if config.ImportPath == "" {
+		return fmt.Sprintf("Import the %s package in template files", alias)
+	}
+	if path.Base(config.ImportPath) != alias {
+		return fmt.Sprintf("Import the %s package in template files: import %s %q", alias, alias, config.ImportPath)
+	}
+	return fmt.Sprintf("Import the %s package in template files: import %q", alias, config.ImportPath)
 }
 
 // isInternalClass checks if a class name starts with underscore
@@ -589,8 +1161,60 @@ func hasInternalClasses(fullClassValue string) bool {
 	return false
 }
 
+// isSuppressed reports whether line carries a cssgen:ignore directive covering rule
+// "cssgen:ignore" alone suppresses every rule; "cssgen:ignore invalid-class,hardcoded-class"
+// suppresses only the listed rules
+func isSuppressed(line, rule string) bool {
+	idx := strings.Index(line, ignoreDirective)
+	if idx == -1 {
+		return false
+	}
+
+	args := strings.Fields(line[idx+len(ignoreDirective):])
+	if len(args) == 0 {
+		return true
+	}
+
+	rules := strings.Split(args[0], ",")
+	for _, r := range rules {
+		if !knownRules[r] {
+			// Not a rule list (e.g. a free-form reason): suppress everything
+			return true
+		}
+	}
+	for _, r := range rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleEnabled reports whether rule runs given LintConfig.Only (empty = every rule)
+func ruleEnabled(only []string, rule string) bool {
+	return len(only) == 0 || contains(only, rule)
+}
+
+// ValidateRules rejects names that are not rule IDs (built in or custom)
+func ValidateRules(rules []string, custom ...CustomRule) error {
+	customNames := make(map[string]bool, len(custom))
+	for _, rule := range custom {
+		customNames[rule.Name] = true
+	}
+
+	for _, rule := range rules {
+		if !knownRules[rule] && !customNames[rule] {
+			return fmt.Errorf("unknown rule %q (want %s, %s, %s, %s, %s, %s or %s)", rule,
+				RuleInvalidClass, RuleHardcodedClass, RuleUnusedConstant, RuleRedundantClass, RuleStaleConstant,
+				RuleEmptyClass, RuleA11yHidden)
+		}
+	}
+	return nil
+}
+
 // findUnusedConstants identifies constants with 0 references
-func findUnusedConstants(constants map[string]string, usedConsts map[string]bool) []UnusedClass {
+// Layers are guessed from class names, falling back to defaultLayer ("" = base)
+func findUnusedConstants(constants map[string]string, usedConsts map[string]bool, defaultLayer string) []UnusedClass {
 	var unused []UnusedClass
 
 	for constName, cssValue := range constants {
@@ -598,7 +1222,7 @@ func findUnusedConstants(constants map[string]string, usedConsts map[string]bool
 			unused = append(unused, UnusedClass{
 				ConstName: constName,
 				CSSClass:  cssValue,
-				Layer:     inferLayer(cssValue), // Simple heuristic
+				Layer:     inferLayer(cssValue, defaultLayer), // Simple heuristic
 			})
 		}
 	}
@@ -612,7 +1236,7 @@ func findUnusedConstants(constants map[string]string, usedConsts map[string]bool
 }
 
 // inferLayer attempts to guess the layer from the CSS class name
-func inferLayer(cssClass string) string {
+func inferLayer(cssClass, fallback string) string {
 	// Simple heuristics
 	if strings.HasPrefix(cssClass, "text-") ||
 		strings.HasPrefix(cssClass, "bg-") ||
@@ -629,11 +1253,13 @@ func inferLayer(cssClass string) string {
 		return "components"
 	}
 
-	return "base"
+	// Same fallback as path inference (Config.DefaultLayer)
+	return layerOrDefault(unknownLayer, fallback)
 }
 
 // generateQuickWins identifies the most frequently hardcoded classes
-func generateQuickWins(hardcodedStrings []HardcodedString) QuickWinsSummary {
+// Entries seen fewer than minOccurrences times are dropped (values <= 1 keep everything)
+func generateQuickWins(hardcodedStrings []HardcodedString, minOccurrences int, alias string) QuickWinsSummary {
 	singleClass := make(map[string]int)
 	multiClass := make(map[string]int)
 	suggestionMap := make(map[string]string)
@@ -649,30 +1275,86 @@ func generateQuickWins(hardcodedStrings []HardcodedString) QuickWinsSummary {
 		if len(classes) == 1 && len(hs.Suggestion.Constants) == 1 {
 			// Single-class exact match
 			singleClass[hs.FullClassValue]++
-			suggestionMap[hs.FullClassValue] = "ui." + hs.Suggestion.Constants[0]
+			suggestionMap[hs.FullClassValue] = alias + "." + hs.Suggestion.Constants[0]
 		} else if len(classes) > 1 && len(hs.Suggestion.Constants) > 1 {
 			// Multi-class pattern (only if ALL classes matched)
 			multiClass[hs.FullClassValue]++
+			suggestionMap[hs.FullClassValue] = "{ " + strings.Join(qualify(alias, hs.Suggestion.Constants), ", ") + " }"
+		}
+	}
 
-			constList := make([]string, len(hs.Suggestion.Constants))
-			for i, c := range hs.Suggestion.Constants {
-				constList[i] = "ui." + c
+	return QuickWinsSummary{
+		SingleClass: sortByFrequency(singleClass, suggestionMap, minOccurrences),
+		MultiClass:  sortByFrequency(multiClass, suggestionMap, minOccurrences),
+	}
+}
+
+// groupComponentScopes groups hardcoded strings by file and templ component
+// Only components with several hardcoded elements are kept: one element is already a Quick Win
+func groupComponentScopes(hardcodedStrings []HardcodedString, lookup *CSSLookup, alias string) []ComponentScope {
+	type scopeKey struct{ file, component string }
+	elements := make(map[scopeKey]int)
+	classes := make(map[scopeKey]map[string]bool)
+	var keys []scopeKey
+
+	for _, hs := range hardcodedStrings {
+		if hs.Component == "" {
+			continue
+		}
+		key := scopeKey{hs.Location.File, hs.Component}
+		if classes[key] == nil {
+			classes[key] = make(map[string]bool)
+			keys = append(keys, key)
+		}
+		elements[key]++
+		for _, class := range strings.Fields(hs.FullClassValue) {
+			if _, ok := lookup.ExactMap[class]; ok {
+				classes[key][class] = true
 			}
-			suggestionMap[hs.FullClassValue] = "{ " + strings.Join(constList, ", ") + " }"
 		}
 	}
 
-	return QuickWinsSummary{
-		SingleClass: sortByFrequency(singleClass, suggestionMap),
-		MultiClass:  sortByFrequency(multiClass, suggestionMap),
+	var scopes []ComponentScope
+	for _, key := range keys {
+		if elements[key] < 2 {
+			continue
+		}
+		scope := ComponentScope{File: key.file, Component: key.component, Elements: elements[key]}
+		for class := range classes[key] {
+			scope.Classes = append(scope.Classes, class)
+		}
+		sort.Strings(scope.Classes)
+
+		constants := make([]string, len(scope.Classes))
+		for i, class := range scope.Classes {
+			constants[i] = lookup.ExactMap[class]
+		}
+		scope.Suggestion = strings.Join(qualify(alias, constants), ", ")
+		scopes = append(scopes, scope)
 	}
+
+	// Most elements first, then by location
+	sort.SliceStable(scopes, func(i, j int) bool {
+		if scopes[i].Elements != scopes[j].Elements {
+			return scopes[i].Elements > scopes[j].Elements
+		}
+		if scopes[i].File != scopes[j].File {
+			return scopes[i].File < scopes[j].File
+		}
+		return scopes[i].Component < scopes[j].Component
+	})
+	return scopes
 }
 
 // sortByFrequency converts frequency map to sorted QuickWin slice
-func sortByFrequency(freq map[string]int, suggestions map[string]string) []QuickWin {
+func sortByFrequency(freq map[string]int, suggestions map[string]string, minOccurrences int) []QuickWin {
 	var wins []QuickWin
 
 	for className, count := range freq {
+		// Drop low-frequency entries before the top-10 truncation
+		if count < minOccurrences {
+			continue
+		}
 		if suggestion, ok := suggestions[className]; ok {
 			wins = append(wins, QuickWin{
 				ClassName:   className,
@@ -696,19 +1378,19 @@ func sortByFrequency(freq map[string]int, suggestions map[string]string) []Quick
 }
 
 // generateSuggestions creates actionable recommendations
-func generateSuggestions(result *LintResult) []string {
+func generateSuggestions(result *LintResult, config LintConfig) []string {
 	var suggestions []string
 
 	if len(result.HardcodedStrings) > 0 {
-		suggestions = append(suggestions, "Import the ui package in template files: import \"go-scheduler/internal/web/ui\"")
+		suggestions = append(suggestions, importHint(config))
 		suggestions = append(suggestions, "Replace hardcoded strings with constants (see Quick Wins below)")
 	}
 
-	if result.CompletelyUnused > 50 {
+	if result.CompletelyUnused > config.UnusedWarnThreshold {
 		suggestions = append(suggestions, "Consider removing unused constants or adding them to templates")
 	}
 
-	if result.UsagePercentage < 20 {
+	if result.UsagePercentage < config.LowAdoptionThreshold {
 		suggestions = append(suggestions, "Low adoption detected - start with Quick Wins for maximum impact")
 	}
 
@@ -843,12 +1525,12 @@ func PrintLintReport(result *LintResult, w io.Writer, verbose bool) {
 
 		// Hardcoded strings (show first 20 with detailed analysis)
 		if len(result.HardcodedStrings) > 0 {
-			printHardcodedStringsVerbose(w, result.HardcodedStrings, useColors)
+			printHardcodedStringsVerbose(w, result.HardcodedStrings, aliasOrDefault(result.PackageAlias), useColors)
 		}
 	} else {
 		// Compact mode: show first 5 hardcoded strings
 		if len(result.HardcodedStrings) > 0 {
-			printHardcodedStringsCompact(w, result.HardcodedStrings, 5, useColors)
+			printHardcodedStringsCompact(w, result.HardcodedStrings, 5, aliasOrDefault(result.PackageAlias), useColors)
 		}
 	}
 
@@ -916,7 +1598,7 @@ func printQuickWins(w io.Writer, summary QuickWinsSummary, useColors bool) {
 }
 
 // printHardcodedStringsCompact prints a compact summary of hardcoded strings
-func printHardcodedStringsCompact(w io.Writer, hardcodedStrings []HardcodedString, limit int, useColors bool) {
+func printHardcodedStringsCompact(w io.Writer, hardcodedStrings []HardcodedString, limit int, alias string, useColors bool) {
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, RenderStyle(StyleYellow, "HARDCODED STRINGS", useColors))
 	fmt.Fprintln(w, "-------------------")
@@ -932,13 +1614,9 @@ func printHardcodedStringsCompact(w io.Writer, hardcodedStrings []HardcodedStrin
 		fmt.Fprintf(w, "   Found: %s\n", hs.FullClassValue)
 
 		if len(hs.Suggestion.Constants) == 1 {
-			fmt.Fprintf(w, "   Suggestion: Use ui.%s\n", hs.Suggestion.Constants[0])
+			fmt.Fprintf(w, "   Suggestion: Use %s.%s\n", alias, hs.Suggestion.Constants[0])
 		} else if len(hs.Suggestion.Constants) > 1 {
-			constList := make([]string, len(hs.Suggestion.Constants))
-			for i, c := range hs.Suggestion.Constants {
-				constList[i] = "ui." + c
-			}
-			suggestion := fmt.Sprintf("{ %s }", strings.Join(constList, ", "))
+			suggestion := fmt.Sprintf("{ %s }", strings.Join(qualify(alias, hs.Suggestion.Constants), ", "))
 			if hs.Suggestion.HasUnmatched {
 				fmt.Fprintf(w, "   Suggestion: Replace with %s ⚠️  (loses: %s)\n",
 					suggestion, strings.Join(hs.Suggestion.UnmatchedClasses, ", "))
@@ -953,7 +1631,7 @@ func printHardcodedStringsCompact(w io.Writer, hardcodedStrings []HardcodedStrin
 }
 
 // printHardcodedStringsVerbose prints detailed analysis of hardcoded strings
-func printHardcodedStringsVerbose(w io.Writer, hardcodedStrings []HardcodedString, useColors bool) {
+func printHardcodedStringsVerbose(w io.Writer, hardcodedStrings []HardcodedString, alias string, useColors bool) {
 	fmt.Fprintln(w, "")
 	fmt.Fprintln(w, RenderStyle(StyleYellow, "HARDCODED STRINGS (Detailed Analysis)", useColors))
 	fmt.Fprintln(w, "---------------------------------------")
@@ -970,14 +1648,14 @@ func printHardcodedStringsVerbose(w io.Writer, hardcodedStrings []HardcodedStrin
 
 		if len(hs.Suggestion.Constants) == 1 {
 			// Single constant - simple one-line format
-			fmt.Fprintf(w, "   Suggestion: Use ui.%s\n", hs.Suggestion.Constants[0])
+			fmt.Fprintf(w, "   Suggestion: Use %s.%s\n", alias, hs.Suggestion.Constants[0])
 		} else if len(hs.Suggestion.Constants) > 1 {
 			// Multiple constants - show analysis breakdown
 			fmt.Fprintln(w, "   Analysis:")
 			for _, analysis := range hs.Suggestion.Analysis {
 				switch analysis.Match {
 				case MatchExact:
-					fmt.Fprintf(w, "     • %q → ui.%s ✅\n", analysis.ClassName, analysis.Suggestion)
+					fmt.Fprintf(w, "     • %q → %s.%s ✅\n", analysis.ClassName, alias, analysis.Suggestion)
 				case MatchNone:
 					// Check if it's invalid or just bypassed
 					if hs.Suggestion.HasInvalid && contains(hs.Suggestion.InvalidClasses, analysis.ClassName) {
@@ -992,11 +1670,7 @@ func printHardcodedStringsVerbose(w io.Writer, hardcodedStrings []HardcodedStrin
 
 			// Only show replacement suggestion if no invalid classes
 			if len(hs.Suggestion.Constants) > 0 && !hs.Suggestion.HasInvalid {
-				constList := make([]string, len(hs.Suggestion.Constants))
-				for i, c := range hs.Suggestion.Constants {
-					constList[i] = "ui." + c
-				}
-				suggestion := fmt.Sprintf("{ %s }", strings.Join(constList, ", "))
+				suggestion := fmt.Sprintf("{ %s }", strings.Join(qualify(alias, hs.Suggestion.Constants), ", "))
 				if hs.Suggestion.HasUnmatched {
 					fmt.Fprintf(w, "   ⚠️  Partial Match: Replace with %s\n", suggestion)
 					fmt.Fprintf(w, "   ⚠️  WARNING: This will lose the following classes: %s\n",
@@ -1,8 +1,11 @@
 package cssgen
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -64,6 +67,25 @@ const BtnPrimary = "btn btn--primary" // Primary button
 				"btn--primary": true,
 			},
 		},
+		{
+			name: "sorted class list only",
+			content: `package ui
+
+var AllCSSClassList = []string{
+	"btn",
+	"btn--primary",
+}
+
+const Btn = "btn"
+`,
+			expectedConstants: map[string]string{
+				"Btn": "btn",
+			},
+			expectedAllCSS: map[string]bool{
+				"btn":          true,
+				"btn--primary": true,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -86,6 +108,150 @@ const BtnPrimary = "btn btn--primary" // Primary button
 	}
 }
 
+func TestParseGeneratedFileDuplicateConstants(t *testing.T) {
+	dir := t.TempDir()
+	base := filepath.Join(dir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(base, []byte("package ui\n\nvar AllCSSClasses = map[string]bool{}\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "styles_base.gen.go"), []byte("package ui\n\nconst Btn = \"btn\"\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "styles_components.gen.go"), []byte("package ui\n\nconst Card = \"card\"\n\nconst Btn = \"button\"\n"), 0644))
+
+	_, _, err := ParseGeneratedFile(base)
+	require.Error(t, err)
+
+	var dupErr *DuplicateConstantsError
+	require.ErrorAs(t, err, &dupErr)
+	require.Len(t, dupErr.Duplicates, 1)
+	assert.Equal(t, "Btn", dupErr.Duplicates[0].Name)
+	assert.Equal(t, []IssuePos{
+		{Filename: filepath.Join(dir, "styles_base.gen.go"), Line: 3, Column: 7},
+		{Filename: filepath.Join(dir, "styles_components.gen.go"), Line: 5, Column: 7},
+	}, dupErr.Duplicates[0].Positions)
+	assert.Contains(t, err.Error(), "styles_base.gen.go:3:7")
+	assert.Contains(t, err.Error(), "styles_components.gen.go:5:7")
+}
+
+func TestLintStaleConstant(t *testing.T) {
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generated, []byte(`package ui
+
+const Btn = "btn"
+
+const Card = "card"
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+}
+`), 0644))
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`<div class={ ui.Btn }></div>`+"\n"), 0644))
+
+	lint := func(only ...string) []Issue {
+		result, err := Lint(LintConfig{GeneratedFile: generated, PackageName: "ui", ScanPaths: []string{page}, Only: only})
+		require.NoError(t, err)
+		var stale []Issue
+		for _, issue := range result.Issues {
+			if issue.Rule == RuleStaleConstant {
+				stale = append(stale, issue)
+			}
+		}
+		return stale
+	}
+
+	stale := lint()
+	require.Len(t, stale, 1)
+	assert.Equal(t, `constant Card references class "card", which is missing from AllCSSClasses (regenerate)`, stale[0].Text)
+	assert.Equal(t, SeverityWarning, stale[0].Severity)
+	assert.Equal(t, IssuePos{Filename: generated, Line: 5, Column: 7}, stale[0].Pos)
+
+	assert.Empty(t, lint(RuleInvalidClass))
+}
+
+func TestLintEmptyClassAttribute(t *testing.T) {
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generated, []byte(`package ui
+
+const Btn = "btn"
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+}
+`), 0644))
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`<div class=""></div>
+<span class="   "></span>
+<p class=""></p> // cssgen:ignore empty-class
+<a class={ ui.Btn }></a>
+`), 0644))
+
+	result, err := Lint(LintConfig{GeneratedFile: generated, PackageName: "ui", ScanPaths: []string{page}})
+	require.NoError(t, err)
+
+	var empty []Issue
+	for _, issue := range result.Issues {
+		if issue.Rule == RuleEmptyClass {
+			empty = append(empty, issue)
+		}
+	}
+	require.Len(t, empty, 2)
+	assert.Equal(t, "empty class attribute", empty[0].Text)
+	assert.Equal(t, SeverityWarning, empty[0].Severity)
+	assert.Equal(t, IssuePos{Filename: page, Line: 1, Column: 6}, empty[0].Pos)
+	assert.Equal(t, IssuePos{Filename: page, Line: 2, Column: 7}, empty[1].Pos)
+
+	// Empty attributes are not hardcoded classes
+	assert.Zero(t, result.ClassesFound)
+}
+
+func TestLintComponentScope(t *testing.T) {
+	dir := t.TempDir()
+	generated := filepath.Join(dir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generated, []byte(`package ui
+
+const Btn = "btn"
+const BtnPrimary = "btn--primary"
+const Card = "card"
+
+var AllCSSClasses = map[string]bool{
+	"btn":          true,
+	"btn--primary": true,
+	"card":         true,
+}
+`), 0644))
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`package web
+
+templ Toolbar() {
+	<div class="btn"></div>
+	<span class="btn--primary"></span>
+	<a class="btn"></a>
+}
+
+templ (p Page) Panel() {
+	<div class="card"></div>
+}
+`), 0644))
+
+	config := LintConfig{GeneratedFile: generated, PackageName: "ui", ScanPaths: []string{page}}
+	result, err := Lint(config)
+	require.NoError(t, err)
+	assert.Empty(t, result.QuickWins.Components, "grouping is opt-in")
+
+	config.ComponentScope = true
+	result, err = Lint(config)
+	require.NoError(t, err)
+
+	// Elements of one component are grouped; Panel has a single element, so it's already a Quick Win
+	assert.Equal(t, []ComponentScope{{
+		File:       page,
+		Component:  "Toolbar",
+		Elements:   3,
+		Classes:    []string{"btn", "btn--primary"},
+		Suggestion: "ui.Btn, ui.BtnPrimary",
+	}}, result.QuickWins.Components)
+}
+
 func TestExtractClassesFromLine(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -151,6 +317,14 @@ func TestExtractClassesFromLine(t *testing.T) {
 				{FullClassValue: "btn btn--sm", IsConstant: false},
 			},
 		},
+		{
+			name: "templ.Classes with nested templ.KV",
+			line: `<div class={ templ.Classes(ui.Btn, templ.KV("active", cond)) }>`,
+			expected: []ClassReference{
+				{IsConstant: true, ConstName: "Btn"},
+				{FullClassValue: "active", IsConstant: false},
+			},
+		},
 		{
 			name:     "comment line",
 			line:     `// class="old-style"`,
@@ -241,7 +415,7 @@ func TestAnalyzeUsage(t *testing.T) {
 		{IsConstant: true, ConstName: "AppSidebar", Location: FileLocation{File: "test.templ", Line: 3}},
 	}
 
-	result := analyzeUsage(constants, references, lookup)
+	result := analyzeUsage(constants, references, lookup, LintConfig{})
 
 	assert.Equal(t, 4, result.TotalConstants)
 	assert.Equal(t, 1, result.ActuallyUsed)              // AppSidebar (actually used via ui.AppSidebar)
@@ -326,7 +500,7 @@ templ Component() {
 	assert.True(t, foundActive, "should find active")
 }
 
-func TestExpandGlobPatterns(t *testing.T) {
+func TestCollectScanFiles(t *testing.T) {
 	// Create temp directory structure
 	tmpDir, err := os.MkdirTemp("", "glob-test-*")
 	require.NoError(t, err)
@@ -350,7 +524,7 @@ func TestExpandGlobPatterns(t *testing.T) {
 
 	// Test glob pattern
 	pattern := filepath.Join(tmpDir, "**/*.templ")
-	matches, err := expandGlobPatterns([]string{pattern})
+	matches, _, err := collectScanFiles([]string{pattern}, nil, scanOptions{})
 	require.NoError(t, err)
 
 	// Should find file1.templ and subdir/file3.templ
@@ -365,25 +539,28 @@ func TestExpandGlobPatterns(t *testing.T) {
 func TestInferLayer(t *testing.T) {
 	tests := []struct {
 		cssClass string
+		fallback string
 		expected string
 	}{
-		{"text-primary", "utilities"},
-		{"bg-danger", "utilities"},
-		{"flex-row", "utilities"},
-		{"grid-cols-3", "utilities"},
-		{"btn", "components"},
-		{"btn-primary", "components"},
-		{"card", "components"},
-		{"modal", "components"},
-		{"nav-item", "components"},
-		{"table-row", "components"},
-		{"body", "base"},
-		{"container", "base"},
+		{"text-primary", "", "utilities"},
+		{"bg-danger", "", "utilities"},
+		{"flex-row", "", "utilities"},
+		{"grid-cols-3", "", "utilities"},
+		{"btn", "", "components"},
+		{"btn-primary", "", "components"},
+		{"card", "", "components"},
+		{"modal", "", "components"},
+		{"nav-item", "", "components"},
+		{"table-row", "", "components"},
+		{"body", "", "base"},
+		{"container", "", "base"},
+		{"container", "theme", "theme"},
+		{"card", "theme", "components"},
 	}
 
 	for _, tt := range tests {
-		t.Run(tt.cssClass, func(t *testing.T) {
-			result := inferLayer(tt.cssClass)
+		t.Run(tt.cssClass+"/"+tt.fallback, func(t *testing.T) {
+			result := inferLayer(tt.cssClass, tt.fallback)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
@@ -409,7 +586,7 @@ func TestGenerateQuickWins(t *testing.T) {
 		{FullClassValue: "nav-item", Suggestion: ResolveBestConstants("nav-item", lookup)},
 	}
 
-	summary := generateQuickWins(hardcodedStrings)
+	summary := generateQuickWins(hardcodedStrings, 1, "ui")
 
 	// Should be sorted by occurrences (descending)
 	require.Len(t, summary.SingleClass, 3)
@@ -421,6 +598,39 @@ func TestGenerateQuickWins(t *testing.T) {
 	assert.Equal(t, 1, summary.SingleClass[2].Occurrences)
 }
 
+func TestGenerateQuickWinsMinOccurrences(t *testing.T) {
+	constants := map[string]string{
+		"Btn":       "btn",
+		"DataTable": "data-table",
+		"NavItem":   "nav-item",
+	}
+	lookup := buildLookupMaps(constants)
+
+	var hardcodedStrings []HardcodedString
+	add := func(class string, count int) {
+		for i := 0; i < count; i++ {
+			hardcodedStrings = append(hardcodedStrings, HardcodedString{
+				FullClassValue: class,
+				Suggestion:     ResolveBestConstants(class, lookup),
+			})
+		}
+	}
+	add("btn", 10)
+	add("data-table", 3)
+	add("nav-item", 1)
+
+	summary := generateQuickWins(hardcodedStrings, 3, "ui")
+
+	require.Len(t, summary.SingleClass, 2)
+	assert.Equal(t, "btn", summary.SingleClass[0].ClassName)
+	assert.Equal(t, 10, summary.SingleClass[0].Occurrences)
+	assert.Equal(t, "data-table", summary.SingleClass[1].ClassName)
+	assert.Equal(t, 3, summary.SingleClass[1].Occurrences)
+	for _, win := range summary.SingleClass {
+		assert.NotEqual(t, "nav-item", win.ClassName)
+	}
+}
+
 func TestLintEndToEnd(t *testing.T) {
 	// Create temp directory
 	tmpDir, err := os.MkdirTemp("", "lint-e2e-*")
@@ -643,7 +853,13 @@ func TestClassifyClass(t *testing.T) {
 			class:    "fake-class",
 			expected: ClassZombie,
 		},
+		{
+			name:     "dynamic pattern - missing from CSS",
+			class:    "btn--size-xl",
+			expected: ClassBypassed,
+		},
 	}
+	lookup.DynamicPatterns = []*regexp.Regexp{regexp.MustCompile(`^btn--size-.*$`)}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -726,6 +942,64 @@ templ Page() {
 	assert.Equal(t, "btn--outline", result.InvalidClasses[0].ClassName)
 }
 
+func TestLintInvalidClassColumnsSharePrefix(t *testing.T) {
+	const line = `<button class="fake-class fake">Click</button>`
+
+	tests := []struct {
+		name   string
+		indent string
+	}{
+		{name: "unindented", indent: ""},
+		{name: "indented with spaces", indent: strings.Repeat(" ", 20)},
+		{name: "indented with tabs", indent: "\t\t"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+			require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn":  true,
+	"fame": true,
+}
+
+const Btn = "btn"
+const Fame = "fame"
+`), 0644))
+
+			templFile := filepath.Join(tmpDir, "page.templ")
+			require.NoError(t, os.WriteFile(templFile, []byte("package test\n\ntempl Page() {\n"+tt.indent+line+"\n}\n"), 0644))
+
+			result, err := Lint(LintConfig{
+				GeneratedFile: generatedFile,
+				PackageName:   "ui",
+				ScanPaths:     []string{filepath.Join(tmpDir, "*.templ")},
+			})
+			require.NoError(t, err)
+
+			// Columns are relative to the trimmed line, whatever the indent
+			var invalid []Issue
+			for _, issue := range result.Issues {
+				if issue.Rule == RuleInvalidClass {
+					invalid = append(invalid, issue)
+				}
+			}
+			require.Len(t, invalid, 2)
+			assert.Equal(t, strings.Index(line, "fake-class")+1, invalid[0].Pos.Column)
+			assert.Equal(t, strings.LastIndex(line, "fake")+1, invalid[1].Pos.Column)
+
+			// The guessed fix rewrites the "fake" token, not the start of "fake-class"
+			_, err = ApplyFixes(invalid, true)
+			require.NoError(t, err)
+			content, err := os.ReadFile(templFile)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "\n"+tt.indent+`<button class="fake-class fame">Click</button>`+"\n")
+		})
+	}
+}
+
 func TestHardcodedClassWarnings(t *testing.T) {
 	tests := []struct {
 		name             string
@@ -945,8 +1219,503 @@ func TestFormatSuggestion(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatSuggestion(tt.input)
+			got := formatSuggestion(tt.input, "ui")
 			assert.Equal(t, tt.expected, got)
 		})
 	}
 }
+
+func TestLintErrorOnUnused(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+	"stale": true,
+}
+
+const Btn = "btn"
+
+const Stale = "stale"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(templFile, []byte("templ Page() {\n\t<button class={ ui.Btn }></button>\n}\n"), 0644))
+
+	config := LintConfig{
+		ScanPaths:     []string{templFile},
+		GeneratedFile: generatedFile,
+		PackageName:   "ui",
+	}
+
+	// Informational by default
+	result, err := Lint(config)
+	require.NoError(t, err)
+	assert.Empty(t, result.Issues)
+	require.Len(t, result.UnusedClasses, 1)
+	assert.Equal(t, generatedFile+":10", result.UnusedClasses[0].DefinedIn)
+
+	config.ErrorOnUnused = true
+	result, err = Lint(config)
+	require.NoError(t, err)
+
+	require.Len(t, result.Issues, 1)
+	issue := result.Issues[0]
+	assert.Equal(t, SeverityError, issue.Severity)
+	assert.Equal(t, "exported constant Stale is unused", issue.Text)
+	assert.Equal(t, generatedFile, issue.Pos.Filename)
+	assert.Equal(t, 10, issue.Pos.Line)
+	assert.Equal(t, 7, issue.Pos.Column)
+	assert.Equal(t, 1, result.ErrorCount)
+}
+
+func TestIgnoreDirectiveSuppressesNamedRule(t *testing.T) {
+	constants := map[string]string{"Btn": "btn"}
+	lookup := buildLookupMaps(constants)
+	lookup.AllCSSClasses = map[string]bool{"btn": true}
+
+	refsOn := func(line string) []ClassReference {
+		return extractClassesFromLine(line, 4, "page.templ")
+	}
+	rulesOf := func(issues []Issue) []string {
+		var labels []string
+		for _, issue := range issues {
+			labels = append(labels, issueTypeLabel(issue))
+		}
+		return labels
+	}
+
+	tests := []struct {
+		name   string
+		line   string
+		labels []string
+	}{
+		{
+			name:   "no directive",
+			line:   `<div class={ templ.Classes("btn--outline", "btn") }></div>`,
+			labels: []string{"Invalid classes", "Hardcoded classes"},
+		},
+		{
+			name:   "invalid-class keeps hardcoded warning",
+			line:   `<div class={ templ.Classes("btn--outline", "btn") }></div> // cssgen:ignore invalid-class`,
+			labels: []string{"Hardcoded classes"},
+		},
+		{
+			name:   "hardcoded-class keeps invalid error",
+			line:   `<div class={ templ.Classes("btn--outline", "btn") }></div> // cssgen:ignore hardcoded-class`,
+			labels: []string{"Invalid classes"},
+		},
+		{
+			name:   "rule list",
+			line:   `<div class={ templ.Classes("btn--outline", "btn") }></div> // cssgen:ignore invalid-class,hardcoded-class`,
+			labels: nil,
+		},
+		{
+			name:   "bare directive suppresses all",
+			line:   `<div class={ templ.Classes("btn--outline", "btn") }></div> // cssgen:ignore legacy markup`,
+			labels: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := analyzeUsage(constants, refsOn(tt.line), lookup, LintConfig{})
+			assert.Equal(t, tt.labels, rulesOf(result.Issues))
+			assert.Equal(t, len(result.IssuesByCategory[SeverityError]), result.ErrorCount)
+		})
+	}
+}
+
+func TestIgnoreDirectiveKeepsUnusedConstant(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.css"), []byte(`.dead { color: red; }
+
+/* Rendered by the CMS, outside the scan paths
+   cssgen:ignore unused-constant */
+.legacy { color: blue; }
+`), 0644))
+	_, err := Generate(Config{SourceDir: dir, OutputDir: dir, PackageName: "ui", Includes: []string{"*.css"}})
+	require.NoError(t, err)
+
+	generated := filepath.Join(dir, "styles.gen.go")
+	data, err := os.ReadFile(filepath.Join(dir, "styles_app.gen.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "// cssgen:ignore unused-constant\nconst Legacy = \"legacy\"")
+
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package web\n"), 0644))
+	result, err := Lint(LintConfig{GeneratedFile: generated, PackageName: "ui", ScanPaths: []string{page}, ErrorOnUnused: true})
+	require.NoError(t, err)
+
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, "exported constant Dead is unused", result.Issues[0].Text)
+	require.Len(t, result.UnusedClasses, 1)
+	assert.Equal(t, "Dead", result.UnusedClasses[0].ConstName)
+	assert.Equal(t, 1, result.CompletelyUnused)
+}
+
+func TestRedundantClassIssues(t *testing.T) {
+	properties := map[string]map[string]string{
+		"btn":  {"display": "flex", "padding": "0.5rem"},
+		"flex": {"display": "flex"},
+		"grid": {"display": "grid"},
+		"row":  {"display": "flex"},
+	}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+	}{
+		{"utility duplicated by component", "btn flex", []string{`class "flex" is redundant: "btn" already sets display: flex`}},
+		{"order does not matter", "flex btn", []string{`class "flex" is redundant: "btn" already sets display: flex`}},
+		{"identical sets flag the later class", "flex row", []string{`class "row" is redundant: "flex" already sets display: flex`}},
+		{"different value is not redundant", "btn grid", nil},
+		{"single class", "flex", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := `<div class="` + tt.value + `"></div>`
+			refs := extractClassesFromLine(line, 1, "page.templ")
+			require.Len(t, refs, 1)
+
+			var texts []string
+			for _, issue := range redundantClassIssues(refs[0], properties) {
+				assert.Equal(t, SeverityInfo, issue.Severity)
+				texts = append(texts, issue.Text)
+			}
+			assert.Equal(t, tt.expected, texts)
+		})
+	}
+}
+
+func TestLintUsagePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+	"badge": true,
+	"stale": true,
+}
+
+const Btn = "btn"
+
+const Badge = "badge"
+
+const Stale = "stale"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(templFile, []byte("templ Page() {\n\t<button class={ ui.Btn }></button>\n}\n"), 0644))
+
+	// Handler code outside the scan paths: constants count, hardcoded strings don't
+	handlerFile := filepath.Join(tmpDir, "api", "handler.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(handlerFile), 0755))
+	require.NoError(t, os.WriteFile(handlerFile, []byte(`package api
+
+func status() map[string]string {
+	return map[string]string{"class": ui.Badge, "other": "not-a-class"}
+}
+
+var html = `+"`"+`<div class="typo"></div>`+"`"+`
+`), 0644))
+
+	config := LintConfig{
+		ScanPaths:     []string{templFile},
+		GeneratedFile: generatedFile,
+		PackageName:   "ui",
+	}
+
+	result, err := Lint(config)
+	require.NoError(t, err)
+	assert.Len(t, result.UnusedClasses, 2)
+
+	config.UsagePaths = []string{filepath.Join(tmpDir, "api", "**", "*.go")}
+	result, err = Lint(config)
+	require.NoError(t, err)
+
+	require.Len(t, result.UnusedClasses, 1)
+	assert.Equal(t, "Stale", result.UnusedClasses[0].ConstName)
+	assert.Equal(t, 2, result.ActuallyUsed)
+	assert.Empty(t, result.Issues, "hardcoded strings under usage paths are not linted")
+	assert.Equal(t, 1, result.FilesScanned)
+}
+
+func TestLintReportsRedundantUtility(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer components {
+		.btn { display: flex; padding: 0.5rem; }
+	}
+	@layer utilities {
+		.flex { display: flex; }
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+	_, err := Generate(Config{
+		SourceDir:     tmpDir,
+		OutputDir:     tmpDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "markdown",
+		PropertyLimit: 5,
+	})
+	require.NoError(t, err)
+
+	page := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package ui\n\ntempl A() {\n\t<div class=\"btn flex\"></div>\n}\n"), 0644))
+
+	result, err := Lint(LintConfig{
+		GeneratedFile: filepath.Join(tmpDir, "styles.gen.go"),
+		ScanPaths:     []string{page},
+	})
+	require.NoError(t, err)
+
+	var redundant []Issue
+	for _, issue := range result.Issues {
+		if issueTypeLabel(issue) == "Redundant classes" {
+			redundant = append(redundant, issue)
+		}
+	}
+	require.Len(t, redundant, 1)
+	assert.Contains(t, redundant[0].Text, `class "flex" is redundant: "btn" already sets display: flex`)
+	assert.Equal(t, 0, result.ErrorCount)
+}
+
+func TestLintDynamicPatterns(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(`.btn { color: red; }`), 0644))
+	_, err := Generate(Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+	})
+	require.NoError(t, err)
+
+	page := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package ui\n\ntempl A() {\n\t<div class=\"btn btn--anything cardd\"></div>\n}\n"), 0644))
+
+	config := LintConfig{
+		GeneratedFile:   filepath.Join(tmpDir, "styles.gen.go"),
+		ScanPaths:       []string{page},
+		DynamicPatterns: []string{`^btn--.*$`},
+	}
+	result, err := Lint(config)
+	require.NoError(t, err)
+
+	// Only the typo is invalid; btn--anything matches the dynamic pattern
+	require.Len(t, result.InvalidClasses, 1)
+	assert.Equal(t, "cardd", result.InvalidClasses[0].ClassName)
+
+	config.DynamicPatterns = []string{`^btn--(`}
+	_, err = Lint(config)
+	require.ErrorContains(t, err, "invalid dynamic pattern")
+}
+
+func TestGenerateSuggestionsThresholds(t *testing.T) {
+	const unusedAdvice = "Consider removing unused constants or adding them to templates"
+	const adoptionAdvice = "Low adoption detected - start with Quick Wins for maximum impact"
+	result := &LintResult{CompletelyUnused: 10, UsagePercentage: 30}
+
+	tests := []struct {
+		name        string
+		config      LintConfig
+		wantUnused  bool
+		wantAdopted bool
+	}{
+		{name: "cli defaults", config: LintConfig{UnusedWarnThreshold: 50, LowAdoptionThreshold: 20}, wantUnused: false, wantAdopted: false},
+		{name: "lower unused threshold", config: LintConfig{UnusedWarnThreshold: 5, LowAdoptionThreshold: 20}, wantUnused: true},
+		{name: "unused at threshold", config: LintConfig{UnusedWarnThreshold: 10, LowAdoptionThreshold: 20}, wantUnused: false},
+		{name: "zero unused threshold", config: LintConfig{UnusedWarnThreshold: 0, LowAdoptionThreshold: 20}, wantUnused: true},
+		{name: "higher adoption threshold", config: LintConfig{UnusedWarnThreshold: 50, LowAdoptionThreshold: 50}, wantAdopted: true},
+		{name: "zero adoption threshold", config: LintConfig{UnusedWarnThreshold: 50, LowAdoptionThreshold: 0}, wantAdopted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestions := generateSuggestions(result, tt.config)
+			assert.Equal(t, tt.wantUnused, contains(suggestions, unusedAdvice))
+			assert.Equal(t, tt.wantAdopted, contains(suggestions, adoptionAdvice))
+		})
+	}
+}
+
+func TestCustomPackageAliasInSuggestions(t *testing.T) {
+	constants := map[string]string{"Btn": "btn", "BtnSm": "btn--sm"}
+	lookup := buildLookupMaps(constants)
+	lookup.AllCSSClasses = map[string]bool{"btn": true, "btn--sm": true}
+
+	refs := append(
+		extractClassesFromLine(`<button class="btn"></button>`, 1, "a.templ"),
+		extractClassesFromLine(`<button class="btn btn--sm"></button>`, 2, "a.templ")...)
+	config := LintConfig{PackageAlias: "css", ImportPath: "example.com/app/web/styles"}
+	result := analyzeUsage(constants, refs, lookup, config)
+
+	require.Len(t, result.Issues, 2)
+	assert.Equal(t, `hardcoded CSS class "btn" should use css.Btn constant`, result.Issues[0].Text)
+	assert.Equal(t, `hardcoded CSS class "btn btn--sm" should use { css.Btn, css.BtnSm } constant`, result.Issues[1].Text)
+	assert.Equal(t, "{ css.Btn, css.BtnSm }", result.Issues[1].Replacement.NewText)
+	assert.Equal(t, "css.Btn", result.QuickWins.SingleClass[0].Suggestion)
+
+	assert.Contains(t, generateSuggestions(result, config),
+		`Import the css package in template files: import css "example.com/app/web/styles"`)
+
+	var buf bytes.Buffer
+	PrintLintReport(result, &buf, false)
+	assert.Contains(t, buf.String(), "Suggestion: Use css.Btn")
+	assert.NotContains(t, buf.String(), "ui.")
+}
+
+func TestImportHint(t *testing.T) {
+	assert.Equal(t, "Import the ui package in template files", importHint(LintConfig{}))
+	assert.Equal(t, `Import the ui package in template files: import "example.com/app/ui"`,
+		importHint(LintConfig{ImportPath: "example.com/app/ui"}))
+}
+
+func TestLintCheckA11y(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `.sr-only { position: absolute; width: 1px; }
+.hidden { display: none; }
+.muted { color: gray; }`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+	_, err := Generate(Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+	})
+	require.NoError(t, err)
+
+	page := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte("package ui\n\ntempl A() {\n\t<span class=\"sr-only hidden\">Menu</span>\n\t<span class=\"sr-only muted\">Close</span>\n}\n"), 0644))
+
+	a11yIssues := func(config LintConfig) []Issue {
+		result, err := Lint(config)
+		require.NoError(t, err)
+		var found []Issue
+		for _, issue := range result.Issues {
+			if issueTypeLabel(issue) == "Accessibility" {
+				found = append(found, issue)
+			}
+		}
+		return found
+	}
+
+	config := LintConfig{
+		GeneratedFile: filepath.Join(tmpDir, "styles.gen.go"),
+		ScanPaths:     []string{page},
+	}
+	assert.Empty(t, a11yIssues(config), "off unless CheckA11y is set")
+
+	config.CheckA11y = true
+	found := a11yIssues(config)
+	require.Len(t, found, 1)
+	assert.Equal(t, `class "sr-only" is for screen readers, but "hidden" sets display: none and hides the element from them too`, found[0].Text)
+	assert.Equal(t, SeverityInfo, found[0].Severity)
+	assert.Equal(t, 4, found[0].Pos.Line)
+}
+
+func TestLintCustomRules(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(`.btn { color: red; }
+.debug-outline { outline: 1px solid red; }
+.debug-grid { background: pink; }`), 0644))
+	_, err := Generate(Config{
+		SourceDir:   tmpDir,
+		OutputDir:   tmpDir,
+		PackageName: "ui",
+		Includes:    []string{"*.css"},
+		Format:      "markdown",
+	})
+	require.NoError(t, err)
+
+	page := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`package ui
+
+templ A() {
+	<div class="btn debug-outline"></div>
+	<div class={ ui.DebugGrid }></div>
+	<div class={ ui.Btn }></div>
+}
+`), 0644))
+
+	config := LintConfig{
+		GeneratedFile: filepath.Join(tmpDir, "styles.gen.go"),
+		ScanPaths:     []string{page},
+		CustomRules: []CustomRule{{
+			Name:         "no-debug-classes",
+			ClassPattern: "^debug-",
+			Message:      "debug class {{.Class}} must not ship ({{.Rule}})",
+			Severity:     "error",
+		}},
+	}
+	result, err := Lint(config)
+	require.NoError(t, err)
+
+	var custom []Issue
+	for _, issue := range result.Issues {
+		if strings.HasPrefix(issue.Text, "debug class") {
+			custom = append(custom, issue)
+		}
+	}
+	require.Len(t, custom, 2)
+	assert.Equal(t, "debug class debug-outline must not ship (no-debug-classes)", custom[0].Text)
+	assert.Equal(t, 4, custom[0].Pos.Line)
+	assert.Equal(t, "debug class debug-grid must not ship (no-debug-classes)", custom[1].Text)
+	assert.Equal(t, 5, custom[1].Pos.Line)
+	for _, issue := range custom {
+		assert.Equal(t, SeverityError, issue.Severity)
+	}
+	assert.Equal(t, 2, result.ErrorCount)
+
+	// Custom rule names work with Only
+	config.Only = []string{"no-debug-classes"}
+	result, err = Lint(config)
+	require.NoError(t, err)
+	assert.Len(t, result.Issues, 2)
+
+	config.CustomRules[0].ClassPattern = "^debug-("
+	_, err = Lint(config)
+	assert.ErrorContains(t, err, `custom rule "no-debug-classes": invalid class-pattern`)
+}
+
+func TestLintInvalidClassHintShowsDefinition(t *testing.T) {
+	dir := t.TempDir()
+	srcDir := filepath.Join(dir, "styles")
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "components"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "components", "button.css"),
+		[]byte(".btn { color: red; }\n\n.btn--primary { color: blue; }\n"), 0644))
+
+	_, err := Generate(Config{
+		SourceDir:   srcDir,
+		OutputDir:   dir,
+		PackageName: "ui",
+		Includes:    []string{"components/*.css"},
+	})
+	require.NoError(t, err)
+
+	generated := filepath.Join(dir, "styles.gen.go")
+	_, _, _, sources, err := parseGeneratedFiles(generated)
+	require.NoError(t, err)
+	assert.Equal(t, "components/button.css:3", sources["btn--primary"])
+
+	page := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(page, []byte(`<button class="btn--primray"></button>`+"\n"), 0644))
+
+	result, err := Lint(LintConfig{GeneratedFile: generated, PackageName: "ui", ScanPaths: []string{page}, Only: []string{RuleInvalidClass}})
+	require.NoError(t, err)
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, `invalid CSS class "btn--primray" not found in stylesheet (did you mean "btn--primary"? defined at components/button.css:3)`,
+		result.Issues[0].Message())
+	assert.Equal(t, fmt.Sprintf(IssueInvalidClassHint, "btn--primray", "btn--primary"), result.Issues[0].Text,
+		"the location stays out of the fingerprinted text")
+	require.NotNil(t, result.Issues[0].Replacement)
+	assert.Equal(t, "btn--primary", result.Issues[0].Replacement.NewText)
+}
@@ -0,0 +1,69 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// BEMEntry lists the modifiers and elements derived from one base class
+type BEMEntry struct {
+	Modifiers []string `json:"modifiers"` // ["btn--primary", "btn--sm"]
+	Elements  []string `json:"elements"`  // ["card__header"]
+}
+
+// BuildBEMManifest groups classes under their ParentClass (set by AnalyzeClasses)
+// Only base classes with at least one modifier or element are included
+func BuildBEMManifest(classes []*CSSClass) map[string]*BEMEntry {
+	return buildBEMManifest(classes, defaultBEM)
+}
+
+// buildBEMManifest is BuildBEMManifest with a custom BEM naming convention
+func buildBEMManifest(classes []*CSSClass, bem bemConvention) map[string]*BEMEntry {
+	manifest := make(map[string]*BEMEntry)
+
+	for _, class := range classes {
+		if class.ParentClass == nil {
+			continue
+		}
+
+		base := class.ParentClass.Name
+		entry, ok := manifest[base]
+		if !ok {
+			entry = &BEMEntry{Modifiers: []string{}, Elements: []string{}}
+			manifest[base] = entry
+		}
+
+		// detect splits on -- before __, so card__header--active is a modifier
+		if _, kind := bem.detect(class.Name); kind == bemModifier {
+			entry.Modifiers = append(entry.Modifiers, class.Name)
+		} else {
+			entry.Elements = append(entry.Elements, class.Name)
+		}
+	}
+
+	for _, entry := range manifest {
+		sort.Strings(entry.Modifiers)
+		sort.Strings(entry.Elements)
+	}
+
+	return manifest
+}
+
+// writeManifestFile writes the BEM manifest as indented JSON, creating parent dirs
+func writeManifestFile(path string, classes []*CSSClass, bem bemConvention) error {
+	data, err := json.MarshalIndent(buildBEMManifest(classes, bem), "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating manifest directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing manifest: %w", err)
+	}
+	return nil
+}
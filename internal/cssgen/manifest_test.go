@@ -0,0 +1,48 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBEMManifest(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer components {
+		.btn { color: red; }
+		.btn--primary { background: blue; }
+		.card { padding: 1rem; }
+		.card__header { font-weight: bold; }
+		.card--flat { box-shadow: none; }
+		.flex { display: flex; }
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	manifestFile := filepath.Join(tmpDir, "docs", "bem.json")
+	_, err := Generate(Config{
+		SourceDir:     tmpDir,
+		OutputDir:     tmpDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "markdown",
+		PropertyLimit: 5,
+		ManifestFile:  manifestFile,
+	})
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(manifestFile)
+	require.NoError(t, err)
+
+	var manifest map[string]BEMEntry
+	require.NoError(t, json.Unmarshal(data, &manifest))
+
+	assert.Equal(t, map[string]BEMEntry{
+		"btn":  {Modifiers: []string{"btn--primary"}, Elements: []string{}},
+		"card": {Modifiers: []string{"card--flat"}, Elements: []string{"card__header"}},
+	}, manifest)
+	assert.Contains(t, string(data), `"elements": []`)
+}
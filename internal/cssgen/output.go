@@ -3,6 +3,9 @@ package cssgen
 import (
 	"io"
 	"os"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar/v4"
 )
 
 // DetermineOutputFormat selects the appropriate output format based on flags and environment
@@ -23,6 +26,10 @@ func DetermineOutputFormat(formatFlag string, quiet bool) OutputFormat {
 			return OutputFull
 		case "json":
 			return OutputJSON
+		case "ndjson", "jsonl":
+			return OutputNDJSON
+		case "csv":
+			return OutputCSV
 		case "markdown", "md":
 			return OutputMarkdown
 		default:
@@ -40,23 +47,53 @@ func DetermineDefaultOutputFormat() OutputFormat {
 	return OutputIssues
 }
 
+// filterIssuesByPath keeps issues whose file matches the doublestar pattern
+func filterIssuesByPath(issues []Issue, pattern string) []Issue {
+	var kept []Issue
+	for _, issue := range issues {
+		if matched, _ := doublestar.Match(pattern, filepath.ToSlash(issue.Pos.Filename)); matched {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// progressOutput receives the progress indicator (stderr to avoid polluting output)
+var progressOutput io.Writer = os.Stderr
+
 // WriteOutput writes the lint result in the specified format
 func WriteOutput(w io.Writer, result *LintResult, format OutputFormat, config LintConfig) {
-	// Show progress indicator if we scanned many files (stderr to avoid polluting output)
-	if result.FilesScanned > 50 && format != OutputJSON && format != OutputMarkdown {
-		os.Stderr.WriteString("🔍 Scanning complete\n")
+	// Print and count only matching issues; statistics still cover every file
+	if config.PathFilter != "" {
+		filtered := *result
+		filtered.Issues = filterIssuesByPath(result.Issues, config.PathFilter)
+		result = &filtered
+	}
+
+	// Show progress indicator if we scanned many files
+	if format != OutputJSON && format != OutputNDJSON && format != OutputCSV && format != OutputMarkdown {
+		NewReporter(progressOutput, config).PrintProgress(*result, config.ProgressThreshold)
+	}
+
+	// Limits trim the printed issue list; the summary still counts every issue
+	shown := result.Issues
+	if config.MaxIssuesPerLinter > 0 || config.MaxSameIssues > 0 {
+		limited := *result
+		shown, limited.TruncatedCount = limitIssues(result.Issues, config)
+		result = &limited
 	}
 
 	switch format {
 	case OutputIssues:
 		// Issues only (golangci-lint format)
 		reporter := NewReporter(w, config)
-		reporter.PrintIssues(result.Issues)
+		reporter.PrintBaselineHeader(*result)
+		reporter.PrintIssues(shown)
 		reporter.PrintSummary(*result)
 
 	case OutputSummary:
 		// Statistics and Quick Wins only (no individual issues)
-		useColors := shouldUseColors(config)
+		useColors := ShouldUseColors(config)
 		verboseReporter := NewVerboseReporter(w, useColors)
 		verboseReporter.PrintStatistics(*result)
 		verboseReporter.PrintAdoptionProgress(*result)
@@ -66,7 +103,8 @@ func WriteOutput(w io.Writer, result *LintResult, format OutputFormat, config Li
 	case OutputFull:
 		// Everything: issues + statistics + quick wins
 		reporter := NewReporter(w, config)
-		reporter.PrintIssues(result.Issues)
+		reporter.PrintBaselineHeader(*result)
+		reporter.PrintIssues(shown)
 		reporter.PrintSummary(*result)
 
 		verboseReporter := NewVerboseReporter(w, reporter.UseColors())
@@ -82,6 +120,20 @@ func WriteOutput(w io.Writer, result *LintResult, format OutputFormat, config Li
 			os.Stderr.WriteString("Error writing JSON: " + err.Error() + "\n")
 		}
 
+	case OutputNDJSON:
+		// One JSON object per issue and line
+		if err := WriteNDJSON(w, result); err != nil {
+			// Log error but don't crash
+			os.Stderr.WriteString("Error writing NDJSON: " + err.Error() + "\n")
+		}
+
+	case OutputCSV:
+		// Spreadsheet rows
+		if err := WriteCSV(w, result); err != nil {
+			// Log error but don't crash
+			os.Stderr.WriteString("Error writing CSV: " + err.Error() + "\n")
+		}
+
 	case OutputMarkdown:
 		// Markdown report
 		if err := WriteMarkdown(w, result); err != nil {
@@ -0,0 +1,39 @@
+package cssgen
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// csvHeader is the first row of --output-format csv
+var csvHeader = []string{"file", "line", "column", "severity", "type", "class", "message"}
+
+// WriteCSV writes one row per issue for spreadsheet triage, after a header row
+// type is the rule ID (invalid-class, hardcoded-class, ...) or the custom rule's name
+func WriteCSV(w io.Writer, result *LintResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, issue := range result.Issues {
+		severity := issue.Severity
+		if severity == SeverityInfo {
+			severity = "info"
+		}
+		record := []string{
+			issue.Pos.Filename,
+			strconv.Itoa(issue.Pos.Line),
+			strconv.Itoa(issue.Pos.Column),
+			severity,
+			issue.Rule,
+			extractClassNameFromMessage(issue.Text),
+			issue.Message(),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
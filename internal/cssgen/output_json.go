@@ -31,19 +31,22 @@ type JSONStats struct {
 	MigrationOpportunities int     `json:"migration_opportunities"`
 	CompletelyUnused       int     `json:"completely_unused"`
 	UsagePercentage        float64 `json:"usage_percentage"`
+	HealthScore            float64 `json:"health_score"`
 	HardcodedClasses       int     `json:"hardcoded_classes"`
 	ConstantReferences     int     `json:"constant_references"`
 }
 
 // JSONIssue represents a single linting issue
 type JSONIssue struct {
-	File     string `json:"file"`
-	Line     int    `json:"line"`
-	Column   int    `json:"column"`
-	Severity string `json:"severity"`
-	Message  string `json:"message"`
-	Linter   string `json:"linter"`
-	Source   string `json:"source,omitempty"` // Optional source line
+	File        string `json:"file"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	Linter      string `json:"linter"`
+	Source      string `json:"source,omitempty"`     // Optional source line
+	DefinedAt   string `json:"defined_at,omitempty"` // CSS file:line of the suggested class
+	Fingerprint string `json:"fingerprint"`          // Stable ID used for baselines
 }
 
 // JSONQuickWins contains migration opportunities
@@ -67,6 +70,36 @@ func WriteJSON(w io.Writer, result *LintResult) error {
 	return encoder.Encode(output)
 }
 
+// WriteNDJSON writes one JSON object per issue and line, with no enclosing array
+func WriteNDJSON(w io.Writer, result *LintResult) error {
+	encoder := json.NewEncoder(w)
+	for _, issue := range result.Issues {
+		if err := encoder.Encode(newJSONIssue(issue)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newJSONIssue converts an Issue to its JSON form
+func newJSONIssue(issue Issue) JSONIssue {
+	source := ""
+	if len(issue.SourceLines) > 0 {
+		source = issue.SourceLines[0]
+	}
+	return JSONIssue{
+		File:        issue.Pos.Filename,
+		Line:        issue.Pos.Line,
+		Column:      issue.Pos.Column,
+		Severity:    issue.Severity,
+		Message:     issue.Text,
+		Linter:      issue.FromLinter,
+		Source:      source,
+		DefinedAt:   issue.DefinedAt,
+		Fingerprint: issue.Fingerprint(),
+	}
+}
+
 // buildJSONOutput converts LintResult to JSONOutput
 func buildJSONOutput(result *LintResult) JSONOutput {
 	// Count errors and warnings
@@ -83,19 +116,7 @@ func buildJSONOutput(result *LintResult) JSONOutput {
 	// Convert issues
 	jsonIssues := make([]JSONIssue, len(result.Issues))
 	for i, issue := range result.Issues {
-		source := ""
-		if len(issue.SourceLines) > 0 {
-			source = issue.SourceLines[0]
-		}
-		jsonIssues[i] = JSONIssue{
-			File:     issue.Pos.Filename,
-			Line:     issue.Pos.Line,
-			Column:   issue.Pos.Column,
-			Severity: issue.Severity,
-			Message:  issue.Text,
-			Linter:   issue.FromLinter,
-			Source:   source,
-		}
+		jsonIssues[i] = newJSONIssue(issue)
 	}
 
 	// Convert quick wins
@@ -132,6 +153,7 @@ func buildJSONOutput(result *LintResult) JSONOutput {
 			MigrationOpportunities: result.AvailableForMigration,
 			CompletelyUnused:       result.CompletelyUnused,
 			UsagePercentage:        result.UsagePercentage,
+			HealthScore:            result.HealthScore,
 			HardcodedClasses:       result.ClassesFound,
 			ConstantReferences:     result.ConstantsFound,
 		},
@@ -142,3 +164,49 @@ func buildJSONOutput(result *LintResult) JSONOutput {
 		},
 	}
 }
+
+// GenerateJSON is the `generate --output-format json` schema
+type GenerateJSON struct {
+	Version          string                `json:"version"`
+	FilesScanned     int                   `json:"files_scanned"`
+	ClassesGenerated int                   `json:"classes_generated"`
+	IDsGenerated     int                   `json:"ids_generated"`
+	ClassesPruned    int                   `json:"classes_pruned"`
+	IntentsExtracted int                   `json:"intents_extracted"`
+	IntentCoverage   float64               `json:"intent_coverage"`
+	Changes          *GenerateChanges      `json:"changes,omitempty"`
+	Warnings         []GenerateWarningJSON `json:"warnings"`
+}
+
+// GenerateWarningJSON is one generation warning
+type GenerateWarningJSON struct {
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// WriteGenerateJSON writes the generation stats and warnings as JSON
+func WriteGenerateJSON(w io.Writer, result *GenerateResult) error {
+	warnings := make([]GenerateWarningJSON, 0, len(result.Warnings))
+	for _, warning := range result.Warnings {
+		warnings = append(warnings, GenerateWarningJSON{
+			Message: warning.Message,
+			File:    warning.File,
+			Line:    warning.Line,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(GenerateJSON{
+		Version:          "1.0",
+		FilesScanned:     result.FilesScanned,
+		ClassesGenerated: result.ClassesGenerated,
+		IDsGenerated:     result.IDsGenerated,
+		ClassesPruned:    result.ClassesPruned,
+		IntentsExtracted: result.IntentsExtracted,
+		IntentCoverage:   result.IntentCoverage,
+		Changes:          result.Changes,
+		Warnings:         warnings,
+	})
+}
@@ -31,6 +31,7 @@ func WriteMarkdown(w io.Writer, result *LintResult) error {
 	fmt.Fprintf(w, "| **Total Issues** | %d (%d errors, %d warnings) |\n", len(result.Issues), errors, warnings)
 	fmt.Fprintf(w, "| **Files Scanned** | %d |\n", result.FilesScanned)
 	fmt.Fprintf(w, "| **Adoption Rate** | %.1f%% |\n", result.UsagePercentage)
+	fmt.Fprintf(w, "| **Health Score** | %.0f/100 (%s) |\n", result.HealthScore, healthLabel(result.HealthScore))
 	fmt.Fprintf(w, "| **Constants Used** | %d / %d |\n", result.ActuallyUsed, result.TotalConstants)
 	fmt.Fprintf(w, "| **Migration Opportunities** | %d |\n", result.AvailableForMigration)
 	fmt.Fprintf(w, "\n")
@@ -0,0 +1,92 @@
+package cssgen
+
+import (
+	"io"
+)
+
+// jsonOutputSchema is the JSON Schema (draft-07) for JSONOutput
+// Hand-maintained: update it together with the JSON* structs in output_json.go
+const jsonOutputSchema = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "cssgen lint output",
+  "type": "object",
+  "required": ["version", "timestamp", "summary", "stats", "issues", "quick_wins"],
+  "properties": {
+    "version": { "type": "string" },
+    "timestamp": { "type": "string", "format": "date-time" },
+    "summary": {
+      "type": "object",
+      "required": ["total_issues", "errors", "warnings", "files_scanned"],
+      "properties": {
+        "total_issues": { "type": "integer", "minimum": 0 },
+        "errors": { "type": "integer", "minimum": 0 },
+        "warnings": { "type": "integer", "minimum": 0 },
+        "files_scanned": { "type": "integer", "minimum": 0 }
+      }
+    },
+    "stats": {
+      "type": "object",
+      "required": [
+        "total_constants", "actually_used", "migration_opportunities", "completely_unused",
+        "usage_percentage", "health_score", "hardcoded_classes", "constant_references"
+      ],
+      "properties": {
+        "total_constants": { "type": "integer", "minimum": 0 },
+        "actually_used": { "type": "integer", "minimum": 0 },
+        "migration_opportunities": { "type": "integer", "minimum": 0 },
+        "completely_unused": { "type": "integer" },
+        "usage_percentage": { "type": "number", "minimum": 0, "maximum": 100 },
+        "health_score": { "type": "number", "minimum": 0, "maximum": 100 },
+        "hardcoded_classes": { "type": "integer", "minimum": 0 },
+        "constant_references": { "type": "integer", "minimum": 0 }
+      }
+    },
+    "issues": {
+      "type": ["array", "null"],
+      "items": {
+        "type": "object",
+        "required": ["file", "line", "column", "severity", "message", "linter", "fingerprint"],
+        "properties": {
+          "file": { "type": "string" },
+          "line": { "type": "integer", "minimum": 0 },
+          "column": { "type": "integer", "minimum": 0 },
+          "severity": { "type": "string", "enum": ["", "warning", "error"] },
+          "message": { "type": "string" },
+          "linter": { "type": "string" },
+          "source": { "type": "string" },
+          "defined_at": { "type": "string" },
+          "fingerprint": { "type": "string" }
+        }
+      }
+    },
+    "quick_wins": {
+      "type": "object",
+      "required": ["single_class", "multi_class"],
+      "properties": {
+        "single_class": { "$ref": "#/definitions/quickWins" },
+        "multi_class": { "$ref": "#/definitions/quickWins" }
+      }
+    }
+  },
+  "definitions": {
+    "quickWins": {
+      "type": ["array", "null"],
+      "items": {
+        "type": "object",
+        "required": ["class", "occurrences", "suggestion"],
+        "properties": {
+          "class": { "type": "string" },
+          "occurrences": { "type": "integer", "minimum": 1 },
+          "suggestion": { "type": "string" }
+        }
+      }
+    }
+  }
+}
+`
+
+// WriteJSONSchema writes the JSON Schema describing --output-format json
+func WriteJSONSchema(w io.Writer) error {
+	_, err := io.WriteString(w, jsonOutputSchema)
+	return err
+}
@@ -0,0 +1,56 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// collectSchemaProperties gathers every property name declared anywhere in a schema
+func collectSchemaProperties(node interface{}, into map[string]bool) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		if props, ok := v["properties"].(map[string]interface{}); ok {
+			for name := range props {
+				into[name] = true
+			}
+		}
+		for _, child := range v {
+			collectSchemaProperties(child, into)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectSchemaProperties(child, into)
+		}
+	}
+}
+
+func TestJSONSchemaCoversOutputTags(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONSchema(&buf))
+
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &schema))
+	require.Equal(t, "http://json-schema.org/draft-07/schema#", schema["$schema"])
+
+	properties := make(map[string]bool)
+	collectSchemaProperties(schema, properties)
+
+	for _, typ := range []reflect.Type{
+		reflect.TypeOf(JSONOutput{}),
+		reflect.TypeOf(JSONSummary{}),
+		reflect.TypeOf(JSONStats{}),
+		reflect.TypeOf(JSONIssue{}),
+		reflect.TypeOf(JSONQuickWins{}),
+		reflect.TypeOf(JSONQuickWin{}),
+	} {
+		for i := 0; i < typ.NumField(); i++ {
+			tag := strings.Split(typ.Field(i).Tag.Get("json"), ",")[0]
+			require.True(t, properties[tag], "%s.%s (json:%q) missing from schema", typ.Name(), typ.Field(i).Name, tag)
+		}
+	}
+}
@@ -2,8 +2,11 @@ package cssgen
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,6 +51,18 @@ func TestDetermineOutputFormat(t *testing.T) {
 			quiet:      false,
 			expected:   OutputJSON,
 		},
+		{
+			name:       "explicit ndjson format",
+			formatFlag: "ndjson",
+			quiet:      false,
+			expected:   OutputNDJSON,
+		},
+		{
+			name:       "explicit csv format",
+			formatFlag: "csv",
+			quiet:      false,
+			expected:   OutputCSV,
+		},
 		{
 			name:       "explicit markdown format",
 			formatFlag: "markdown",
@@ -528,3 +543,174 @@ func TestMarkdownEscaping(t *testing.T) {
 	// Verify pipes are escaped
 	assert.Contains(t, markdown, "\\|", "Pipes should be escaped in markdown tables")
 }
+
+func TestWriteOutputProgressThreshold(t *testing.T) {
+	var progress bytes.Buffer
+	original := progressOutput
+	progressOutput = &progress
+	t.Cleanup(func() { progressOutput = original })
+
+	config := LintConfig{ProgressThreshold: 50}
+
+	// Above threshold
+	var out bytes.Buffer
+	WriteOutput(&out, &LintResult{FilesScanned: 51}, OutputIssues, config)
+	assert.Contains(t, progress.String(), "Scanning complete")
+	assert.NotContains(t, out.String(), "Scanning complete")
+
+	// At or below threshold
+	progress.Reset()
+	WriteOutput(&out, &LintResult{FilesScanned: 50}, OutputIssues, config)
+	assert.Empty(t, progress.String())
+
+	// Threshold of 0 disables the notice
+	progress.Reset()
+	WriteOutput(&out, &LintResult{FilesScanned: 500}, OutputIssues, LintConfig{})
+	assert.Empty(t, progress.String())
+}
+
+func TestWriteOutputPathFilter(t *testing.T) {
+	result := &LintResult{
+		TotalConstants: 4,
+		ActuallyUsed:   1,
+		Issues: []Issue{
+			{FromLinter: "csslint", Text: `invalid CSS class "btn--typo"`, Severity: SeverityError,
+				Pos: IssuePos{Filename: "internal/web/admin/page.templ", Line: 3, Column: 5}},
+			{FromLinter: "csslint", Text: `hardcoded CSS class "card"`, Severity: SeverityWarning,
+				Pos: IssuePos{Filename: "internal/web/shop/cart.templ", Line: 7, Column: 9}},
+		},
+	}
+	config := LintConfig{PathFilter: "internal/web/admin/**", PrintLinterName: true}
+
+	var out bytes.Buffer
+	WriteOutput(&out, result, OutputIssues, config)
+	assert.Contains(t, out.String(), "internal/web/admin/page.templ:3:5")
+	assert.NotContains(t, out.String(), "cart.templ")
+	assert.Contains(t, out.String(), "1 issue:")
+
+	// Statistics and the caller's result still cover every issue
+	var jsonOut bytes.Buffer
+	WriteOutput(&jsonOut, result, OutputJSON, config)
+	var decoded JSONOutput
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Len(t, decoded.Issues, 1)
+	assert.Equal(t, 4, decoded.Stats.TotalConstants)
+	assert.Len(t, result.Issues, 2)
+}
+
+func TestWriteOutputIssueLimits(t *testing.T) {
+	result := &LintResult{
+		Issues: []Issue{
+			{FromLinter: "csslint", Text: `invalid CSS class "a"`, Severity: SeverityError, Pos: IssuePos{Filename: "a.templ", Line: 1, Column: 1}},
+			{FromLinter: "csslint", Text: `invalid CSS class "b"`, Severity: SeverityError, Pos: IssuePos{Filename: "b.templ", Line: 1, Column: 1}},
+			{FromLinter: "csslint", Text: `hardcoded CSS class "c"`, Severity: SeverityWarning, Pos: IssuePos{Filename: "c.templ", Line: 1, Column: 1}},
+		},
+	}
+	config := LintConfig{MaxIssuesPerLinter: 1}
+
+	// Only one issue is printed, the summary counts all three
+	var out bytes.Buffer
+	WriteOutput(&out, result, OutputIssues, config)
+	assert.Contains(t, out.String(), "a.templ:1:1")
+	assert.NotContains(t, out.String(), "b.templ")
+	assert.Contains(t, out.String(), "3 issues (2 errors, 1 warning; 2 issues truncated):")
+
+	// Reports for tools keep every issue, and the caller's result is untouched
+	var jsonOut bytes.Buffer
+	WriteOutput(&jsonOut, result, OutputJSON, config)
+	var decoded JSONOutput
+	require.NoError(t, json.Unmarshal(jsonOut.Bytes(), &decoded))
+	assert.Len(t, decoded.Issues, 3)
+	assert.Equal(t, 3, decoded.Summary.TotalIssues)
+	assert.Len(t, result.Issues, 3)
+	assert.Zero(t, result.TruncatedCount)
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	result := &LintResult{
+		Issues: []Issue{
+			{FromLinter: "csslint", Text: `invalid CSS class "btn--typo"`, Severity: SeverityError,
+				SourceLines: []string{`<div class="btn--typo">`},
+				Pos:         IssuePos{Filename: "page.templ", Line: 3, Column: 13}},
+			{FromLinter: "csslint", Text: `hardcoded CSS class "card"`, Severity: SeverityWarning,
+				Pos: IssuePos{Filename: "cart.templ", Line: 7, Column: 9}},
+			{FromLinter: "csslint", Text: `hardcoded CSS class "btn"`, Severity: SeverityWarning,
+				Pos: IssuePos{Filename: "cart.templ", Line: 8, Column: 9}},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteOutput(&buf, result, OutputNDJSON, LintConfig{})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, len(result.Issues))
+	for i, line := range lines {
+		var issue JSONIssue
+		require.NoError(t, json.Unmarshal([]byte(line), &issue), "line %d: %s", i+1, line)
+		assert.Equal(t, result.Issues[i].Pos.Filename, issue.File)
+		assert.Equal(t, result.Issues[i].Text, issue.Message)
+		assert.NotEmpty(t, issue.Fingerprint)
+	}
+
+	// No issues, no output
+	buf.Reset()
+	require.NoError(t, WriteNDJSON(&buf, &LintResult{}))
+	assert.Empty(t, buf.String())
+}
+
+func TestWriteCSV(t *testing.T) {
+	result := &LintResult{
+		Issues: []Issue{
+			{FromLinter: "csslint", Rule: RuleInvalidClass, Text: fmt.Sprintf(IssueInvalidClassHint, "btn--typo", "btn--type"),
+				Severity: SeverityError, Pos: IssuePos{Filename: "web/page.templ", Line: 3, Column: 13}},
+			{FromLinter: "csslint", Rule: RuleUnusedConstant, Text: fmt.Sprintf(IssueUnusedConstant, "Card"),
+				Severity: SeverityWarning, Pos: IssuePos{Filename: "ui/styles.gen.go", Line: 7, Column: 7}},
+			// A custom rule whose message reads like a built-in one
+			{FromLinter: "csslint", Rule: "no-legacy-grid", Text: `invalid CSS class "grid-12" is redundant`,
+				Severity: SeverityInfo, Pos: IssuePos{Filename: "web/grid.templ", Line: 1, Column: 1}},
+		},
+	}
+
+	var buf bytes.Buffer
+	WriteOutput(&buf, result, OutputCSV, LintConfig{})
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	assert.Equal(t, []string{"file", "line", "column", "severity", "type", "class", "message"}, records[0])
+	assert.Equal(t, []string{"web/page.templ", "3", "13", "error", "invalid-class", "btn--typo",
+		`invalid CSS class "btn--typo" not found in stylesheet (did you mean "btn--type"?)`}, records[1])
+	assert.Equal(t, "unused-constant", records[2][4])
+	assert.Empty(t, records[2][5])
+	assert.Equal(t, []string{"info", "no-legacy-grid"}, records[3][3:5], "custom issues are typed by their rule name")
+}
+
+func TestWriteGenerateJSON(t *testing.T) {
+	result := &GenerateResult{
+		FilesScanned:     3,
+		ClassesGenerated: 42,
+		IntentsExtracted: 5,
+		Warnings: GenerateWarnings{
+			{Message: "Duplicate class 'btn' (first defined at a.css:3) - properties merged", File: "b.css", Line: 2},
+			{Message: "Layer override"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteGenerateJSON(&buf, result))
+
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	assert.EqualValues(t, 3, fields["files_scanned"])
+	assert.EqualValues(t, 42, fields["classes_generated"])
+	assert.EqualValues(t, 5, fields["intents_extracted"])
+	assert.Equal(t, []any{
+		map[string]any{"message": result.Warnings[0].Message, "file": "b.css", "line": float64(2)},
+		map[string]any{"message": "Layer override"},
+	}, fields["warnings"])
+
+	// No warnings is an empty list, not null
+	buf.Reset()
+	require.NoError(t, WriteGenerateJSON(&buf, &GenerateResult{}))
+	assert.Contains(t, buf.String(), `"warnings": []`)
+}
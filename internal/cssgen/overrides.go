@@ -0,0 +1,107 @@
+package cssgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// layerOverride collects classes in two layers that set the same property
+type layerOverride struct {
+	property    string
+	winnerLayer string
+	loserLayer  string
+	winners     []string
+	losers      []string
+}
+
+// detectLayerOverrides reports properties set by classes in different declared layers
+// The later layer in layerOrder wins the cascade regardless of selector specificity
+// Layers missing from layerOrder are skipped since their precedence is unknown
+func detectLayerOverrides(classes []*CSSClass, layerOrder []string) GenerateWarnings {
+	rank := make(map[string]int, len(layerOrder))
+	for i, layer := range layerOrder {
+		rank[layer] = i
+	}
+
+	// property -> layer -> class names
+	setters := make(map[string]map[string][]string)
+	for _, class := range classes {
+		if _, declared := rank[class.Layer]; !declared || class.IsInternal {
+			continue
+		}
+		for cat, props := range categorizeProperties(class.Properties, Config{}) {
+			if cat == CategoryInternal {
+				continue // Vendor prefixes are fallbacks, not overrides
+			}
+			for _, prop := range props {
+				if setters[prop.Name] == nil {
+					setters[prop.Name] = make(map[string][]string)
+				}
+				setters[prop.Name][class.Layer] = append(setters[prop.Name][class.Layer], class.Name)
+			}
+		}
+	}
+
+	var overrides []layerOverride
+	for property, byLayer := range setters {
+		layers := make([]string, 0, len(byLayer))
+		for layer := range byLayer {
+			layers = append(layers, layer)
+		}
+		sort.Slice(layers, func(i, j int) bool { return rank[layers[i]] < rank[layers[j]] })
+
+		for i := 0; i < len(layers); i++ {
+			for j := i + 1; j < len(layers); j++ {
+				overrides = append(overrides, layerOverride{
+					property:    property,
+					winnerLayer: layers[j],
+					loserLayer:  layers[i],
+					winners:     byLayer[layers[j]],
+					losers:      byLayer[layers[i]],
+				})
+			}
+		}
+	}
+
+	sort.Slice(overrides, func(i, j int) bool {
+		if overrides[i].property != overrides[j].property {
+			return overrides[i].property < overrides[j].property
+		}
+		if overrides[i].loserLayer != overrides[j].loserLayer {
+			return rank[overrides[i].loserLayer] < rank[overrides[j].loserLayer]
+		}
+		return rank[overrides[i].winnerLayer] < rank[overrides[j].winnerLayer]
+	})
+
+	warnings := make(GenerateWarnings, 0, len(overrides))
+	for _, o := range overrides {
+		warnings = append(warnings, GenerateWarning{Message: fmt.Sprintf(
+			"Layer override: %s set by %s (@layer %s) wins over %s (@layer %s)",
+			o.property, formatClassList(o.winners), o.winnerLayer, formatClassList(o.losers), o.loserLayer,
+		)})
+	}
+	return warnings
+}
+
+// formatClassList renders up to three class selectors with a "+N more" suffix
+func formatClassList(names []string) string {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+
+	const limit = 3
+	shown := sorted
+	if len(shown) > limit {
+		shown = shown[:limit]
+	}
+	parts := make([]string, len(shown))
+	for i, name := range shown {
+		parts[i] = "." + name
+	}
+
+	list := strings.Join(parts, ", ")
+	if extra := len(sorted) - len(shown); extra > 0 {
+		list += fmt.Sprintf(" +%d more", extra)
+	}
+	return list
+}
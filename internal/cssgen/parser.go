@@ -3,6 +3,8 @@ package cssgen
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -17,16 +19,47 @@ type parserState struct {
 	classes       map[string]*CSSClass // Use map to deduplicate during parsing
 	fullContent   string               // For intent extraction
 	config        Config               // Configuration for parsing
+	ordered       []string             // Layer order from @layer declarations (first seen wins)
+	ids           map[string]*CSSClass // ID selectors (only with Config.ExtractIDs)
+	depth         int                  // Nesting of at-rule blocks ({ } not owned by a rule)
+	containers    []conditionScope     // Open @container blocks, innermost last
+	media         []conditionScope     // Open @media blocks, innermost last
+}
+
+// conditionScope is an open @container or @media block and the depth inside it
+type conditionScope struct {
+	condition string // "sidebar (min-width: 400px)", "print"
+	depth     int
+}
+
+// sourceCommentPattern matches a bundler's /* src: button.css */ marker on its own line
+var sourceCommentPattern = regexp.MustCompile(`^\s*/\*\s*src:\s*(\S+)\s*\*/\s*$`)
+
+// parseResult holds everything extracted from a single CSS file
+type parseResult struct {
+	classes    []*CSSClass
+	layerOrder []string
+	ids        []*CSSClass
 }
 
 // ParseCSS parses CSS content and returns structured classes
 func ParseCSS(content string, filename string, inferredLayer string, config Config) ([]*CSSClass, error) {
+	parsed, err := parseCSS(content, filename, inferredLayer, config)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.classes, nil
+}
+
+// parseCSS parses CSS content into classes, declared layer order, and IDs
+func parseCSS(content string, filename string, inferredLayer string, config Config) (*parseResult, error) {
 	state := &parserState{
 		currentLayer:  "",
 		inferredLayer: inferredLayer,
 		classes:       make(map[string]*CSSClass),
 		fullContent:   content,
 		config:        config,
+		ids:           make(map[string]*CSSClass),
 	}
 
 	lexer := css.NewLexer(parse.NewInputString(content))
@@ -44,44 +77,106 @@ func ParseCSS(content string, filename string, inferredLayer string, config Conf
 			continue
 		}
 
+		// @container name (condition) { ... }: inner classes record the condition
+		if tt == css.AtKeywordToken && string(text) == "@container" {
+			state.handleContainerRule(lexer)
+			continue
+		}
+
+		// @media condition { ... }: inner classes record the condition
+		if tt == css.AtKeywordToken && string(text) == "@media" {
+			state.handleMediaRule(lexer)
+			continue
+		}
+
+		// Blocks of other at-rules (@supports) are descended into
+		if tt == css.LeftBraceToken {
+			state.openBlock()
+			continue
+		}
+		if tt == css.RightBraceToken {
+			state.closeBlock()
+			continue
+		}
+
+		// @scope (.root) to (.limit) { ... }: inner rules are parsed like top-level ones
+		if tt == css.AtKeywordToken && string(text) == "@scope" {
+			state.handleScopeRule(lexer, filename)
+			continue
+		}
+
 		// Look for class selectors followed by { declarations }
 		if tt == css.DelimToken && len(text) > 0 && text[0] == '.' {
 			// This is a class selector
 			state.handleClassRule(lexer, filename)
+			continue
+		}
+
+		// Look for ID selectors (opt-in)
+		if tt == css.HashToken && config.ExtractIDs {
+			state.handleIDRule(lexer, string(text[1:]), filename)
 		}
 	}
 
-	// Extract intent if enabled
-	if config.ExtractIntent {
-		for _, class := range state.classes {
-			class.Intent = extractIntent(content, class.Name)
+	// Record where each class is defined, and extract intent if enabled
+	lines := strings.Split(content, "\n")
+	for _, class := range state.classes {
+		classLine := findClassLine(lines, class.Name)
+		class.SourceLine = classLine + 1
+		if config.HonorSourceComments {
+			// Relative sources resolve against the bundle, so component files split as usual
+			if source, line := bundledSource(lines, classLine); source != "" {
+				if !filepath.IsAbs(source) {
+					source = filepath.Join(filepath.Dir(filename), source)
+				}
+				class.SourceFile, class.SourceLine = source, line
+			}
+		}
+		if config.ExtractIntent {
+			class.Intent = intentAbove(lines, classLine)
 		}
+		class.IgnoreDirective = ignoreDirectiveAbove(lines, classLine)
 	}
 
-	// Convert map to slice
-	result := make([]*CSSClass, 0, len(state.classes))
+	// Convert maps to slices
+	result := &parseResult{
+		classes:    make([]*CSSClass, 0, len(state.classes)),
+		layerOrder: state.ordered,
+		ids:        make([]*CSSClass, 0, len(state.ids)),
+	}
 	for _, class := range state.classes {
-		result = append(result, class)
+		result.classes = append(result.classes, class)
+	}
+	for _, id := range state.ids {
+		result.ids = append(result.ids, id)
 	}
 
 	return result, nil
 }
 
 // parseFile reads and parses a single CSS file
-func parseFile(path string, config Config) ([]*CSSClass, error) {
+func parseFile(path string, config Config) (*parseResult, error) {
 	// #nosec G304 - path comes from trusted configuration
 	content, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("read file: %w", err)
+		err = fmt.Errorf("read file: %w", err)
+	}
+	return parseSource(cssSource{path: path, content: content, err: err}, config)
+}
+
+// parseSource parses a CSS file already read by readCSSSources
+func parseSource(source cssSource, config Config) (*parseResult, error) {
+	if source.err != nil {
+		return nil, source.err
 	}
 
 	// Infer layer from path if enabled
 	inferredLayer := ""
 	if config.LayerInferFromPath {
-		inferredLayer = inferLayerFromPath(path, config.SourceDir)
+		inferredLayer = layerOrDefault(inferLayerFromPath(source.path, config.SourceDir), config.DefaultLayer)
 	}
 
-	return ParseCSS(string(content), path, inferredLayer, config)
+	return parseCSS(string(source.content), source.path, inferredLayer, config)
 }
 
 // inferLayerFromPath extracts layer name from file path
@@ -120,13 +215,31 @@ func inferLayerFromPath(filePath, sourceDir string) string {
 		return "reset"
 	}
 
-	return "n/a"
+	return unknownLayer
+}
+
+// unknownLayer is returned by inferLayerFromPath when the path names no layer
+const unknownLayer = "n/a"
+
+// defaultLayer holds classes whose layer can't be inferred, unless configured otherwise
+const defaultLayer = "base"
+
+// layerOrDefault replaces an unknown inferred layer with fallback ("" = defaultLayer)
+func layerOrDefault(layer, fallback string) string {
+	if layer != unknownLayer {
+		return layer
+	}
+	if fallback == "" {
+		return defaultLayer
+	}
+	return fallback
 }
 
 // handleLayerDeclaration processes @layer declarations
 func (s *parserState) handleLayerDeclaration(lexer *css.Lexer) {
 	// Read tokens until we hit { or ;
 	var layerName string
+	var names []string
 
 	for {
 		tt, text := lexer.Next()
@@ -136,23 +249,36 @@ func (s *parserState) handleLayerDeclaration(lexer *css.Lexer) {
 
 		if tt == css.IdentToken {
 			layerName = string(text)
+			names = append(names, layerName)
 		}
 
 		if tt == css.LeftBraceToken {
 			// @layer name { ... }
+			s.openBlock()
 			if layerName != "" {
 				s.currentLayer = layerName
+				s.recordLayerOrder(layerName)
 			}
 			return
 		}
 
 		if tt == css.SemicolonToken {
-			// @layer name1, name2;
+			// @layer name1, name2; declares cascade order
+			s.recordLayerOrder(names...)
 			return
 		}
 	}
 }
 
+// recordLayerOrder appends layers not seen before, matching CSS cascade semantics
+func (s *parserState) recordLayerOrder(names ...string) {
+	for _, name := range names {
+		if !contains(s.ordered, name) {
+			s.ordered = append(s.ordered, name)
+		}
+	}
+}
+
 // handleClassRule processes a class selector and its declarations
 func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 	// At this point we've seen a '.', read the class name
@@ -165,12 +291,16 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 
 	// Collect all class names and pseudo-states before the opening brace
 	type selectorInfo struct {
-		className    string
-		pseudoStates []string
+		className      string
+		pseudoStates   []string
+		pseudoElements []string // "::before"; the rule styles a generated box, not the element
+		compound       int      // Classes sharing an ID are combined (.a.b)
 	}
 
 	selectors := []selectorInfo{{className: firstClassName, pseudoStates: []string{}}}
 	currentIdx := 0
+	nextCompound := 1
+	boundary := false // Whitespace or a combinator since the last class
 
 	for {
 		tt, text := lexer.Next()
@@ -178,14 +308,29 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 			return
 		}
 
+		// Descendant and sibling combinators end the current compound selector
+		if tt == css.WhitespaceToken ||
+			(tt == css.DelimToken && len(text) > 0 && (text[0] == '>' || text[0] == '+' || text[0] == '~')) {
+			boundary = true
+			continue
+		}
+
 		// Handle additional class names in compound selectors (.foo.bar)
 		if tt == css.DelimToken && len(text) > 0 && text[0] == '.' {
 			tt2, className2 := lexer.Next()
 			if tt2 == css.IdentToken {
+				compound := selectors[currentIdx].compound
+				if boundary {
+					compound = nextCompound
+					nextCompound++
+				}
+				boundary = false
+
 				// Found another class in compound selector
 				selectors = append(selectors, selectorInfo{
 					className:    string(className2),
 					pseudoStates: []string{},
+					compound:     compound,
 				})
 				currentIdx = len(selectors) - 1
 			}
@@ -195,6 +340,21 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 		// Track pseudo-classes/elements
 		if tt == css.ColonToken {
 			tt2, text2 := lexer.Next()
+
+			// ::before, ::after, ::part(...): a pseudo-element, not a state
+			if tt2 == css.ColonToken {
+				if element := readPseudoElement(lexer); element != "" {
+					selectors[currentIdx].pseudoElements = append(selectors[currentIdx].pseudoElements, element)
+				}
+				continue
+			}
+
+			// CSS2 single-colon forms of the original pseudo-elements
+			if tt2 == css.IdentToken && legacyPseudoElements[string(text2)] {
+				selectors[currentIdx].pseudoElements = append(selectors[currentIdx].pseudoElements, "::"+string(text2))
+				continue
+			}
+
 			if tt2 == css.IdentToken {
 				pseudoName := string(text2)
 
@@ -230,7 +390,9 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 									selectors = append(selectors, selectorInfo{
 										className:    string(classNameInner),
 										pseudoStates: []string{},
+										compound:     nextCompound,
 									})
+									nextCompound++
 								}
 							}
 						}
@@ -261,7 +423,10 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 						selectors = append(selectors, selectorInfo{
 							className:    string(className3),
 							pseudoStates: []string{},
+							compound:     nextCompound,
 						})
+						nextCompound++
+						boundary = false
 						currentIdx = len(selectors) - 1
 						break
 					}
@@ -274,26 +439,57 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 			// Found the declaration block
 			properties := s.extractDeclarations(lexer)
 
+			compounds := make(map[int][]string)
+			for _, sel := range selectors {
+				compounds[sel.compound] = append(compounds[sel.compound], sel.className)
+			}
+
 			// Apply properties to all collected selectors
 			for _, sel := range selectors {
 				// Create or update the base class (always)
-				class, exists := s.classes[sel.className]
-				if !exists {
-					// Use explicit layer if set, otherwise use inferred layer
-					layer := s.currentLayer
-					if layer == "" && s.inferredLayer != "" {
-						layer = s.inferredLayer
+				class := s.ensureClass(sel.className, filename)
+
+				// Record enclosing container queries
+				for _, c := range s.containers {
+					if !contains(class.ContainerStates, c.condition) {
+						class.ContainerStates = append(class.ContainerStates, c.condition)
+					}
+				}
+
+				// Record enclosing media queries, or that the class applies without one
+				if len(s.media) == 0 {
+					class.OutsideMedia = true
+				}
+				for _, m := range s.media {
+					if !contains(class.MediaStates, m.condition) {
+						class.MediaStates = append(class.MediaStates, m.condition)
 					}
+				}
+
+				// Record whether the class ever stands alone or only combined (.a.b)
+				alone := true
+				for _, partner := range compounds[sel.compound] {
+					if partner == sel.className {
+						continue
+					}
+					alone = false
+					if !contains(class.CompoundWith, partner) {
+						class.CompoundWith = append(class.CompoundWith, partner)
+					}
+				}
+				if alone {
+					class.UsedAlone = true
+				}
 
-					class = &CSSClass{
-						Name:         sel.className,
-						Layer:        layer,
-						Properties:   make(map[string]string),
-						PseudoStates: []string{},
-						SourceFile:   filename,
-						IsInternal:   strings.HasPrefix(sel.className, "_"),
+				// Pseudo-element rules style a generated box, so the class's own
+				// properties and states are left alone
+				if len(sel.pseudoElements) > 0 {
+					for _, pe := range sel.pseudoElements {
+						if !contains(class.PseudoElements, pe) {
+							class.PseudoElements = append(class.PseudoElements, pe)
+						}
 					}
-					s.classes[sel.className] = class
+					continue
 				}
 
 				// If this selector has pseudo-states, track property changes
@@ -339,6 +535,191 @@ func (s *parserState) handleClassRule(lexer *css.Lexer, filename string) {
 	}
 }
 
+// ensureClass returns the class for name, registering it in the current layer if new
+func (s *parserState) ensureClass(name string, filename string) *CSSClass {
+	if class, exists := s.classes[name]; exists {
+		return class
+	}
+
+	// Use explicit layer if set, otherwise use inferred layer
+	layer := s.currentLayer
+	if layer == "" && s.inferredLayer != "" {
+		layer = s.inferredLayer
+	}
+
+	class := &CSSClass{
+		Name:         name,
+		Layer:        layer,
+		Properties:   make(map[string]string),
+		PseudoStates: []string{},
+		SourceFile:   filename,
+		IsInternal:   strings.HasPrefix(name, "_"),
+	}
+	s.classes[name] = class
+	return class
+}
+
+// handleScopeRule processes the prelude of @scope (.root) to (.limit) { ... }
+// Root and limit classes are registered; the block's rules are left to the main loop
+func (s *parserState) handleScopeRule(lexer *css.Lexer, filename string) {
+	for {
+		tt, text := lexer.Next()
+		switch {
+		case tt == css.ErrorToken, tt == css.SemicolonToken:
+			return
+		case tt == css.LeftBraceToken:
+			s.openBlock()
+			return
+		case tt == css.DelimToken && len(text) > 0 && text[0] == '.':
+			if tt2, name := lexer.Next(); tt2 == css.IdentToken {
+				s.ensureClass(string(name), filename).UsedAlone = true
+			}
+		}
+	}
+}
+
+// legacyPseudoElements may be written with a single colon (:before)
+var legacyPseudoElements = map[string]bool{
+	"before":       true,
+	"after":        true,
+	"first-line":   true,
+	"first-letter": true,
+}
+
+// readPseudoElement reads the name after "::", skipping the arguments of
+// functional pseudo-elements such as ::part(label) ("" if there is no name)
+func readPseudoElement(lexer *css.Lexer) string {
+	tt, text := lexer.Next()
+	switch tt {
+	case css.IdentToken:
+		return "::" + string(text)
+	case css.FunctionToken:
+		for depth := 1; depth > 0; {
+			ttInner, _ := lexer.Next()
+			switch ttInner {
+			case css.ErrorToken:
+				return ""
+			case css.LeftParenthesisToken, css.FunctionToken:
+				depth++
+			case css.RightParenthesisToken:
+				depth--
+			}
+		}
+		return "::" + strings.TrimSuffix(string(text), "(")
+	}
+	return ""
+}
+
+// handleContainerRule reads the @container prelude and opens its block
+func (s *parserState) handleContainerRule(lexer *css.Lexer) {
+	if scope, ok := s.openConditionBlock(lexer); ok {
+		s.containers = append(s.containers, scope)
+	}
+}
+
+// handleMediaRule reads the @media prelude and opens its block
+func (s *parserState) handleMediaRule(lexer *css.Lexer) {
+	if scope, ok := s.openConditionBlock(lexer); ok {
+		s.media = append(s.media, scope)
+	}
+}
+
+// openConditionBlock reads an at-rule prelude up to its block and opens it
+// Returns false for statements without a block
+func (s *parserState) openConditionBlock(lexer *css.Lexer) (conditionScope, bool) {
+	var prelude strings.Builder
+	for {
+		tt, text := lexer.Next()
+		switch tt {
+		case css.ErrorToken, css.SemicolonToken:
+			return conditionScope{}, false
+		case css.LeftBraceToken:
+			s.openBlock()
+			return conditionScope{
+				condition: strings.Join(strings.Fields(prelude.String()), " "),
+				depth:     s.depth,
+			}, true
+		default:
+			prelude.Write(text)
+		}
+	}
+}
+
+// openBlock enters an at-rule block
+func (s *parserState) openBlock() {
+	s.depth++
+}
+
+// closeBlock leaves an at-rule block, closing any @container or @media opened at this depth
+func (s *parserState) closeBlock() {
+	if n := len(s.containers); n > 0 && s.containers[n-1].depth == s.depth {
+		s.containers = s.containers[:n-1]
+	}
+	if n := len(s.media); n > 0 && s.media[n-1].depth == s.depth {
+		s.media = s.media[:n-1]
+	}
+	if s.depth > 0 {
+		s.depth--
+	}
+}
+
+// handleIDRule processes an ID selector (#main-nav) and its declarations
+// A hash only counts as a selector if a { follows before ; or } (so #fff values are ignored)
+func (s *parserState) handleIDRule(lexer *css.Lexer, firstID string, filename string) {
+	ids := []string{firstID}
+
+	for {
+		tt, text := lexer.Next()
+		switch {
+		case tt == css.ErrorToken, tt == css.SemicolonToken:
+			// Not a selector (e.g. a hex color in a declaration value)
+			return
+
+		case tt == css.RightBraceToken:
+			// Not a selector, and the brace closes an enclosing block
+			s.closeBlock()
+			return
+
+		case tt == css.DelimToken && len(text) > 0 && text[0] == '.':
+			// #main-nav .link { ... } - record the IDs, let the class rule own the block
+			s.addIDs(ids, nil, filename)
+			s.handleClassRule(lexer, filename)
+			return
+
+		case tt == css.HashToken:
+			// #a, #b { ... }
+			ids = append(ids, string(text[1:]))
+
+		case tt == css.LeftBraceToken:
+			s.addIDs(ids, s.extractDeclarations(lexer), filename)
+			return
+		}
+	}
+}
+
+// addIDs records ID selectors, merging properties for repeated IDs
+func (s *parserState) addIDs(ids []string, properties map[string]string, filename string) {
+	for _, name := range ids {
+		id, exists := s.ids[name]
+		if !exists {
+			layer := s.currentLayer
+			if layer == "" && s.inferredLayer != "" {
+				layer = s.inferredLayer
+			}
+			id = &CSSClass{
+				Name:       name,
+				Layer:      layer,
+				Properties: make(map[string]string),
+				SourceFile: filename,
+			}
+			s.ids[name] = id
+		}
+		for k, v := range properties {
+			id.Properties[k] = v
+		}
+	}
+}
+
 // extractDeclarations reads property: value pairs until }
 func (s *parserState) extractDeclarations(lexer *css.Lexer) map[string]string {
 	props := make(map[string]string)
@@ -380,10 +761,18 @@ func (s *parserState) extractDeclarations(lexer *css.Lexer) map[string]string {
 	return props
 }
 
-// cleanProperties formats properties as single-line comment
-func cleanProperties(props map[string]string) string {
+// defaultCommentWidth is where property comments are cut when Config.CommentWidth is unset
+const defaultCommentWidth = 120
+
+// cleanProperties formats properties as a comment of at most width characters
+// Longer text is truncated with "...", or with wrap split between properties
+// onto extra lines (a single property wider than width keeps its own line)
+func cleanProperties(props map[string]string, width int, wrap bool) []string {
 	if len(props) == 0 {
-		return ""
+		return nil
+	}
+	if width <= 0 {
+		width = defaultCommentWidth
 	}
 
 	// Sort keys for determinism
@@ -400,13 +789,34 @@ func cleanProperties(props map[string]string) string {
 	}
 
 	result := "{ " + strings.Join(parts, "; ") + "; }"
+	if len(result) <= width {
+		return []string{result}
+	}
 
-	// Truncate for IDE tooltip readability
-	if len(result) > 120 {
-		result = result[:117] + "..."
+	if !wrap {
+		// Truncate for IDE tooltip readability
+		if width <= 3 {
+			return []string{"..."}
+		}
+		return []string{result[:width-3] + "..."}
+	}
+
+	var lines []string
+	line := "{"
+	for i, part := range parts {
+		item := " " + part + ";"
+		if i == len(parts)-1 {
+			item += " }"
+		}
+		if len(line)+len(item) > width && line != "{" {
+			lines = append(lines, line)
+			line = strings.TrimPrefix(item, " ")
+			continue
+		}
+		line += item
 	}
 
-	return result
+	return append(lines, line)
 }
 
 // contains checks if a string slice contains a value
@@ -419,47 +829,132 @@ func contains(slice []string, val string) bool {
 	return false
 }
 
-// extractIntent looks for @intent comments above CSS rules
-func extractIntent(content string, className string) string {
-	lines := strings.Split(content, "\n")
-
-	// Find the line with the class definition
-	classLine := -1
-	searchPattern := fmt.Sprintf(".%s", className)
+// findClassLine returns the 0-based index of the first rule line for className (-1 if none)
+func findClassLine(lines []string, className string) int {
 	for i, line := range lines {
-		if strings.Contains(line, searchPattern) && strings.Contains(line, "{") {
-			classLine = i
-			break
+		if strings.Contains(line, "{") && hasClassSelector(line, className) {
+			return i
+		}
+	}
+	return -1
+}
+
+// hasClassSelector reports whether line selects .className itself, not a longer
+// class it prefixes (.btn in ".btn:hover" but not in ".btn-group")
+func hasClassSelector(line, className string) bool {
+	selector := "." + className
+	for offset := 0; ; {
+		idx := strings.Index(line[offset:], selector)
+		if idx == -1 {
+			return false
+		}
+		end := offset + idx + len(selector)
+		if end == len(line) || (line[end] != '-' && !isIdentByte(line[end])) {
+			return true
+		}
+		offset = end
+	}
+}
+
+// bundledSource returns the file named by the nearest /* src: ... */ comment above
+// lines[classLine] and the class's line within it, assuming the source follows the
+// comment verbatim ("" if there is no such comment)
+func bundledSource(lines []string, classLine int) (string, int) {
+	for i := classLine - 1; i >= 0; i-- {
+		if m := sourceCommentPattern.FindStringSubmatch(lines[i]); m != nil {
+			return m[1], classLine - i
 		}
 	}
+	return "", 0
+}
 
+// intentAbove reads the @intent comment directly above lines[classLine]
+// The intent continues over the following comment lines until a blank comment
+// line, another @directive or the end of the comment. Text is kept verbatim,
+// so references like "see: tokens.md#primary" survive for docs tooling
+func intentAbove(lines []string, classLine int) string {
 	if classLine == -1 {
 		return ""
 	}
 
-	// Look backwards for @intent comment (max 10 lines)
-	for i := classLine - 1; i >= 0 && i >= classLine-10; i-- {
-		line := strings.TrimSpace(lines[i])
-
-		// Stop at empty line or non-comment
-		if line == "" || (!strings.HasPrefix(line, "/*") && !strings.HasPrefix(line, "*") && !strings.HasPrefix(line, "//")) {
-			break
+	comment := commentAbove(lines, classLine)
+	for i, line := range comment {
+		_, text, found := strings.Cut(line, "@intent")
+		if !found {
+			continue
 		}
 
-		// Extract @intent directive
-		if strings.Contains(line, "@intent") {
-			// Extract text after @intent
-			parts := strings.SplitN(line, "@intent", 2)
-			if len(parts) == 2 {
-				intent := strings.TrimSpace(parts[1])
-				// Clean up comment markers
-				intent = strings.TrimPrefix(intent, "*")
-				intent = strings.TrimSuffix(intent, "*/")
-				intent = strings.TrimSpace(intent)
-				return intent
+		var parts []string
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+		for _, next := range comment[i+1:] {
+			if next == "" || strings.HasPrefix(next, "@") {
+				break
 			}
+			parts = append(parts, next)
 		}
+		return strings.Join(parts, " ")
 	}
 
 	return ""
 }
+
+// ignoreDirectiveAbove returns the cssgen:ignore directive in the comment above
+// lines[classLine], e.g. "cssgen:ignore unused-constant" ("" if there is none)
+func ignoreDirectiveAbove(lines []string, classLine int) string {
+	if classLine == -1 {
+		return ""
+	}
+	for _, line := range commentAbove(lines, classLine) {
+		if idx := strings.Index(line, ignoreDirective); idx != -1 {
+			return line[idx:]
+		}
+	}
+	return ""
+}
+
+// commentAbove returns the comment lines directly above lines[classLine] (max 10),
+// top to bottom with comment markers stripped. Each closing */ is followed by an
+// empty entry so separate comments read as separate paragraphs
+func commentAbove(lines []string, classLine int) []string {
+	start := classLine
+	inBlock := false // Walking up through a /* ... */ comment
+
+scan:
+	for i := classLine - 1; i >= 0 && i >= classLine-10; i-- {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case inBlock:
+			inBlock = !strings.Contains(line, "/*")
+		case strings.HasSuffix(line, "*/"):
+			inBlock = !strings.Contains(line, "/*")
+		case strings.HasPrefix(line, "/*"), strings.HasPrefix(line, "*"), strings.HasPrefix(line, "//"):
+		default:
+			// Stop at empty line or non-comment
+			break scan
+		}
+		start = i
+	}
+
+	var comment []string
+	for _, line := range lines[start:classLine] {
+		line = strings.TrimSpace(line)
+		closes := strings.HasSuffix(line, "*/")
+
+		line = strings.TrimSuffix(line, "*/")
+		if strings.HasPrefix(line, "//") {
+			line = strings.TrimPrefix(line, "//")
+		} else {
+			line = strings.TrimPrefix(line, "/*")
+			line = strings.TrimPrefix(line, "*")
+		}
+		comment = append(comment, strings.TrimSpace(line))
+
+		if closes {
+			comment = append(comment, "")
+		}
+	}
+	return comment
+}
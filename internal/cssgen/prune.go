@@ -0,0 +1,98 @@
+package cssgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+// UnusedPatch is the --export-unused-patch schema: generated-file line ranges to delete
+type UnusedPatch struct {
+	Version  string          `json:"version"`
+	Removals []UnusedRemoval `json:"removals"`
+}
+
+// UnusedRemoval deletes lines From..To (1-based, inclusive) of File, which declare Const
+type UnusedRemoval struct {
+	File  string    `json:"file"`
+	Const string    `json:"const"`
+	Class string    `json:"class"`
+	Lines LineRange `json:"lines"`
+}
+
+// BuildUnusedPatch lists the declaration of every unused constant, sorted by file and line
+func BuildUnusedPatch(result *LintResult) UnusedPatch {
+	removals := []UnusedRemoval{}
+	for _, unused := range result.UnusedClasses {
+		if unused.File == "" || unused.Declaration.From == 0 {
+			continue
+		}
+		removals = append(removals, UnusedRemoval{
+			File:  unused.File,
+			Const: unused.ConstName,
+			Class: unused.CSSClass,
+			Lines: unused.Declaration,
+		})
+	}
+
+	sort.Slice(removals, func(i, j int) bool {
+		if removals[i].File != removals[j].File {
+			return removals[i].File < removals[j].File
+		}
+		return removals[i].Lines.From < removals[j].Lines.From
+	})
+
+	return UnusedPatch{Version: "1.0", Removals: removals}
+}
+
+// WriteUnusedPatch writes the unused-constant removals as JSON without modifying any files
+func WriteUnusedPatch(w io.Writer, result *LintResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(BuildUnusedPatch(result))
+}
+
+// PruneUnused deletes the unused constant declarations from the generated files
+// AllCSSClasses is left alone, since the classes still exist in the CSS
+// Returns the number of constants removed
+func PruneUnused(result *LintResult) (int, error) {
+	byFile := make(map[string][]UnusedRemoval)
+	for _, removal := range BuildUnusedPatch(result).Removals {
+		byFile[removal.File] = append(byFile[removal.File], removal)
+	}
+
+	removed := 0
+	for file, removals := range byFile {
+		// #nosec G304 - path comes from the parsed generated files
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return removed, fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		lineEnding := LineEndingLF
+		if strings.Contains(string(content), "\r\n") {
+			lineEnding = LineEndingCRLF
+		}
+		lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+		// Delete bottom-up so earlier ranges keep their line numbers
+		for i := len(removals) - 1; i >= 0; i-- {
+			r := removals[i].Lines
+			if r.From < 1 || r.To > len(lines) || r.From > r.To {
+				return removed, fmt.Errorf("%s changed since it was parsed (line range %d-%d)", file, r.From, r.To)
+			}
+			lines = append(lines[:r.From-1], lines[r.To:]...)
+		}
+
+		// gofmt collapses the blank lines left behind
+		if err := writeFormattedGoFile(file, strings.Join(lines, "\n"), lineEnding); err != nil {
+			return removed, err
+		}
+		removed += len(removals)
+	}
+
+	return removed, nil
+}
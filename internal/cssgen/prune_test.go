@@ -0,0 +1,92 @@
+package cssgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const pruneGenerated = `package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn":   true,
+	"stale": true,
+}
+
+// **Layout:**
+// - display: ` + "`flex`" + `
+const Btn = "btn"
+
+// **Visual:**
+// - color: ` + "`red`" + `
+const Stale = "stale"
+
+const (
+	// Grouped is declared inside a const block
+	Grouped = "grouped"
+)
+`
+
+func TestPruneUnused(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(t *testing.T, generatedFile string, result *LintResult)
+	}{
+		{
+			name: "export patch",
+			check: func(t *testing.T, generatedFile string, result *LintResult) {
+				var buf bytes.Buffer
+				require.NoError(t, WriteUnusedPatch(&buf, result))
+
+				var patch UnusedPatch
+				require.NoError(t, json.Unmarshal(buf.Bytes(), &patch))
+				assert.Equal(t, "1.0", patch.Version)
+				assert.Equal(t, []UnusedRemoval{
+					{File: generatedFile, Const: "Stale", Class: "stale", Lines: LineRange{From: 12, To: 14}},
+					{File: generatedFile, Const: "Grouped", Class: "grouped", Lines: LineRange{From: 17, To: 18}},
+				}, patch.Removals)
+			},
+		},
+		{
+			name: "prune in place",
+			check: func(t *testing.T, generatedFile string, result *LintResult) {
+				removed, err := PruneUnused(result)
+				require.NoError(t, err)
+				assert.Equal(t, 2, removed)
+
+				constants, allCSSClasses, err := ParseGeneratedFile(generatedFile)
+				require.NoError(t, err)
+				assert.Equal(t, map[string]string{"Btn": "btn"}, constants)
+				assert.True(t, allCSSClasses["stale"])
+
+				content, err := os.ReadFile(generatedFile)
+				require.NoError(t, err)
+				assert.Contains(t, string(content), "// - display: `flex`\nconst Btn")
+				assert.NotContains(t, string(content), "color: `red`")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+			require.NoError(t, os.WriteFile(generatedFile, []byte(pruneGenerated), 0644))
+			templFile := filepath.Join(tmpDir, "page.templ")
+			require.NoError(t, os.WriteFile(templFile, []byte("templ Page() {\n\t<button class={ ui.Btn }></button>\n}\n"), 0644))
+
+			result, err := Lint(LintConfig{
+				ScanPaths:     []string{templFile},
+				GeneratedFile: generatedFile,
+				PackageName:   "ui",
+			})
+			require.NoError(t, err)
+			tt.check(t, generatedFile, result)
+		})
+	}
+}
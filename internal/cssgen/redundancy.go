@@ -0,0 +1,172 @@
+package cssgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// redundantClassIssues reports classes in a multi-class value whose properties are all
+// set, with identical values, by another class in the same value
+// When two classes set exactly the same properties, the later one is reported
+func redundantClassIssues(ref ClassReference, properties map[string]map[string]string) []Issue {
+	tokens := uniqueTokens(ref.FullClassValue)
+	if len(tokens) < 2 || len(properties) == 0 {
+		return nil
+	}
+
+	var issues []Issue
+	for i, class := range tokens {
+		props := properties[class]
+		if len(props) == 0 {
+			continue
+		}
+
+		for j, other := range tokens {
+			if i == j || !setsAll(properties[other], props) {
+				continue
+			}
+			// Identical sets: keep the first class, flag the later one
+			if len(properties[other]) == len(props) && j > i {
+				continue
+			}
+
+			column := findClassColumn(ref.Location.Text, class)
+			if column == 0 {
+				column = ref.Location.Column
+			}
+			issues = append(issues, Issue{
+				FromLinter:  "csslint",
+				Rule:        RuleRedundantClass,
+				Text:        fmt.Sprintf(IssueRedundantClass, class, other, formatDeclarations(props)),
+				Severity:    SeverityInfo,
+				SourceLines: []string{ref.Location.Text},
+				Pos: IssuePos{
+					Filename: ref.Location.File,
+					Line:     ref.Location.Line,
+					Column:   column,
+				},
+			})
+			break
+		}
+	}
+
+	return issues
+}
+
+// uniqueTokens splits a class value into classes, dropping repeats
+func uniqueTokens(classValue string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	for _, token := range strings.Fields(classValue) {
+		if !seen[token] {
+			seen[token] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// setsAll reports whether have contains every property in want with the same value
+func setsAll(have, want map[string]string) bool {
+	if len(have) < len(want) {
+		return false
+	}
+	for name, value := range want {
+		if have[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// formatDeclarations renders properties as "display: flex; gap: 1rem" in name order
+func formatDeclarations(props map[string]string) string {
+	names := make([]string, 0, len(props))
+	for name := range props {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	decls := make([]string, len(names))
+	for i, name := range names {
+		decls[i] = name + ": " + props[name]
+	}
+	return strings.Join(decls, "; ")
+}
+
+// parseClassProperties reads the CSSClassProperties map from the generated files
+// Output generated before the map existed yields an empty result
+func parseClassProperties(path string) (map[string]map[string]string, error) {
+	files, err := generatedFilePaths(path)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := make(map[string]map[string]string)
+	fset := token.NewFileSet()
+	for _, filePath := range files {
+		file, err := parser.ParseFile(fset, filePath, nil, 0)
+		if err != nil {
+			// Skip files that can't be parsed (might be in progress)
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				vspec, ok := spec.(*ast.ValueSpec)
+				if !ok || len(vspec.Names) == 0 || vspec.Names[0].Name != "CSSClassProperties" || len(vspec.Values) == 0 {
+					continue
+				}
+				comp, ok := vspec.Values[0].(*ast.CompositeLit)
+				if !ok {
+					continue
+				}
+				for _, elt := range comp.Elts {
+					kv, ok := elt.(*ast.KeyValueExpr)
+					if !ok {
+						continue
+					}
+					className, ok := stringLiteral(kv.Key)
+					inner, isMap := kv.Value.(*ast.CompositeLit)
+					if !ok || !isMap {
+						continue
+					}
+					props := make(map[string]string, len(inner.Elts))
+					for _, propElt := range inner.Elts {
+						propKV, ok := propElt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						name, okName := stringLiteral(propKV.Key)
+						value, okValue := stringLiteral(propKV.Value)
+						if okName && okValue {
+							props[name] = value
+						}
+					}
+					properties[className] = props
+				}
+			}
+		}
+	}
+
+	return properties, nil
+}
+
+// stringLiteral unquotes expr when it is a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
@@ -0,0 +1,151 @@
+package cssgen
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// RenameConfig holds configuration for renaming a CSS class across scanned files
+type RenameConfig struct {
+	ScanPaths     []string // Patterns to rewrite (same as LintConfig.ScanPaths)
+	GeneratedFile string   // Path to styles.gen.go (for const <-> class lookup)
+	PackageName   string   // "ui"
+	OldClass      string   // "btn--primary"
+	NewClass      string   // "btn--brand"
+	MaxFiles      int      // Abort if ScanPaths match more files (0 = unlimited)
+	Exclude       []string // Glob patterns of matched files to leave alone
+}
+
+// RenameResult summarizes a rename run
+type RenameResult struct {
+	FilesChanged     int
+	ConstantsChanged int // ui.OldConst -> ui.NewConst rewrites
+	StringsChanged   int // "old-class" -> "new-class" rewrites
+	OldConst         string
+	NewConst         string
+}
+
+// Rename rewrites ui.OldConst references and hardcoded "old-class" tokens in ScanPaths.
+// Stylesheets are not touched; rename the CSS class there and regenerate first or after.
+func Rename(config RenameConfig) (*RenameResult, error) {
+	if config.OldClass == "" || config.NewClass == "" {
+		return nil, fmt.Errorf("old and new class names are required")
+	}
+	if strings.ContainsAny(config.OldClass+config.NewClass, " \t\"'") {
+		return nil, fmt.Errorf("class names must be single tokens")
+	}
+	if config.OldClass == config.NewClass {
+		return nil, fmt.Errorf("old and new class names are identical")
+	}
+
+	constants, _, err := ParseGeneratedFile(config.GeneratedFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated file: %w", err)
+	}
+	lookup := buildLookupMaps(constants)
+
+	result := &RenameResult{
+		OldConst: findConstantSuggestion(config.OldClass, lookup),
+		NewConst: findConstantSuggestion(config.NewClass, lookup),
+	}
+	if result.OldConst == "" {
+		result.OldConst = toGoName(config.OldClass)
+	}
+	if result.NewConst == "" {
+		// New class may not be generated yet - use the name the generator will produce
+		result.NewConst = toGoName(config.NewClass)
+	}
+
+	if config.PackageName == "" {
+		config.PackageName = "ui"
+	}
+	constPattern := regexp.MustCompile(`\b` + regexp.QuoteMeta(config.PackageName+"."+result.OldConst) + `\b`)
+	classPattern := regexp.MustCompile(`(^|[\s"'` + "`" + `])` + regexp.QuoteMeta(config.OldClass) + `($|[\s"'` + "`" + `])`)
+
+	// The same file set lint scans: templ-generated and gitignored files are left alone
+	files, _, err := collectScanFiles(config.ScanPaths, nil, scanOptions{maxFiles: config.MaxFiles, exclude: config.Exclude})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan files: %w", err)
+	}
+
+	for _, file := range files {
+		changed, err := renameInFile(file, config, result, constPattern, classPattern)
+		if err != nil {
+			return nil, err
+		}
+		if changed {
+			result.FilesChanged++
+		}
+	}
+
+	return result, nil
+}
+
+// renameInFile rewrites a single file line by line
+// Hardcoded strings are only rewritten on lines where the scanner sees them as class references
+func renameInFile(file string, config RenameConfig, result *RenameResult, constPattern, classPattern *regexp.Regexp) (bool, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return false, fmt.Errorf("stat %s: %w", file, err)
+	}
+	// #nosec G304 - path comes from trusted configuration
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", file, err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	changed := false
+
+	for i, line := range lines {
+		newLine := line
+
+		if n := len(constPattern.FindAllStringIndex(newLine, -1)); n > 0 {
+			newLine = constPattern.ReplaceAllString(newLine, config.PackageName+"."+result.NewConst)
+			result.ConstantsChanged += n
+		}
+
+		if lineReferencesClass(line, i+1, file, config.OldClass) {
+			// Replace repeatedly: adjacent tokens share a separator, so one pass can miss some
+			for {
+				n := len(classPattern.FindAllStringIndex(newLine, -1))
+				if n == 0 {
+					break
+				}
+				newLine = classPattern.ReplaceAllString(newLine, "${1}"+config.NewClass+"${2}")
+				result.StringsChanged += n
+			}
+		}
+
+		if newLine != line {
+			lines[i] = newLine
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	if err := os.WriteFile(file, []byte(strings.Join(lines, "\n")), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("write %s: %w", file, err)
+	}
+	return true, nil
+}
+
+// lineReferencesClass reports whether the scanner finds className in a hardcoded class reference
+func lineReferencesClass(line string, lineNum int, file string, className string) bool {
+	for _, ref := range extractClassesFromLine(line, lineNum, file) {
+		if ref.IsConstant {
+			continue
+		}
+		for _, token := range strings.Fields(ref.FullClassValue) {
+			if token == className {
+				return true
+			}
+		}
+	}
+	return false
+}
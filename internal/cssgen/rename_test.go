@@ -0,0 +1,71 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRename(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte(`package ui
+
+var AllCSSClasses = map[string]bool{
+	"btn": true,
+	"btn--primary": true,
+}
+
+const Btn = "btn"
+const BtnPrimary = "btn--primary"
+`), 0644))
+
+	templFile := filepath.Join(tmpDir, "page.templ")
+	require.NoError(t, os.WriteFile(templFile, []byte(`package test
+
+templ Page() {
+	<button class={ ui.Btn, ui.BtnPrimary }>Save</button>
+	<button class="btn btn--primary">Cancel</button>
+	<p>btn--primary is mentioned in prose</p>
+	<div class={ ui.BtnPrimaryLarge }></div>
+}
+`), 0644))
+
+	untouched := filepath.Join(tmpDir, "other.templ")
+	require.NoError(t, os.WriteFile(untouched, []byte("<div class=\"btn\"></div>\n"), 0644))
+
+	// templ's output is skipped, as lint skips it; templ generate rewrites it
+	generatedTempl := filepath.Join(tmpDir, "page_templ.go")
+	require.NoError(t, os.WriteFile(generatedTempl, []byte("package test\n\nvar _ = ui.BtnPrimary\n"), 0644))
+
+	result, err := Rename(RenameConfig{
+		ScanPaths:     []string{filepath.Join(tmpDir, "*.templ"), filepath.Join(tmpDir, "*_templ.go")},
+		GeneratedFile: generatedFile,
+		PackageName:   "ui",
+		OldClass:      "btn--primary",
+		NewClass:      "btn--brand",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.FilesChanged)
+	assert.Equal(t, "BtnPrimary", result.OldConst)
+	assert.Equal(t, "BtnBrand", result.NewConst)
+	assert.Equal(t, 1, result.ConstantsChanged)
+	assert.Equal(t, 1, result.StringsChanged)
+
+	content, err := os.ReadFile(templFile)
+	require.NoError(t, err)
+	got := string(content)
+	assert.Contains(t, got, "{ ui.Btn, ui.BtnBrand }")
+	assert.Contains(t, got, `class="btn btn--brand"`)
+	assert.Contains(t, got, "<p>btn--primary is mentioned in prose</p>", "non-class text must not change")
+	assert.Contains(t, got, "ui.BtnPrimaryLarge", "longer constant names must not change")
+
+	content, err = os.ReadFile(generatedTempl)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "ui.BtnPrimary\n")
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 	"sort"
 	"strings"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Reporter handles formatting and outputting linting results
@@ -14,25 +16,36 @@ type Reporter struct {
 	useColors       bool
 	printLines      bool
 	printLinterName bool
+	groupBy         GroupBy
 }
 
 // NewReporter creates a new reporter with the given configuration
 func NewReporter(w io.Writer, config LintConfig) *Reporter {
 	return &Reporter{
 		w:               w,
-		useColors:       shouldUseColors(config),
+		useColors:       ShouldUseColors(config),
 		printLines:      config.PrintIssuedLines,
 		printLinterName: config.PrintLinterName,
+		groupBy:         config.GroupBy,
 	}
 }
 
-// shouldUseColors determines if colors should be enabled
-func shouldUseColors(config LintConfig) bool {
-	// Explicit flag wins
+// ShouldUseColors determines if colors should be enabled: flags first, then NO_COLOR,
+// CI variables and whether stdout is a terminal
+func ShouldUseColors(config LintConfig) bool {
+	// Explicit flags win, --no-color over --color
+	if config.NoColor {
+		return false
+	}
 	if config.UseColors {
 		return true
 	}
 
+	// NO_COLOR (https://no-color.org) turns off auto-detection
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
 	// Check for FORCE_COLOR environment variable (GitHub Actions, etc.)
 	if os.Getenv("FORCE_COLOR") != "" {
 		return true
@@ -54,7 +67,7 @@ func shouldUseColors(config LintConfig) bool {
 // PrintIssues outputs issues in golangci-lint format
 func (r *Reporter) PrintIssues(issues []Issue) {
 	// Sort issues by file, then line, then column
-	sort.Slice(issues, func(i, j int) bool {
+	sort.SliceStable(issues, func(i, j int) bool {
 		if issues[i].Pos.Filename != issues[j].Pos.Filename {
 			return issues[i].Pos.Filename < issues[j].Pos.Filename
 		}
@@ -64,10 +77,133 @@ func (r *Reporter) PrintIssues(issues []Issue) {
 		return issues[i].Pos.Column < issues[j].Pos.Column
 	})
 
-	// Print each issue
+	if r.groupBy == GroupByNone {
+		for _, issue := range issues {
+			r.printIssue(issue)
+		}
+		return
+	}
+
+	// Group issues, keeping the file/line/column order within each group
+	groups := make(map[string][]Issue)
 	for _, issue := range issues {
-		r.printIssue(issue)
+		key := issueGroupKey(issue, r.groupBy)
+		groups[key] = append(groups[key], issue)
+	}
+
+	for i, key := range sortedGroupKeys(groups, r.groupBy) {
+		if i > 0 {
+			fmt.Fprintln(r.w)
+		}
+		header := fmt.Sprintf("%s (%d)", key, len(groups[key]))
+		fmt.Fprintln(r.w, RenderStyle(groupHeaderStyle(key), header, r.useColors))
+		for _, issue := range groups[key] {
+			r.printIssue(issue)
+		}
+	}
+}
+
+// issueGroupKey returns the header an issue is listed under
+func issueGroupKey(issue Issue, groupBy GroupBy) string {
+	switch groupBy {
+	case GroupBySeverity:
+		switch issue.Severity {
+		case SeverityError:
+			return "Errors"
+		case SeverityWarning:
+			return "Warnings"
+		default:
+			return "Info"
+		}
+	case GroupByType:
+		return issueTypeLabel(issue)
+	default:
+		return issue.Pos.Filename
+	}
+}
+
+// issueTypeLabel classifies an issue by the rule that produced it
+func issueTypeLabel(issue Issue) string {
+	switch issue.Rule {
+	case RuleInvalidClass:
+		return "Invalid classes"
+	case RuleHardcodedClass:
+		return "Hardcoded classes"
+	case RuleUnusedConstant:
+		return "Unused constants"
+	case RuleRedundantClass:
+		return "Redundant classes"
+	case RuleStaleConstant:
+		return "Stale constants"
+	case RuleEmptyClass:
+		return "Empty class attributes"
+	case RuleA11yHidden:
+		return "Accessibility"
+	default:
+		return "Other"
+	}
+}
+
+// groupOrder fixes the header order for severity and type grouping
+var groupOrder = map[string]int{
+	"Errors":                 0,
+	"Warnings":               1,
+	"Info":                   2,
+	"Invalid classes":        0,
+	"Hardcoded classes":      1,
+	"Unused constants":       2,
+	"Redundant classes":      3,
+	"Stale constants":        4,
+	"Empty class attributes": 5,
+	"Accessibility":          6,
+	"Other":                  7,
+}
+
+// sortedGroupKeys orders headers by severity/type rank, or alphabetically for files
+func sortedGroupKeys(groups map[string][]Issue, groupBy GroupBy) []string {
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if groupBy != GroupByFile && groupOrder[keys[i]] != groupOrder[keys[j]] {
+			return groupOrder[keys[i]] < groupOrder[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// groupHeaderStyle colors severity headers like their issues would be in a summary
+func groupHeaderStyle(key string) lipgloss.Style {
+	switch key {
+	case "Errors", "Invalid classes":
+		return StyleRed
+	case "Warnings", "Hardcoded classes", "Unused constants":
+		return StyleYellow
+	default:
+		return StyleCyan
+	}
+}
+
+// PrintProgress outputs a scan completion notice when more than threshold files were scanned
+// A threshold of 0 disables the notice
+func (r *Reporter) PrintProgress(result LintResult, threshold int) {
+	if threshold <= 0 || result.FilesScanned <= threshold {
+		return
+	}
+	fmt.Fprintln(r.w, RenderStyle(StyleGray, "🔍 Scanning complete", r.useColors))
+}
+
+// PrintBaselineHeader outputs how many issues are new relative to the baseline
+func (r *Reporter) PrintBaselineHeader(result LintResult) {
+	if !result.BaselineApplied {
+		return
 	}
+
+	fmt.Fprintf(r.w, "%s (%d suppressed by baseline)\n",
+		pluralizeCount(len(result.Issues), "new issue", "new issues"),
+		result.BaselineSuppressed)
 }
 
 // printIssue formats a single issue in golangci-lint style
@@ -80,10 +216,18 @@ func (r *Reporter) printIssue(issue Issue) {
 		linterSuffix = fmt.Sprintf(" (%s)", issue.FromLinter)
 	}
 
-	// Print main issue line
+	// Print main issue line, message colored by severity
+	message := issue.Message()
+	switch issue.Severity {
+	case SeverityError:
+		message = RenderStyle(StyleRed, message, r.useColors)
+	case SeverityWarning:
+		message = RenderStyle(StyleYellow, message, r.useColors)
+	}
+
 	fmt.Fprintf(r.w, "%s %s%s\n",
 		RenderStyle(StyleCyan, location, r.useColors),
-		issue.Text,
+		message,
 		RenderStyle(StyleGray, linterSuffix, r.useColors))
 
 	// Print source lines with caret indicator
@@ -1,8 +1,12 @@
 package cssgen
 
 import (
+	"bytes"
+	"strings"
 	"testing"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,3 +58,98 @@ func TestBuildCaretIndicator(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintIssuesGroupBySeverity(t *testing.T) {
+	issues := []Issue{
+		{FromLinter: "csslint", Text: `hardcoded CSS class "btn" should use ui.Btn constant`, Severity: SeverityWarning, Pos: IssuePos{Filename: "a.templ", Line: 1, Column: 1}},
+		{FromLinter: "csslint", Text: `invalid CSS class "btn--typo" not found in stylesheet`, Severity: SeverityError, Pos: IssuePos{Filename: "b.templ", Line: 9, Column: 1}},
+		{FromLinter: "csslint", Text: `invalid CSS class "card--typo" not found in stylesheet`, Severity: SeverityError, Pos: IssuePos{Filename: "a.templ", Line: 5, Column: 1}},
+	}
+
+	var buf bytes.Buffer
+	NewReporter(&buf, LintConfig{GroupBy: GroupBySeverity}).PrintIssues(issues)
+	out := buf.String()
+
+	errorsAt := strings.Index(out, "Errors (2)")
+	warningsAt := strings.Index(out, "Warnings (1)")
+	require.GreaterOrEqual(t, errorsAt, 0)
+	require.Greater(t, warningsAt, errorsAt)
+
+	// Within a group, file/line/column order is kept
+	require.Less(t, strings.Index(out, "card--typo"), strings.Index(out, "btn--typo"))
+	require.Greater(t, strings.Index(out, `"btn" should use`), warningsAt)
+}
+
+func TestPrintIssuesGroupByType(t *testing.T) {
+	issues := []Issue{
+		{FromLinter: "csslint", Rule: RuleRedundantClass, Text: `class "btn" is redundant: "card" already sets color`, Pos: IssuePos{Filename: "a.templ", Line: 1, Column: 1}},
+		// Custom rules are grouped by their rule, not by what their message looks like
+		{FromLinter: "csslint", Rule: "no-legacy", Text: `invalid CSS class "grid" is redundant`, Severity: SeverityError, Pos: IssuePos{Filename: "a.templ", Line: 2, Column: 1}},
+	}
+
+	var buf bytes.Buffer
+	NewReporter(&buf, LintConfig{GroupBy: GroupByType}).PrintIssues(issues)
+	out := buf.String()
+
+	redundantAt := strings.Index(out, "Redundant classes (1)")
+	otherAt := strings.Index(out, "Other (1)")
+	require.GreaterOrEqual(t, redundantAt, 0)
+	require.Greater(t, otherAt, redundantAt)
+	require.Greater(t, strings.Index(out, `"grid" is redundant`), otherAt)
+}
+
+func TestPrintIssueColorsMessageBySeverity(t *testing.T) {
+	// Force ANSI output regardless of the test runner's terminal
+	prev := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(prev) })
+
+	errorIssue := Issue{
+		FromLinter: "csslint",
+		Text:       `invalid CSS class "btn--typo" not found in stylesheet`,
+		Severity:   SeverityError,
+		Pos:        IssuePos{Filename: "a.templ", Line: 1, Column: 1},
+	}
+	warningIssue := errorIssue
+	warningIssue.Text = `hardcoded CSS class "btn" should use ui.Btn constant`
+	warningIssue.Severity = SeverityWarning
+
+	var buf bytes.Buffer
+	reporter := NewReporter(&buf, LintConfig{UseColors: true})
+	reporter.printIssue(errorIssue)
+	reporter.printIssue(warningIssue)
+	out := buf.String()
+
+	require.Contains(t, out, StyleRed.Render(errorIssue.Text))
+	require.Contains(t, out, "\x1b[1;31m"+errorIssue.Text)
+	require.Contains(t, out, StyleYellow.Render(warningIssue.Text))
+
+	// Without colors the message is plain
+	buf.Reset()
+	(&Reporter{w: &buf}).printIssue(errorIssue)
+	require.NotContains(t, buf.String(), "\x1b[")
+}
+
+func TestShouldUseColors(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      map[string]string
+		config   LintConfig
+		expected bool
+	}{
+		{"NO_COLOR beats GitHub Actions", map[string]string{"NO_COLOR": "1", "GITHUB_ACTIONS": "true"}, LintConfig{}, false},
+		{"NO_COLOR beats FORCE_COLOR", map[string]string{"NO_COLOR": "1", "FORCE_COLOR": "1"}, LintConfig{}, false},
+		{"--color beats NO_COLOR", map[string]string{"NO_COLOR": "1"}, LintConfig{UseColors: true}, true},
+		{"--no-color beats --color", map[string]string{"GITHUB_ACTIONS": "true"}, LintConfig{UseColors: true, NoColor: true}, false},
+		{"GitHub Actions", map[string]string{"GITHUB_ACTIONS": "true"}, LintConfig{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"NO_COLOR", "FORCE_COLOR", "GITHUB_ACTIONS"} {
+				t.Setenv(key, tt.env[key])
+			}
+			require.Equal(t, tt.expected, ShouldUseColors(tt.config))
+		})
+	}
+}
@@ -32,6 +32,7 @@ func (r *VerboseReporter) PrintStatistics(result LintResult) {
 	fmt.Fprintf(r.w, "Files Scanned:           %d\n", result.FilesScanned)
 	fmt.Fprintf(r.w, "Hardcoded Classes:       %d\n", result.ClassesFound)
 	fmt.Fprintf(r.w, "Constant References:     %d\n", result.ConstantsFound)
+	fmt.Fprintf(r.w, "Health Score:            %.0f/100 (%s)\n", result.HealthScore, healthLabel(result.HealthScore))
 }
 
 // PrintAdoptionProgress shows visual progress bar
@@ -73,6 +74,17 @@ func (r *VerboseReporter) PrintQuickWins(result LintResult) {
 				i+1, win.ClassName, win.Occurrences, win.Suggestion)
 		}
 	}
+
+	if len(result.QuickWins.Components) > 0 {
+		fmt.Fprintln(r.w, "\nBy Component (classes spread over one templ component):")
+		for i, scope := range result.QuickWins.Components {
+			if i >= 10 {
+				break
+			}
+			fmt.Fprintf(r.w, "%d. %s (%s) - %d elements → %s\n",
+				i+1, scope.Component, scope.File, scope.Elements, scope.Suggestion)
+		}
+	}
 }
 
 // PrintWarnings shows linter warnings
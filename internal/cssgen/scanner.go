@@ -2,11 +2,16 @@ package cssgen
 
 import (
 	"bufio"
+	"fmt"
+	"io"
+	"io/fs"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 	"sync"
+	"unicode"
 
 	"github.com/bmatcuk/doublestar/v4"
 	ignore "github.com/sabhiram/go-gitignore"
@@ -20,6 +25,10 @@ type ClassReference struct {
 	IsConstant     bool         // true if using ui.Foo, false if "foo"
 	ConstName      string       // "Foo" if IsConstant is true
 	LineContent    string       // The full line for context
+	InExpression   bool         // From a :class style expression, where constants can't be substituted
+	InStructTag    bool         // From a class:"..." struct tag, which must stay a string literal
+	IsEmpty        bool         // class="" or class="  ": an attribute naming no classes
+	Component      string       // Enclosing templ component, e.g. "Card" ("" outside one)
 }
 
 // FileLocation tracks where a class reference was found
@@ -28,73 +37,233 @@ type FileLocation struct {
 	Line   int
 	Column int    // 1-based column (exact start of class name)
 	Text   string // Full line content for source display
+	Indent int    // Bytes of leading whitespace trimmed from Text
+}
+
+// lineLocation locates a reference starting at byte offset of the raw line, keeping
+// the trimmed line for display
+func lineLocation(file string, lineNum, offset int, line string) FileLocation {
+	return FileLocation{
+		File:   file,
+		Line:   lineNum,
+		Column: offset + 1,
+		Text:   strings.TrimSpace(line),
+		Indent: len(line) - len(strings.TrimLeftFunc(line, unicode.IsSpace)),
+	}
+}
+
+// textColumn converts Column to the trimmed Text's coordinates
+func (l FileLocation) textColumn() int {
+	return l.Column - l.Indent
 }
 
 // ScanStats tracks file scanning statistics
 type ScanStats struct {
-	FilesDiscovered int // Total files found by glob patterns
-	FilesScanned    int // Files actually scanned (after filtering)
-	FilesSkipped    int // Files skipped due to filtering
+	FilesDiscovered int           // Total files found by glob patterns
+	FilesScanned    int           // Files actually scanned (after filtering)
+	FilesSkipped    int           // Files skipped due to filtering
+	Skipped         []SkippedFile // Each skipped file with its reason (for --debug-scan)
+}
+
+// SkippedFile records a file excluded from scanning and why
+type SkippedFile struct {
+	Path   string
+	Reason string // SkipReasonTemplGenerated, SkipReasonGitignored or SkipReasonExcluded
 }
 
+// Skip reasons reported by --debug-scan
+const (
+	SkipReasonTemplGenerated = "templ-generated"
+	SkipReasonGitignored     = "gitignored"
+	SkipReasonExcluded       = "excluded" // Matched an exclude pattern
+)
+
+// quotedValue matches a double-quoted, single-quoted, or backtick string,
+// capturing its content in one of three groups (see capturedValue)
+const quotedValue = `(?:"([^"]+)"|'([^']+)'|` + "`([^`]+)`)"
+
 // scanPattern represents a regex pattern for finding class references
 type scanPattern struct {
-	name    string
-	regex   *regexp.Regexp
-	isConst bool
+	name      string
+	regex     *regexp.Regexp
+	isConst   bool
+	qualifier string         // Package qualifier of a constant pattern ("ui" for ui.Foo)
+	attribute bool           // Match must start an attribute name (data-class= is not class=)
+	literal   string         // Substring every match contains, checked before running the regex
+	exts      []string       // Only applies to files with these extensions (nil = all files)
+	toggle    bool           // Captures a JS expression whose strings and object keys are classes
+	structTag bool           // Matches a Go struct tag
+	empty     *regexp.Regexp // Matches the attribute with an empty value, e.g. class="" (nil = not checked)
+}
+
+// defaultAttributes are the attributes whose values are scanned as class strings
+var defaultAttributes = []string{"class"}
+
+// defaultQualifier is the package qualifier of constant references (ui.Btn)
+const defaultQualifier = "ui"
+
+// scanOptions configures what scanFiles recognizes as a class reference
+type scanOptions struct {
+	attributes []string      // Attributes scanned for class strings (nil = class)
+	qualifier  string        // Package qualifier of constant references ("" = ui)
+	imports    packageImport // Imports whose alias is also recognized, per file
+	structTags bool          // Also scan class:"..." in Go struct tags
+	dataKeys   []string      // Keys whose values are class strings in .yaml/.yml/.json files (nil = off)
+	toggles    []string      // Attributes holding class toggle expressions, e.g. :class (nil = off)
+	maxFiles   int           // Abort as soon as more files match (0 = unlimited)
+	exclude    []string      // Glob patterns of matched files to skip
+}
+
+// lintScanOptions derives scan options from a lint config
+func lintScanOptions(config LintConfig) scanOptions {
+	return scanOptions{
+		attributes: config.Attributes,
+		qualifier:  config.PackageAlias,
+		imports:    packageImport{path: config.ImportPath, name: config.PackageName},
+		structTags: config.StructTags,
+		dataKeys:   config.DataKeys,
+		toggles:    config.ToggleAttributes,
+		maxFiles:   config.MaxFiles,
+		exclude:    config.Exclude,
+	}
+}
+
+// packageImport identifies imports of the generated package in scanned files
+type packageImport struct {
+	path string // Exact import path; "" matches any path ending in name
+	name string // Package name, the qualifier of an import without an alias
+}
+
+// importSpecPattern matches `css "example.com/app/ui"` or `"example.com/app/ui"`
+var importSpecPattern = regexp.MustCompile(`^(?:([A-Za-z_][A-Za-z0-9_]*)\s+)?"([^"]+)"`)
+
+// qualifier returns the qualifier an import spec gives the generated package ("" if
+// the spec imports something else, or imports it blank or dot)
+func (p packageImport) qualifier(spec string) string {
+	m := importSpecPattern.FindStringSubmatch(strings.TrimSpace(spec))
+	if m == nil {
+		return ""
+	}
+	alias, importPath := m[1], m[2]
+
+	switch {
+	case p.path != "" && importPath != p.path:
+		return ""
+	case p.path == "" && (p.name == "" || path.Base(importPath) != p.name):
+		return ""
+	}
+
+	if alias == "" {
+		return p.name
+	}
+	if alias == "_" {
+		return ""
+	}
+	return alias
+}
+
+// importTracker follows a file's import declarations line by line
+type importTracker struct {
+	inBlock bool
+}
+
+// spec returns the import spec on line, if line is part of an import declaration
+func (t *importTracker) spec(line string) (string, bool) {
+	trimmed := strings.TrimSpace(line)
+	if t.inBlock {
+		if strings.HasPrefix(trimmed, ")") {
+			t.inBlock = false
+			return "", false
+		}
+		return trimmed, true
+	}
+	if !strings.HasPrefix(trimmed, "import") {
+		return "", false
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "import"))
+	if strings.HasPrefix(rest, "(") {
+		t.inBlock = true
+		return "", false
+	}
+	return rest, rest != ""
+}
+
+// withQualifier returns linePatterns plus a constant pattern for qualifier,
+// unless one is already present
+func withQualifier(linePatterns []scanPattern, qualifier string) []scanPattern {
+	for _, p := range linePatterns {
+		if p.isConst && p.qualifier == qualifier {
+			return linePatterns
+		}
+	}
+	extended := make([]scanPattern, 0, len(linePatterns)+1)
+	extended = append(extended, constantPattern(qualifier))
+	return append(extended, linePatterns...)
+}
+
+// constantPattern matches qualifier.Foo constant references
+func constantPattern(qualifier string) scanPattern {
+	return scanPattern{
+		name:      qualifier + " package constant",
+		regex:     regexp.MustCompile(`\b` + regexp.QuoteMeta(qualifier) + `\.([A-Z][a-zA-Z0-9]*)`),
+		isConst:   true,
+		qualifier: qualifier,
+		literal:   qualifier + ".",
+	}
 }
 
 var (
-	// Patterns for finding CSS class references
-	// Ordered from most specific to least specific
-	patterns = []scanPattern{
-		// Constant usage (ui.Foo)
-		{
-			name:    "ui package constant",
-			regex:   regexp.MustCompile(`ui\.([A-Z][a-zA-Z0-9]*)`),
-			isConst: true,
-		},
+	// Patterns for finding CSS class references with the default attributes
+	patterns = buildScanPatterns(nil, defaultQualifier)
 
-		// Hardcoded strings in various contexts
-		{
-			name:    "class attribute with quotes",
-			regex:   regexp.MustCompile(`class="([^"]+)"`),
-			isConst: false,
-		},
-		{
-			name:    "class with string literal in braces",
-			regex:   regexp.MustCompile(`class=\{\s*"([^"]+)"`),
-			isConst: false,
-		},
+	// Patterns for calls that take class strings
+	callPatterns = []scanPattern{
 		{
 			name:    "templ.Classes with string",
-			regex:   regexp.MustCompile(`templ\.Classes\(\s*"([^"]+)"`),
+			regex:   regexp.MustCompile(`templ\.Classes\(\s*` + quotedValue),
 			isConst: false,
+			literal: "templ.Classes(",
 		},
 		{
 			name:    "templ.KV with string",
-			regex:   regexp.MustCompile(`templ\.KV\(\s*"([^"]+)"`),
+			regex:   regexp.MustCompile(`templ\.KV\(\s*` + quotedValue),
 			isConst: false,
+			literal: "templ.KV(",
 		},
 		{
 			name:    "ds.Class call",
-			regex:   regexp.MustCompile(`ds\.Class\(\s*"([^"]+)"`),
+			regex:   regexp.MustCompile(`ds\.Class\(\s*` + quotedValue),
 			isConst: false,
+			literal: "ds.Class(",
 		},
 	}
 
-	// Regex to detect templ.Classes and templ.KV with comma-separated values
-	templClassesMulti = regexp.MustCompile(`templ\.Classes\(([^)]+)\)`)
-	templKVMulti      = regexp.MustCompile(`templ\.KV\(([^)]+)\)`)
+	// structTagPattern matches class:"..." in Go struct tags (opt-in, Go files only)
+	// The colon keeps it apart from the class= attribute patterns
+	structTagPattern = scanPattern{
+		name:      "class struct tag",
+		regex:     regexp.MustCompile(`class:"([^"]+)"`),
+		attribute: true,
+		literal:   `class:"`,
+		exts:      []string{".go"},
+		structTag: true,
+	}
 
 	// Comment patterns to skip
 	commentPattern = regexp.MustCompile(`^\s*//`)
 
+	// Quoted items inside an annotated []string{...} literal
+	stringLiteralPattern = regexp.MustCompile(`"([^"\\]*)"`)
+
 	// gitignore caching
 	gitIgnoreCache *ignore.GitIgnore
 	gitIgnoreOnce  sync.Once
 )
 
+// classesHint marks a []string literal as a list of CSS classes
+// Place it at the end of the line or on the line directly above
+const classesHint = "cssgen:classes"
+
 // isTemplGenerated checks if a file is a templ-generated Go file
 // Handles both _templ.go and .templ.go suffix variations
 func isTemplGenerated(path string) bool {
@@ -125,9 +294,14 @@ func loadGitIgnore() *ignore.GitIgnore {
 // 1. Pattern check (fast): Skip *_templ.go files
 // 2. Gitignore check (professional): Skip gitignored files (only for relative paths)
 func shouldSkipFile(path string) bool {
+	return skipReason(path) != ""
+}
+
+// skipReason returns why a file is excluded from scanning, or "" if it should be scanned
+func skipReason(path string) string {
 	// Layer 1: Fast pattern check for templ-generated files
 	if isTemplGenerated(path) {
-		return true
+		return SkipReasonTemplGenerated
 	}
 
 	// Layer 2: Check against .gitignore if available
@@ -136,16 +310,337 @@ func shouldSkipFile(path string) bool {
 	if !filepath.IsAbs(path) {
 		gi := loadGitIgnore()
 		if gi != nil && gi.MatchesPath(path) {
-			return true
+			return SkipReasonGitignored
+		}
+	}
+
+	return ""
+}
+
+// PrintSkippedFiles lists each skipped file with the reason it was excluded
+func PrintSkippedFiles(w io.Writer, stats ScanStats) {
+	for _, skipped := range stats.Skipped {
+		fmt.Fprintf(w, "  skipped %s (%s)\n", skipped.Path, skipped.Reason)
+	}
+}
+
+// buildScanPatterns returns the scan patterns for the given class attributes and
+// constant qualifier. Ordered from most specific to least specific; no attributes
+// means just "class", no qualifier means "ui"
+func buildScanPatterns(attributes []string, qualifier string) []scanPattern {
+	if len(attributes) == 0 {
+		attributes = defaultAttributes
+	}
+	if qualifier == "" {
+		qualifier = defaultQualifier
+	}
+
+	// Constant usage (ui.Foo)
+	result := []scanPattern{constantPattern(qualifier)}
+
+	// Hardcoded strings in various contexts
+	for _, attr := range attributes {
+		result = append(result, attributePatterns(attr)...)
+	}
+
+	return append(result, callPatterns...)
+}
+
+// attributePatterns matches attr="..." and attr={ "..." }
+func attributePatterns(attr string) []scanPattern {
+	name := regexp.QuoteMeta(attr)
+	return []scanPattern{
+		{
+			name:      attr + " attribute with quotes",
+			regex:     regexp.MustCompile(name + `=` + quotedValue),
+			attribute: true,
+			literal:   attr + "=",
+			empty:     regexp.MustCompile(name + `=(?:""|''|\{\s*(?:""|` + "``" + `)\s*\})`),
+		},
+		{
+			name:      attr + " with string literal in braces",
+			regex:     regexp.MustCompile(name + `=\{\s*` + quotedValue),
+			attribute: true,
+			literal:   attr + "=",
+		},
+	}
+}
+
+// togglePattern matches attr="..." holding an Alpine/Vue style class expression,
+// e.g. :class="{ 'btn--active': open }" or x-bind:class="open ? 'menu--open' : ''"
+func togglePattern(attr string) scanPattern {
+	return scanPattern{
+		name:      attr + " class toggle expression",
+		regex:     regexp.MustCompile(regexp.QuoteMeta(attr) + `=(?:"([^"]+)"|'([^']+)')`),
+		attribute: true,
+		literal:   attr + "=",
+		toggle:    true,
+	}
+}
+
+// toggleClass is a class string found in a toggle expression
+type toggleClass struct {
+	value  string
+	offset int // Byte offset of value within the expression
+}
+
+// toggleClasses returns the quoted strings of a class toggle expression and the keys
+// of its { 'btn': cond } object literals. Object values are conditions, so strings
+// inside them ({ 'btn': mode === 'dark' }) are not classes; nor are ${...} templates
+func toggleClasses(expr string) []toggleClass {
+	var found []toggleClass
+	depth := 0         // Open object literals
+	expectKey := false // At the start of an object entry
+
+	// keyFollows reports whether a key/value colon comes next after i
+	keyFollows := func(i int) bool {
+		return strings.HasPrefix(strings.TrimLeft(expr[i:], " \t"), ":")
+	}
+
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == '{':
+			depth++
+			expectKey = true
+			i++
+		case c == '}':
+			depth = max(depth-1, 0)
+			expectKey = false
+			i++
+		case c == ',':
+			expectKey = depth > 0
+			i++
+		case c == '"' || c == '\'' || c == '`':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end == -1 {
+				return found
+			}
+			value := expr[i+1 : i+1+end]
+			next := i + end + 2
+			if (depth == 0 || (expectKey && keyFollows(next))) && value != "" && !strings.Contains(value, "${") {
+				found = append(found, toggleClass{value: value, offset: i + 1})
+			}
+			expectKey = false
+			i = next
+		case expectKey && isIdentByte(c):
+			start := i
+			for i < len(expr) && isIdentByte(expr[i]) {
+				i++
+			}
+			if keyFollows(i) {
+				found = append(found, toggleClass{value: expr[start:i], offset: start})
+			}
+			expectKey = false
+		case c == ' ' || c == '\t':
+			i++
+		default:
+			expectKey = false
+			i++
+		}
+	}
+	return found
+}
+
+// isIdentByte reports whether b can appear in a bare JS object key
+func isIdentByte(b byte) bool {
+	return b == '_' || b == '$' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (b >= '0' && b <= '9')
+}
+
+// isMarkdownFile reports whether path is Markdown or MDX, where fenced code is
+// example text and JSX components use className
+func isMarkdownFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".md", ".mdx":
+		return true
+	}
+	return false
+}
+
+// markdownPatterns returns linePatterns plus className patterns for JSX in MDX
+func markdownPatterns(linePatterns []scanPattern) []scanPattern {
+	for _, p := range linePatterns {
+		if p.attribute && p.literal == "className=" {
+			return linePatterns
+		}
+	}
+	extended := make([]scanPattern, 0, len(linePatterns)+2)
+	extended = append(extended, linePatterns...)
+	return append(extended, attributePatterns("className")...)
+}
+
+// patternsForFile returns linePatterns minus those restricted to other file extensions
+func patternsForFile(linePatterns []scanPattern, filePath string) []scanPattern {
+	ext := strings.ToLower(filepath.Ext(filePath))
+	applies := func(p scanPattern) bool {
+		return p.exts == nil || contains(p.exts, ext)
+	}
+
+	all := true
+	for _, p := range linePatterns {
+		all = all && applies(p)
+	}
+	if all {
+		return linePatterns
+	}
+	kept := make([]scanPattern, 0, len(linePatterns))
+	for _, p := range linePatterns {
+		if applies(p) {
+			kept = append(kept, p)
 		}
 	}
+	return kept
+}
+
+// isDataFile reports whether path is a YAML or JSON file, scanned with dataKeyPatterns
+func isDataFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
+
+// dataKeyPatterns matches key: value in YAML and "key": "value" in JSON
+// A YAML key starts a line (or list item, or flow mapping entry), so commented-out
+// lines don't match; plain values end before a # comment
+func dataKeyPatterns(key string) []scanPattern {
+	name := regexp.QuoteMeta(key)
+	return []scanPattern{
+		{
+			name: key + " key in YAML",
+			regex: regexp.MustCompile(`(?:^\s*(?:-\s+)?|[{,]\s*)["']?` + name + `["']?:\s*` +
+				`(?:"([^"]+)"|'([^']+)'|([^\s#"'{}\[\],&*!|>](?:[^#{}\[\],]*[^\s#{}\[\],])?))`),
+			literal: key,
+			exts:    []string{".yaml", ".yml"},
+		},
+		{
+			name:      key + " key in JSON",
+			regex:     regexp.MustCompile(`"` + name + `"\s*:\s*"([^"]+)"`),
+			attribute: true,
+			literal:   `"` + key + `"`,
+			exts:      []string{".json"},
+		},
+	}
+}
 
+// fenceState tracks an open ``` or ~~~ code fence in a Markdown file
+type fenceState struct {
+	marker string // "```" or "~~~" while inside a fence
+}
+
+// skip reports whether line is a fence delimiter or inside a fence
+func (f *fenceState) skip(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	if f.marker != "" {
+		if strings.HasPrefix(trimmed, f.marker) {
+			f.marker = ""
+		}
+		return true
+	}
+	for _, marker := range []string{"```", "~~~"} {
+		if strings.HasPrefix(trimmed, marker) {
+			f.marker = marker
+			return true
+		}
+	}
 	return false
 }
 
+// continuesAttributeName reports whether the byte before idx belongs to a longer
+// attribute name (data-class, x-bind:class), so a match at idx is a different attribute
+func continuesAttributeName(line string, idx int) bool {
+	if idx == 0 {
+		return false
+	}
+	c := line[idx-1]
+	return c == '-' || c == ':' || c == '_' || c == '.' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
 // ScanFiles scans files matching the given patterns for CSS class references
 func ScanFiles(scanPatterns []string, verbose bool) ([]ClassReference, ScanStats, error) {
-	files, stats, err := expandGlobPatternsWithStats(scanPatterns)
+	return scanFiles(scanPatterns, nil, scanOptions{}, verbose)
+}
+
+// ScanFS scans files in fsys matching the given patterns, e.g. templates in an embed.FS
+// Patterns and reported paths are relative to the root of fsys
+func ScanFS(fsys fs.FS, scanPatterns []string) ([]ClassReference, ScanStats, error) {
+	src := fsSource{fsys: fsys}
+	files, stats, err := collectSourceFiles(src, scanPatterns, nil, scanOptions{})
+	if err != nil {
+		return nil, stats, err
+	}
+
+	var allRefs []ClassReference
+	for _, file := range files {
+		refs, err := scanSourceFile(src, file, patterns, packageImport{})
+		if err != nil {
+			continue
+		}
+		allRefs = append(allRefs, refs...)
+	}
+
+	return allRefs, stats, nil
+}
+
+// fileSource is where scanned files come from: the OS filesystem or an fs.FS
+type fileSource interface {
+	GlobWalk(pattern string, fn func(match string) error) error
+	Stat(name string) (fs.FileInfo, error)
+	Open(name string) (fs.File, error)
+	SkipReason(name string) string
+}
+
+// osSource reads from disk; paths may be absolute and .gitignore applies
+type osSource struct{}
+
+func (osSource) Stat(name string) (fs.FileInfo, error) { return os.Stat(name) }
+func (osSource) Open(name string) (fs.File, error)     { return os.Open(name) }
+func (osSource) SkipReason(name string) string         { return skipReason(name) }
+
+// GlobWalk is doublestar.FilepathGlob, handing each match to fn as it is found
+func (osSource) GlobWalk(pattern string, fn func(match string) error) error {
+	base, rel := doublestar.SplitPattern(filepath.ToSlash(filepath.Clean(pattern)))
+	if rel == "" || rel == "." || rel == ".." {
+		matches, err := doublestar.FilepathGlob(pattern)
+		if err != nil {
+			return err
+		}
+		for _, match := range matches {
+			if err := fn(match); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return doublestar.GlobWalk(os.DirFS(base), rel, func(match string, _ fs.DirEntry) error {
+		return fn(filepath.FromSlash(path.Join(base, match)))
+	})
+}
+
+// fsSource reads from an fs.FS; only templ-generated files are skipped
+type fsSource struct {
+	fsys fs.FS
+}
+
+func (s fsSource) Stat(name string) (fs.FileInfo, error) { return fs.Stat(s.fsys, name) }
+func (s fsSource) Open(name string) (fs.File, error)     { return s.fsys.Open(name) }
+
+func (s fsSource) GlobWalk(pattern string, fn func(match string) error) error {
+	return doublestar.GlobWalk(s.fsys, pattern, func(match string, _ fs.DirEntry) error { return fn(match) })
+}
+
+func (s fsSource) SkipReason(name string) string {
+	if isTemplGenerated(name) {
+		return SkipReasonTemplGenerated
+	}
+	return ""
+}
+
+// scanFiles scans glob patterns plus literal paths (e.g. from --paths-from)
+func scanFiles(scanPatterns []string, literalPaths []string, opts scanOptions, verbose bool) ([]ClassReference, ScanStats, error) {
+	files, stats, err := collectScanFiles(scanPatterns, literalPaths, opts)
 	if err != nil {
 		return nil, stats, err
 	}
@@ -155,9 +650,23 @@ func ScanFiles(scanPatterns []string, verbose bool) ([]ClassReference, ScanStats
 		println("✓ Scanned", stats.FilesScanned, "files (skipped", stats.FilesSkipped, "generated/ignored files)")
 	}
 
+	linePatterns := patterns
+	if len(opts.attributes) > 0 || (opts.qualifier != "" && opts.qualifier != defaultQualifier) {
+		linePatterns = buildScanPatterns(opts.attributes, opts.qualifier)
+	}
+	if opts.structTags {
+		linePatterns = append(linePatterns[:len(linePatterns):len(linePatterns)], structTagPattern)
+	}
+	for _, key := range opts.dataKeys {
+		linePatterns = append(linePatterns[:len(linePatterns):len(linePatterns)], dataKeyPatterns(key)...)
+	}
+	for _, attr := range opts.toggles {
+		linePatterns = append(linePatterns[:len(linePatterns):len(linePatterns)], togglePattern(attr))
+	}
+
 	var allRefs []ClassReference
 	for _, file := range files {
-		refs, err := scanFile(file)
+		refs, err := scanSourceFile(osSource{}, file, linePatterns, opts.imports)
 		if err != nil {
 			// Log warning but continue
 			continue
@@ -168,72 +677,174 @@ func ScanFiles(scanPatterns []string, verbose bool) ([]ClassReference, ScanStats
 	return allRefs, stats, nil
 }
 
-// expandGlobPatterns expands glob patterns to actual file paths
-func expandGlobPatterns(patterns []string) ([]string, error) {
-	var allFiles []string
-	seen := make(map[string]bool)
-
+// expandBraces rewrites each {a,b} alternation into separate patterns, so
+// "**/*.{templ,go}" becomes "**/*.templ" and "**/*.go" (nested groups included)
+// Unbalanced braces and \{ escapes are left for the glob library
+func expandBraces(patterns []string) []string {
+	var expanded []string
 	for _, pattern := range patterns {
-		matches, err := doublestar.FilepathGlob(pattern)
-		if err != nil {
-			return nil, err
-		}
+		expanded = append(expanded, expandBracePattern(pattern)...)
+	}
+	return expanded
+}
 
-		for _, match := range matches {
-			// Deduplicate and only include files (not directories)
-			if !seen[match] {
-				info, err := os.Stat(match)
-				if err == nil && !info.IsDir() {
-					// Apply two-layer filtering
-					if !shouldSkipFile(match) {
-						allFiles = append(allFiles, match)
-						seen[match] = true
-					}
-				}
+// expandBracePattern expands the first brace group of pattern, recursively
+func expandBracePattern(pattern string) []string {
+	open, depth := -1, 0
+	var commas []int
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++ // Skip the escaped character
+		case '{':
+			if open == -1 {
+				open = i
+			}
+			depth++
+		case ',':
+			if depth == 1 {
+				commas = append(commas, i)
+			}
+		case '}':
+			if open == -1 {
+				continue
 			}
+			depth--
+			if depth > 0 {
+				continue
+			}
+
+			prefix, suffix := pattern[:open], pattern[i+1:]
+			start := open + 1
+			var expanded []string
+			for _, end := range append(commas, i) {
+				expanded = append(expanded, expandBracePattern(prefix+pattern[start:end]+suffix)...)
+				start = end + 1
+			}
+			return expanded
 		}
 	}
+	return []string{pattern}
+}
 
-	return allFiles, nil
+// collectScanFiles expands glob patterns and appends literal paths (no glob expansion)
+// Both go through the same dedup and skip filtering
+func collectScanFiles(patterns []string, literals []string, opts scanOptions) ([]string, ScanStats, error) {
+	return collectSourceFiles(osSource{}, patterns, literals, opts)
 }
 
-// expandGlobPatternsWithStats expands globs and tracks statistics
-// Used when verbose output is enabled
-func expandGlobPatternsWithStats(patterns []string) ([]string, ScanStats, error) {
+// collectSourceFiles is collectScanFiles over any fileSource
+// Files matching opts.exclude are skipped; matching stops with an error once more
+// than opts.maxFiles files would be scanned, so a too-broad glob doesn't walk the
+// whole tree first
+func collectSourceFiles(src fileSource, patterns []string, literals []string, opts scanOptions) ([]string, ScanStats, error) {
 	var allFiles []string
 	seen := make(map[string]bool)
 	stats := ScanStats{}
 
-	for _, pattern := range patterns {
-		matches, err := doublestar.FilepathGlob(pattern)
-		if err != nil {
+	for _, pattern := range opts.exclude {
+		if !doublestar.ValidatePathPattern(pattern) {
+			return nil, stats, fmt.Errorf("invalid exclude pattern %q", pattern)
+		}
+	}
+	reasonFor := func(match string) string {
+		if reason := src.SkipReason(match); reason != "" {
+			return reason
+		}
+		for _, pattern := range opts.exclude {
+			if ok, _ := doublestar.PathMatch(pattern, match); ok {
+				return SkipReasonExcluded
+			}
+		}
+		return ""
+	}
+
+	add := func(match string) error {
+		if seen[match] {
+			return nil
+		}
+		info, err := src.Stat(match)
+		if err != nil || info.IsDir() {
+			return nil
+		}
+		seen[match] = true
+		stats.FilesDiscovered++
+
+		if reason := reasonFor(match); reason != "" {
+			stats.FilesSkipped++
+			stats.Skipped = append(stats.Skipped, SkippedFile{Path: match, Reason: reason})
+			return nil
+		}
+		allFiles = append(allFiles, match)
+		stats.FilesScanned++
+		if opts.maxFiles > 0 && stats.FilesScanned > opts.maxFiles {
+			return fmt.Errorf("scan patterns matched more than max-files (%d) files; tighten the patterns or raise the limit", opts.maxFiles)
+		}
+		return nil
+	}
+
+	for _, pattern := range expandBraces(patterns) {
+		if err := src.GlobWalk(pattern, add); err != nil {
 			return nil, stats, err
 		}
+	}
 
-		for _, match := range matches {
-			if !seen[match] {
-				info, err := os.Stat(match)
-				if err == nil && !info.IsDir() {
-					stats.FilesDiscovered++
-
-					if shouldSkipFile(match) {
-						stats.FilesSkipped++
-					} else {
-						allFiles = append(allFiles, match)
-						seen[match] = true
-						stats.FilesScanned++
-					}
-				}
-			}
+	for _, path := range literals {
+		if err := add(filepath.Clean(path)); err != nil {
+			return nil, stats, err
 		}
 	}
 
 	return allFiles, stats, nil
 }
 
+// lintLiteralPaths joins LiteralPaths and the PathsFrom list, neither glob-expanded
+func lintLiteralPaths(config LintConfig) ([]string, error) {
+	paths := config.LiteralPaths
+	if config.PathsFrom != "" {
+		listed, err := ReadPathList(config.PathsFrom)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths[:len(paths):len(paths)], listed...)
+	}
+	return paths, nil
+}
+
+// ReadPathList reads newline-separated file paths, ignoring blank lines and # comments
+func ReadPathList(path string) ([]string, error) {
+	// #nosec G304 - path comes from trusted configuration
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read path list: %w", err)
+	}
+
+	var paths []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		paths = append(paths, line)
+	}
+	return paths, nil
+}
+
 // scanFile scans a single file for CSS class references
 func scanFile(filePath string) ([]ClassReference, error) {
-	file, err := os.Open(filePath)
+	return scanFileWithPatterns(filePath, patterns)
+}
+
+// scanFileWithPatterns is scanFile with custom line patterns (see buildScanPatterns)
+func scanFileWithPatterns(filePath string, linePatterns []scanPattern) ([]ClassReference, error) {
+	return scanSourceFile(osSource{}, filePath, linePatterns, packageImport{})
+}
+
+// scanSourceFile scans one file opened from src
+// Imports of the generated package (see packageImport) add their alias to linePatterns
+// for the rest of the file
+func scanSourceFile(src fileSource, filePath string, linePatterns []scanPattern, imports packageImport) ([]ClassReference, error) {
+	file, err := src.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -242,13 +853,58 @@ func scanFile(filePath string) ([]ClassReference, error) {
 	var refs []ClassReference
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
+	hinted := false // Previous line was a // cssgen:classes comment
+	var comments commentState
+	var importDecl importTracker
+
+	// Markdown/MDX: skip fenced code, scan JSX className attributes
+	markdown := isMarkdownFile(filePath)
+	var fences fenceState
+	if markdown {
+		linePatterns = markdownPatterns(linePatterns)
+	}
+	linePatterns = patternsForFile(linePatterns, filePath)
+
+	// templ files: remember the component each reference belongs to
+	templ := strings.EqualFold(filepath.Ext(filePath), ".templ")
+	component := ""
 
 	for scanner.Scan() {
 		lineNum++
-		line := scanner.Text()
+		if markdown && fences.skip(scanner.Text()) {
+			continue
+		}
+		line := comments.strip(scanner.Text())
+
+		if spec, ok := importDecl.spec(line); ok {
+			if qualifier := imports.qualifier(spec); qualifier != "" {
+				linePatterns = withQualifier(linePatterns, qualifier)
+			}
+			continue
+		}
 
-		lineRefs := extractClassesFromLine(line, lineNum, filePath)
-		refs = append(refs, lineRefs...)
+		if templ {
+			if match := templComponentPattern.FindStringSubmatch(line); match != nil {
+				component = match[1]
+			} else if templTopLevelPattern.MatchString(line) {
+				component = ""
+			}
+		}
+
+		found := len(refs)
+		if hinted && !strings.Contains(line, classesHint) && strings.Contains(line, "[]string{") {
+			refs = append(refs, extractFromStringSlice(line, lineNum, filePath)...)
+		} else {
+			refs = append(refs, extractClassesWithPatterns(line, lineNum, filePath, linePatterns)...)
+		}
+		for i := found; i < len(refs); i++ {
+			refs[i].Component = component
+		}
+		// A closing brace in column 0 ends the component
+		if templ && strings.TrimRight(line, " \t\r") == "}" {
+			component = ""
+		}
+		hinted = commentPattern.MatchString(line) && strings.Contains(line, classesHint)
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -258,8 +914,75 @@ func scanFile(filePath string) ([]ClassReference, error) {
 	return refs, nil
 }
 
+// templComponentPattern matches a templ component declaration, capturing its name:
+// templ Card(title string) { or templ (c Page) Header() {
+var templComponentPattern = regexp.MustCompile(`^templ\s+(?:\([^)]*\)\s*)?([A-Za-z_]\w*)\s*\(`)
+
+// templTopLevelPattern matches the other top-level declarations of a templ file
+// (css, script, Go code), which are never inside a component
+var templTopLevelPattern = regexp.MustCompile(`^(?:css|script|func|type|var|const|import|package)\b`)
+
+// commentState tracks an open /* */ or {! !} block comment across lines
+type commentState struct {
+	closer string // "*/" or "!}" while inside a block comment
+}
+
+// strip blanks out block comment text on line, keeping columns intact
+// Comment openers inside double-quoted or backtick strings are ignored
+func (s *commentState) strip(line string) string {
+	out := []byte(line)
+	var quote byte
+
+	for i := 0; i < len(line); {
+		if s.closer != "" {
+			if strings.HasPrefix(line[i:], s.closer) {
+				for j := i; j < i+len(s.closer); j++ {
+					out[j] = ' '
+				}
+				i += len(s.closer)
+				s.closer = ""
+				continue
+			}
+			out[i] = ' '
+			i++
+			continue
+		}
+
+		c := line[i]
+		if quote != 0 {
+			if c == '\\' && quote == '"' {
+				i += 2
+				continue
+			}
+			if c == quote {
+				quote = 0
+			}
+			i++
+			continue
+		}
+
+		switch {
+		case c == '"' || c == '`':
+			quote = c
+		case strings.HasPrefix(line[i:], "/*"):
+			s.closer = "*/"
+		case strings.HasPrefix(line[i:], "{!"):
+			s.closer = "!}"
+		}
+		if s.closer != "" {
+			out[i], out[i+1] = ' ', ' '
+			i += 2
+			continue
+		}
+		i++
+	}
+
+	return string(out)
+}
+
 // findClassColumn locates the exact column where className starts within line
-// For multi-class strings like "btn btn--sm", finds the first token
+// For multi-class strings like "btn btn--sm", finds the first token; "fake" is not
+// found in "fake-class"
 func findClassColumn(line string, fullClassString string) int {
 	// For multi-class strings, find the anchor (first token)
 	tokens := strings.Fields(fullClassString)
@@ -272,19 +995,19 @@ func findClassColumn(line string, fullClassString string) int {
 	classAttrIdx := strings.Index(line, "class=")
 	if classAttrIdx != -1 {
 		// Find opening quote
-		quoteIdx := strings.IndexAny(line[classAttrIdx:], `"'`)
+		quoteIdx := strings.IndexAny(line[classAttrIdx:], "\"'`")
 		if quoteIdx != -1 {
 			searchStart := classAttrIdx + quoteIdx + 1
 
 			// Find the search target within the attribute
 			classesStr := line[searchStart:]
-			endQuote := strings.IndexAny(classesStr, `"'`)
+			endQuote := strings.IndexAny(classesStr, "\"'`")
 			if endQuote != -1 {
 				classesStr = classesStr[:endQuote]
 			}
 
 			// Find our target token
-			idx := strings.Index(classesStr, searchTarget)
+			idx := indexClass(classesStr, searchTarget, 0)
 			if idx != -1 {
 				return searchStart + idx + 1 // 1-based column
 			}
@@ -298,8 +1021,8 @@ func findClassColumn(line string, fullClassString string) int {
 		return idx + 2 // +1 for 1-based, +1 to skip quote
 	}
 
-	// Strategy 3: Direct search
-	idx = strings.Index(line, searchTarget)
+	// Strategy 3: Direct search, as a whole token
+	idx = indexClass(line, searchTarget, 0)
 	if idx != -1 {
 		return idx + 1
 	}
@@ -308,8 +1031,105 @@ func findClassColumn(line string, fullClassString string) int {
 	return 0
 }
 
+// classCursor walks the classes of one attribute in order, so each is located after
+// the previous one. Classes match as whole tokens: "fake" is not found in "fake-class"
+type classCursor struct {
+	line   string
+	offset int // Byte offset where the next search starts
+}
+
+// newClassCursor starts a cursor at the 1-based column of the attribute value
+func newClassCursor(line string, column int) *classCursor {
+	offset := column - 1
+	if offset < 0 || offset > len(line) {
+		offset = 0
+	}
+	return &classCursor{line: line, offset: offset}
+}
+
+// next returns the 1-based column of the next whole-token className (0 if not found)
+func (c *classCursor) next(className string) int {
+	start := indexClass(c.line, className, c.offset)
+	if start == -1 {
+		return 0
+	}
+	c.offset = start + len(className)
+	return start + 1
+}
+
+// indexClass returns the byte offset of the first whole-token className in s at or
+// after from (-1 if none)
+func indexClass(s, className string, from int) int {
+	if className == "" {
+		return -1
+	}
+	for from <= len(s)-len(className) {
+		idx := strings.Index(s[from:], className)
+		if idx == -1 {
+			return -1
+		}
+		start := from + idx
+		end := start + len(className)
+		if (start == 0 || isClassBoundary(s[start-1])) && (end == len(s) || isClassBoundary(s[end])) {
+			return start
+		}
+		from = start + 1
+	}
+	return -1
+}
+
+// isClassBoundary reports whether b can separate classes in an attribute value
+func isClassBoundary(b byte) bool {
+	return strings.IndexByte(" \t\"'`", b) >= 0
+}
+
+// mayContainClasses reports whether any pattern's literal (or the classes hint) occurs in line
+func mayContainClasses(line string, linePatterns []scanPattern) bool {
+	if strings.Contains(line, classesHint) {
+		return true
+	}
+	for _, pattern := range linePatterns {
+		if pattern.literal == "" || strings.Contains(line, pattern.literal) {
+			return true
+		}
+	}
+	return false
+}
+
+// stripTrailingComment cuts line at a // comment that follows code
+// The // must be outside quotes and after whitespace, so URLs like https://x survive
+func stripTrailingComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote != '`' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '/' && i+1 < len(line) && line[i+1] == '/' && i > 0 && (line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
 // extractClassesFromLine extracts all CSS class references from a line
 func extractClassesFromLine(line string, lineNum int, file string) []ClassReference {
+	return extractClassesWithPatterns(line, lineNum, file, patterns)
+}
+
+// extractClassesWithPatterns is extractClassesFromLine with custom line patterns
+func extractClassesWithPatterns(line string, lineNum int, file string, linePatterns []scanPattern) []ClassReference {
+	// Most lines reference no classes; skip the regexes unless one could match
+	if !mayContainClasses(line, linePatterns) {
+		return nil
+	}
+
 	// Skip comments
 	if commentPattern.MatchString(line) {
 		return nil
@@ -317,44 +1137,78 @@ func extractClassesFromLine(line string, lineNum int, file string) []ClassRefere
 
 	var refs []ClassReference
 
+	// Annotated class lists: []string{"btn", "btn--primary"} // cssgen:classes
+	if strings.Contains(line, classesHint) && strings.Contains(line, "[]string{") {
+		return extractFromStringSlice(line, lineNum, file)
+	}
+
+	// A trailing // comment is not a usage; match against the code before it but
+	// keep the full line as Text, so cssgen:ignore directives still apply
+	if code := stripTrailingComment(line); code != line {
+		refs := extractClassesWithPatterns(code, lineNum, file, linePatterns)
+		for i := range refs {
+			refs[i].Location.Text = strings.TrimSpace(line)
+			refs[i].LineContent = strings.TrimSpace(line)
+		}
+		return refs
+	}
+
 	// Check if line contains templ.Classes or templ.KV - use specialized handlers
 	hasTemplClasses := strings.Contains(line, "templ.Classes(")
 	hasTemplKV := strings.Contains(line, "templ.KV(")
 
 	if hasTemplClasses {
-		refs = append(refs, extractFromTemplClasses(line, lineNum, file)...)
+		refs = append(refs, extractFromTemplClasses(line, lineNum, file, linePatterns)...)
 	}
 	if hasTemplKV {
-		refs = append(refs, extractFromTemplKV(line, lineNum, file)...)
+		refs = append(refs, extractFromTemplKV(line, lineNum, file, linePatterns)...)
 	}
 
 	// If we handled templ functions, skip standard pattern matching for those
 	// to avoid duplicates
 	if hasTemplClasses || hasTemplKV {
-		// templ functions already handled, don't apply other patterns
-		// to avoid duplicates
+		// Constants next to the calls, as in { ui.Btn, templ.KV(ui.BtnActive, on) },
+		// are still picked up; those inside the calls were handled above
+		refs = append(refs, extractConstantsOutsideTempl(line, lineNum, file, linePatterns)...)
 		return refs
 	}
 
 	// Standard pattern matching for other cases
-	for _, pattern := range patterns {
+	for _, pattern := range linePatterns {
+		if pattern.empty != nil {
+			for _, match := range pattern.empty.FindAllStringIndex(line, -1) {
+				if !continuesAttributeName(line, match[0]) {
+					refs = append(refs, emptyAttributeRef(line, lineNum, file, match[0]))
+				}
+			}
+		}
+
 		matches := pattern.regex.FindAllStringSubmatchIndex(line, -1)
 		for _, match := range matches {
 			if len(match) < 4 {
 				continue
 			}
+			if pattern.attribute && continuesAttributeName(line, match[0]) {
+				continue
+			}
 
-			captured := line[match[2]:match[3]]
+			captured := capturedValue(line, match)
+
+			if pattern.toggle {
+				refs = append(refs, toggleRefs(line, lineNum, file, captured, capturedStart(match))...)
+				continue
+			}
+
+			if pattern.attribute && strings.TrimSpace(captured) == "" {
+				refs = append(refs, emptyAttributeRef(line, lineNum, file, match[0]))
+				continue
+			}
 
 			ref := ClassReference{
-				Location: FileLocation{
-					File:   file,
-					Line:   lineNum,
-					Column: match[0] + 1, // 1-indexed (relative to original line)
-					Text:   strings.TrimSpace(line),
-				},
+				Location:    lineLocation(file, lineNum, match[0], line),
 				LineContent: strings.TrimSpace(line),
 				IsConstant:  pattern.isConst,
+				InStructTag: pattern.structTag,
 			}
 
 			if pattern.isConst {
@@ -372,67 +1226,252 @@ func extractClassesFromLine(line string, lineNum int, file string) []ClassRefere
 	return refs
 }
 
-// extractFromTemplClasses extracts class names from templ.Classes(...) calls
-// Handles: templ.Classes("foo", "bar", ui.Baz, templ.KV(...))
-func extractFromTemplClasses(line string, lineNum int, file string) []ClassReference {
+// emptyAttributeRef builds the reference for an attribute naming no classes,
+// which starts at byte offset start of line
+func emptyAttributeRef(line string, lineNum int, file string, start int) ClassReference {
+	return ClassReference{
+		Location:    lineLocation(file, lineNum, start, line),
+		LineContent: strings.TrimSpace(line),
+		IsEmpty:     true,
+	}
+}
+
+// toggleRefs builds a reference for each class in the toggle expression expr,
+// which starts at byte offset start of line
+func toggleRefs(line string, lineNum int, file string, expr string, start int) []ClassReference {
 	var refs []ClassReference
+	for _, class := range toggleClasses(expr) {
+		refs = append(refs, ClassReference{
+			FullClassValue: class.value,
+			Location:       lineLocation(file, lineNum, start+class.offset, line),
+			LineContent:    strings.TrimSpace(line),
+			InExpression:   true,
+		})
+	}
+	return refs
+}
 
-	matches := templClassesMulti.FindAllStringSubmatchIndex(line, -1)
-	for _, match := range matches {
-		if len(match) < 4 {
+// capturedStart returns the start offset of the first participating capture group
+func capturedStart(match []int) int {
+	for i := 2; i+1 < len(match); i += 2 {
+		if match[i] >= 0 {
+			return match[i]
+		}
+	}
+	return 0
+}
+
+// capturedValue returns the first participating capture group of match
+// Patterns built from quotedValue capture into a different group per quote style
+func capturedValue(line string, match []int) string {
+	for i := 2; i+1 < len(match); i += 2 {
+		if match[i] >= 0 {
+			return line[match[i]:match[i+1]]
+		}
+	}
+	return ""
+}
+
+// extractFromStringSlice extracts each quoted item of a []string{...} literal
+// Only called for lines carrying (or preceded by) the cssgen:classes hint
+func extractFromStringSlice(line string, lineNum int, file string) []ClassReference {
+	start := strings.Index(line, "[]string{")
+	if start == -1 {
+		return nil
+	}
+	start += len("[]string{")
+
+	end := strings.Index(line[start:], "}")
+	if end == -1 {
+		end = len(line) // Literal continues on the next line
+	} else {
+		end += start
+	}
+
+	var refs []ClassReference
+	for _, match := range stringLiteralPattern.FindAllStringSubmatchIndex(line[start:end], -1) {
+		classStr := line[start+match[2] : start+match[3]]
+		if strings.TrimSpace(classStr) == "" {
 			continue
 		}
+		refs = append(refs, ClassReference{
+			Location:       lineLocation(file, lineNum, start+match[2], line),
+			LineContent:    strings.TrimSpace(line),
+			IsConstant:     false,
+			FullClassValue: classStr,
+		})
+	}
+
+	return refs
+}
+
+// extractFromTemplClasses extracts class names from templ.Classes(...) calls
+// Handles: templ.Classes("foo", "bar", ui.Baz, templ.KV(...))
+func extractFromTemplClasses(line string, lineNum int, file string, linePatterns []scanPattern) []ClassReference {
+	var refs []ClassReference
 
+	for _, match := range findTemplCalls(line, templClassesCall) {
 		content := line[match[2]:match[3]]
-		refs = append(refs, parseTemplArguments(content, lineNum, file, line)...)
+		refs = append(refs, parseTemplArguments(content, match[2], lineNum, file, line, linePatterns)...)
 	}
 
 	return refs
 }
 
 // extractFromTemplKV extracts class names from templ.KV(...) calls
-// Handles: templ.KV("foo", condition)
-func extractFromTemplKV(line string, lineNum int, file string) []ClassReference {
+// Handles: templ.KV("foo", condition), templ.KV(ui.Foo, condition)
+func extractFromTemplKV(line string, lineNum int, file string, linePatterns []scanPattern) []ClassReference {
 	var refs []ClassReference
 
-	matches := templKVMulti.FindAllStringSubmatchIndex(line, -1)
-	for _, match := range matches {
-		if len(match) < 4 {
+	// templ.KV nested in templ.Classes is parsed with the Classes arguments
+	classesSpans := findTemplCalls(line, templClassesCall)
+	for _, match := range findTemplCalls(line, templKVCall) {
+		if insideSpan(classesSpans, match[0]) {
 			continue
 		}
+		refs = append(refs, templKVClass(line, match[2], match[3], lineNum, file, linePatterns)...)
+	}
 
-		content := line[match[2]:match[3]]
-		// For KV, only the first argument is the class name
-		parts := splitTemplArgs(content)
-		if len(parts) > 0 {
-			refs = append(refs, parseTemplArguments(parts[0], lineNum, file, line)...)
+	return refs
+}
+
+// templKVClass parses the class argument of a templ.KV call whose arguments are
+// line[argsStart:argsEnd]; only the first argument is the class name
+func templKVClass(line string, argsStart, argsEnd int, lineNum int, file string, linePatterns []scanPattern) []ClassReference {
+	parts := splitTemplArgs(line[argsStart:argsEnd])
+	if len(parts) == 0 {
+		return nil
+	}
+	return parseTemplArguments(parts[0], argsStart, lineNum, file, line, linePatterns)
+}
+
+// Call prefixes handled by the templ helpers
+const (
+	templClassesCall = "templ.Classes("
+	templKVCall      = "templ.KV("
+)
+
+// findTemplCalls locates each call on line starting with prefix (e.g. "templ.KV(")
+// whose closing parenthesis is on the same line. Each match is
+// {callStart, callEnd, argsStart, argsEnd}, like FindAllStringSubmatchIndex;
+// nested calls and quoted parentheses are skipped when finding the closing one
+func findTemplCalls(line, prefix string) [][]int {
+	var matches [][]int
+	for offset := 0; ; {
+		idx := strings.Index(line[offset:], prefix)
+		if idx == -1 {
+			return matches
+		}
+		start := offset + idx
+		argsStart := start + len(prefix)
+		offset = argsStart
+
+		if argsEnd := closingParen(line, argsStart); argsEnd > argsStart {
+			matches = append(matches, []int{start, argsEnd + 1, argsStart, argsEnd})
+		}
+	}
+}
+
+// closingParen returns the index of the ) closing the call whose arguments start
+// at line[from], or -1 when it isn't on this line
+func closingParen(line string, from int) int {
+	depth := 0
+	var quote byte // Open string delimiter, 0 outside strings
+	for i := from; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+// extractConstantsOutsideTempl returns the constant references on line that are
+// not inside a templ.Classes(...) or templ.KV(...) call
+func extractConstantsOutsideTempl(line string, lineNum int, file string, linePatterns []scanPattern) []ClassReference {
+	var spans [][]int
+	spans = append(spans, findTemplCalls(line, templClassesCall)...)
+	spans = append(spans, findTemplCalls(line, templKVCall)...)
+
+	var refs []ClassReference
+	for _, pattern := range linePatterns {
+		if !pattern.isConst {
+			continue
+		}
+		for _, match := range pattern.regex.FindAllStringSubmatchIndex(line, -1) {
+			if len(match) < 4 || insideSpan(spans, match[0]) {
+				continue
+			}
+			refs = append(refs, ClassReference{
+				Location:    lineLocation(file, lineNum, match[0], line),
+				LineContent: strings.TrimSpace(line),
+				IsConstant:  true,
+				ConstName:   capturedValue(line, match),
+			})
 		}
 	}
 
 	return refs
 }
 
+// insideSpan reports whether offset falls within any [start, end) span
+func insideSpan(spans [][]int, offset int) bool {
+	for _, span := range spans {
+		if offset >= span[0] && offset < span[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// qualifiedConstant returns Foo for an argument like ui.Foo, using the qualifiers of linePatterns
+func qualifiedConstant(arg string, linePatterns []scanPattern) (string, bool) {
+	for _, pattern := range linePatterns {
+		if pattern.isConst && strings.HasPrefix(arg, pattern.qualifier+".") {
+			return strings.TrimPrefix(arg, pattern.qualifier+"."), true
+		}
+	}
+	return "", false
+}
+
 // parseTemplArguments parses arguments inside templ functions
-// Handles: "foo", ui.Bar, "baz qux"
-func parseTemplArguments(args string, lineNum int, file string, fullLine string) []ClassReference {
+// Handles: "foo", ui.Bar, "baz qux" (constants use the qualifiers of linePatterns)
+// and a nested templ.KV("active", cond), whose class argument is parsed in turn
+// argsStart is the byte offset of args within fullLine; columns are resolved
+// with a running cursor so repeated or overlapping tokens get their own position
+func parseTemplArguments(args string, argsStart int, lineNum int, file string, fullLine string, linePatterns []scanPattern) []ClassReference {
 	var refs []ClassReference
 
-	// Split by commas (simple approach - doesn't handle nested parens)
+	// Split by top-level commas
 	parts := splitTemplArgs(args)
 
+	cursor := argsStart
 	for _, part := range parts {
+		partStart := cursor
+		cursor += len(part) + 1 // skip past the separating comma
 		part = strings.TrimSpace(part)
+		if idx := strings.Index(fullLine[partStart:], part); idx >= 0 {
+			partStart += idx
+		}
 
 		// Check if it's a ui constant
-		if strings.HasPrefix(part, "ui.") {
-			constName := strings.TrimPrefix(part, "ui.")
+		if constName, ok := qualifiedConstant(part, linePatterns); ok {
 			refs = append(refs, ClassReference{
-				Location: FileLocation{
-					File:   file,
-					Line:   lineNum,
-					Column: strings.Index(fullLine, part) + 1,
-					Text:   strings.TrimSpace(fullLine),
-				},
+				Location:    lineLocation(file, lineNum, partStart, fullLine),
 				LineContent: strings.TrimSpace(fullLine),
 				IsConstant:  true,
 				ConstName:   constName,
@@ -440,17 +1479,19 @@ func parseTemplArguments(args string, lineNum int, file string, fullLine string)
 			continue
 		}
 
-		// Check if it's a string literal
-		if strings.HasPrefix(part, `"`) && strings.HasSuffix(part, `"`) {
-			classStr := strings.Trim(part, `"`)
+		// Nested templ.KV(...): the class is its first argument
+		if strings.HasPrefix(part, templKVCall) && strings.HasSuffix(part, ")") {
+			argsStart := partStart + len(templKVCall)
+			refs = append(refs, templKVClass(fullLine, argsStart, partStart+len(part)-1, lineNum, file, linePatterns)...)
+			continue
+		}
+
+		// Check if it's a string literal (interpreted or raw)
+		if isQuotedArgument(part) {
+			classStr := part[1 : len(part)-1]
 			// Store full class value instead of splitting
 			refs = append(refs, ClassReference{
-				Location: FileLocation{
-					File:   file,
-					Line:   lineNum,
-					Column: strings.Index(fullLine, classStr) + 1,
-					Text:   strings.TrimSpace(fullLine),
-				},
+				Location:       lineLocation(file, lineNum, partStart+1, fullLine), // skip the opening quote
 				LineContent:    strings.TrimSpace(fullLine),
 				IsConstant:     false,
 				FullClassValue: classStr,
@@ -461,8 +1502,17 @@ func parseTemplArguments(args string, lineNum int, file string, fullLine string)
 	return refs
 }
 
+// isQuotedArgument reports whether part is a "..." or `...` string literal
+func isQuotedArgument(part string) bool {
+	if len(part) < 2 {
+		return false
+	}
+	quote := part[0]
+	return (quote == '"' || quote == '`') && part[len(part)-1] == quote
+}
+
 // splitTemplArgs splits comma-separated arguments
-// Simple splitter - doesn't handle nested function calls
+// Commas inside nested calls, e.g. templ.KV("a", on), don't split
 func splitTemplArgs(s string) []string {
 	var parts []string
 	var current strings.Builder
@@ -1,7 +1,13 @@
 package cssgen
 
 import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/require"
 )
@@ -137,12 +143,12 @@ func TestShouldSkipFile(t *testing.T) {
 }
 
 // Integration test: Verify filtering works end-to-end
-func TestExpandGlobPatternsFiltersGeneratedFiles(t *testing.T) {
+func TestCollectScanFilesFiltersGeneratedFiles(t *testing.T) {
 	// This test requires actual .templ and _templ.go files to exist
 	// It validates that the filtering actually works in practice
 
 	patterns := []string{"internal/web/features/**/*.go"}
-	files, err := expandGlobPatterns(patterns)
+	files, _, err := collectScanFiles(patterns, nil, scanOptions{})
 	require.NoError(t, err)
 
 	// Verify no _templ.go files in results
@@ -151,3 +157,711 @@ func TestExpandGlobPatternsFiltersGeneratedFiles(t *testing.T) {
 			"Found generated file in results: %s", file)
 	}
 }
+
+func TestPrintSkippedFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "legacy"), 0755))
+	for _, name := range []string{"page.templ", "page_templ.go", filepath.Join("legacy", "old.templ")} {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte("package test"), 0644))
+	}
+
+	tests := []struct {
+		name    string
+		exclude []string
+		want    []string // Lines of the skip report
+	}{
+		{
+			name: "templ-generated",
+			want: []string{"  skipped " + filepath.Join(tmpDir, "page_templ.go") + " (templ-generated)"},
+		},
+		{
+			name:    "excluded",
+			exclude: []string{filepath.Join(tmpDir, "legacy", "**")},
+			want: []string{
+				"  skipped " + filepath.Join(tmpDir, "page_templ.go") + " (templ-generated)",
+				"  skipped " + filepath.Join(tmpDir, "legacy", "old.templ") + " (excluded)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files, stats, err := collectScanFiles([]string{filepath.Join(tmpDir, "**", "*")}, nil, scanOptions{exclude: tt.exclude})
+			require.NoError(t, err)
+			require.Len(t, files, 3-len(tt.want))
+			require.Equal(t, len(tt.want), stats.FilesSkipped)
+
+			var buf bytes.Buffer
+			PrintSkippedFiles(&buf, stats)
+			require.Equal(t, strings.Join(tt.want, "\n")+"\n", buf.String())
+		})
+	}
+
+	_, _, err := collectScanFiles([]string{filepath.Join(tmpDir, "*")}, nil, scanOptions{exclude: []string{"[unclosed"}})
+	require.ErrorContains(t, err, "invalid exclude pattern")
+}
+
+func TestExtractClassesFromAnnotatedStringSlice(t *testing.T) {
+	line := `	classes := []string{"btn", "btn--primary"} // cssgen:classes`
+	refs := extractClassesFromLine(line, 7, "button.go")
+	require.Len(t, refs, 2)
+	require.Equal(t, "btn", refs[0].FullClassValue)
+	require.Equal(t, "btn--primary", refs[1].FullClassValue)
+	require.Equal(t, strings.Index(line, "btn--primary")+1, refs[1].Location.Column)
+	require.False(t, refs[0].IsConstant)
+
+	// Unannotated slices are ignored
+	require.Empty(t, extractClassesFromLine(`	names := []string{"alice", "bob"}`, 1, "users.go"))
+}
+
+func TestExtractClassesIgnoresTrailingComment(t *testing.T) {
+	require.Empty(t, extractClassesFromLine(`	x := 1 // see ui.Btn`, 3, "button.go"))
+
+	// Code before the comment still counts, with the comment kept as context
+	line := `	<div class={ ui.Card }> // cssgen:ignore hardcoded-class`
+	refs := extractClassesFromLine(line, 4, "card.templ")
+	require.Len(t, refs, 1)
+	require.Equal(t, "Card", refs[0].ConstName)
+	require.Equal(t, strings.TrimSpace(line), refs[0].Location.Text)
+
+	// A // inside a string or URL is not a comment
+	require.Equal(t, `<a href="https://x.dev" class="btn">`, stripTrailingComment(`<a href="https://x.dev" class="btn">`))
+	require.Equal(t, "x := 1 ", stripTrailingComment("x := 1 // see ui.Btn"))
+}
+
+func TestScanFileAnnotatedStringSliceOnPreviousLine(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "button.go")
+	require.NoError(t, os.WriteFile(file, []byte(`package ui
+
+// cssgen:classes
+var variants = []string{"btn", "btn--ghost"}
+var names = []string{"alice"}
+`), 0644))
+
+	refs, err := scanFile(file)
+	require.NoError(t, err)
+	require.Len(t, refs, 2)
+	require.Equal(t, "btn--ghost", refs[1].FullClassValue)
+	require.Equal(t, 4, refs[1].Location.Line)
+}
+
+func TestScanFileSkipsBlockComments(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "page.templ")
+	require.NoError(t, os.WriteFile(file, []byte(`package test
+
+templ Page() {
+	/* <div class="old-block"></div>
+	   <div class="old-multiline"></div> */
+	{! <span class="old-templ"></span> !}
+	<p class="kept"></p> /* <i class="old-inline"></i> */
+	<a href="/docs/*" class="kept-after-glob"></a>
+}
+`), 0644))
+
+	refs, err := scanFile(file)
+	require.NoError(t, err)
+
+	var classes []string
+	for _, ref := range refs {
+		classes = append(classes, ref.FullClassValue)
+	}
+	require.Equal(t, []string{"kept", "kept-after-glob"}, classes)
+
+	// Blanking keeps columns aligned with the original line
+	plain := extractClassesFromLine(`	<p class="kept"></p>`, 7, file)
+	require.Equal(t, plain[0].Location.Column, refs[0].Location.Column)
+}
+
+func TestScanFileTemplComponents(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "page.templ")
+	require.NoError(t, os.WriteFile(file, []byte(`package test
+
+templ Card() {
+	<div class="card"></div>
+}
+<span class="after-card"></span>
+
+var shared = templ.Classes("shared")
+
+css highlight() {
+	color: red;
+}
+
+func helper() templ.CSSClasses {
+	return templ.Classes("helper")
+}
+
+templ (p Page) Header() {
+	<h1 class="title"></h1>
+}
+`), 0644))
+
+	refs, err := scanFile(file)
+	require.NoError(t, err)
+
+	components := make(map[string]string)
+	for _, ref := range refs {
+		components[ref.FullClassValue] = ref.Component
+	}
+	require.Equal(t, map[string]string{
+		"card":       "Card",
+		"after-card": "",
+		"shared":     "",
+		"helper":     "",
+		"title":      "Header",
+	}, components)
+}
+
+func TestScanFilesFromPathList(t *testing.T) {
+	tmpDir := t.TempDir()
+	a := filepath.Join(tmpDir, "a.templ")
+	b := filepath.Join(tmpDir, "nested", "b.templ")
+	generated := filepath.Join(tmpDir, "page_templ.go")
+	require.NoError(t, os.MkdirAll(filepath.Dir(b), 0755))
+	require.NoError(t, os.WriteFile(a, []byte(`<div class="from-a"></div>`), 0644))
+	require.NoError(t, os.WriteFile(b, []byte(`<div class="from-b"></div>`), 0644))
+	require.NoError(t, os.WriteFile(generated, []byte(`package test`), 0644))
+
+	list := filepath.Join(tmpDir, "paths.txt")
+	require.NoError(t, os.WriteFile(list, []byte("# produced by another tool\n"+a+"\n\n"+b+"\n"+generated+"\n"), 0644))
+
+	paths, err := ReadPathList(list)
+	require.NoError(t, err)
+	require.Equal(t, []string{a, b, generated}, paths)
+
+	refs, stats, err := scanFiles(nil, paths, scanOptions{}, false)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.FilesScanned)
+	require.Equal(t, 1, stats.FilesSkipped, "skip filtering still applies")
+
+	var classes []string
+	for _, ref := range refs {
+		classes = append(classes, ref.FullClassValue)
+	}
+	require.ElementsMatch(t, []string{"from-a", "from-b"}, classes)
+}
+
+func TestParseTemplArgumentsColumnsWithSubstringClasses(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		classes []string
+		columns []int
+	}{
+		{
+			name:    "prefix class first",
+			line:    `<div class={ templ.Classes("btn", "btn--x") }>`,
+			classes: []string{"btn", "btn--x"},
+			columns: []int{29, 36},
+		},
+		{
+			name:    "prefix class last",
+			line:    `<div class={ templ.Classes("btn--x", "btn") }>`,
+			classes: []string{"btn--x", "btn"},
+			columns: []int{29, 39},
+		},
+		{
+			name:    "repeated constant",
+			line:    `<div class={ templ.Classes(ui.Btn, "x", ui.Btn) }>`,
+			classes: []string{"", "x", ""},
+			columns: []int{28, 37, 41},
+		},
+		{
+			name:    "nested templ.KV",
+			line:    `<div class={ templ.Classes(ui.Btn, templ.KV("active", isOn(x)), "card") }>`,
+			classes: []string{"", "active", "card"},
+			columns: []int{28, 46, 66},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := extractFromTemplClasses(tt.line, 1, "page.templ", patterns)
+			require.Len(t, refs, len(tt.columns))
+			for i, ref := range refs {
+				require.Equal(t, tt.classes[i], ref.FullClassValue)
+				require.Equal(t, tt.columns[i], ref.Location.Column, "argument %d", i)
+			}
+		})
+	}
+}
+
+func TestExtractClassesFromLineQuoteStyles(t *testing.T) {
+	tests := []struct {
+		name  string
+		line  string
+		value string
+	}{
+		{
+			name:  "single-quoted attribute",
+			line:  `<button class='btn btn--sm'>Save</button>`,
+			value: "btn btn--sm",
+		},
+		{
+			name:  "backtick class expression",
+			line:  "<div class={ `card card--flat` }></div>",
+			value: "card card--flat",
+		},
+		{
+			name:  "backtick templ.Classes argument",
+			line:  "<div class={ templ.Classes(`badge`) }></div>",
+			value: "badge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := extractClassesFromLine(tt.line, 3, "page.templ")
+			require.Len(t, refs, 1)
+			require.Equal(t, tt.value, refs[0].FullClassValue)
+			require.False(t, refs[0].IsConstant)
+			require.Equal(t, strings.Index(tt.line, tt.value)+1, findClassColumn(tt.line, tt.value))
+		})
+	}
+}
+
+func TestExtractClassesFromLineEmptyAttribute(t *testing.T) {
+	for _, line := range []string{
+		`<div class=""></div>`,
+		`<div class="   "></div>`,
+		`<div class={ "" }></div>`,
+	} {
+		t.Run(line, func(t *testing.T) {
+			refs := extractClassesFromLine(line, 1, "page.templ")
+			require.Len(t, refs, 1)
+			require.True(t, refs[0].IsEmpty)
+			require.Equal(t, 6, refs[0].Location.Column)
+		})
+	}
+
+	// data-class="" is a different attribute
+	require.Empty(t, extractClassesFromLine(`<div data-class=""></div>`, 1, "page.templ"))
+}
+
+func TestScanFilesConfiguredAttributes(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "page.templ")
+	require.NoError(t, os.WriteFile(file, []byte(`package test
+
+templ Page() {
+	<div data-class="btn"></div>
+	<div class="card"></div>
+}
+`), 0644))
+
+	valuesOf := func(refs []ClassReference) []string {
+		var values []string
+		for _, ref := range refs {
+			values = append(values, ref.FullClassValue)
+		}
+		return values
+	}
+
+	// Default: only class=, and data-class= is not mistaken for it
+	refs, _, err := scanFiles([]string{file}, nil, scanOptions{}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"card"}, valuesOf(refs))
+
+	refs, _, err = scanFiles([]string{file}, nil, scanOptions{attributes: []string{"class", "data-class"}}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"btn", "card"}, valuesOf(refs))
+	require.Equal(t, 4, refs[0].Location.Line)
+	require.Equal(t, strings.Index(`	<div data-class="btn"></div>`, "data-class")+1, refs[0].Location.Column)
+}
+
+func TestMayContainClassesFastPath(t *testing.T) {
+	// Without literals every line takes the regex path, so results must match
+	unfiltered := make([]scanPattern, len(patterns))
+	copy(unfiltered, patterns)
+	for i := range unfiltered {
+		unfiltered[i].literal = ""
+	}
+
+	lines := []string{
+		`<button class="btn btn--primary">Save</button>`,
+		`<div class={ ui.Card }></div>`,
+		`<div class={ templ.Classes("badge", ui.BadgeSm) }></div>`,
+		`<div class={ templ.KV("active", isActive) }></div>`,
+		`	return ds.Class("panel")`,
+		`	names := []string{"btn", "btn--sm"} // cssgen:classes`,
+		`	// class="ignored"`,
+		`	count := len(items)`,
+		`<p>Hello, world</p>`,
+	}
+
+	for _, line := range lines {
+		require.Equal(t,
+			extractClassesWithPatterns(line, 1, "page.templ", unfiltered),
+			extractClassesFromLine(line, 1, "page.templ"),
+			line)
+	}
+
+	require.False(t, mayContainClasses(`	count := len(items)`, patterns))
+	require.True(t, mayContainClasses(`<div data-class="x"></div>`, buildScanPatterns([]string{"data-class"}, "")))
+}
+
+func BenchmarkExtractClassesFromLine(b *testing.B) {
+	benchmarks := []struct {
+		name string
+		line string
+	}{
+		{name: "no classes", line: `	for i, item := range items { total += item.Price * float64(i) }`},
+		{name: "class attribute", line: `<button class="btn btn--primary btn--sm">Save</button>`},
+		{name: "constant", line: `<div class={ ui.Card, ui.CardHeader }></div>`},
+	}
+
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				extractClassesFromLine(bm.line, 1, "page.templ")
+			}
+		})
+	}
+}
+
+func TestScanFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/button.templ": {Data: []byte(`package ui
+
+templ Button() {
+	<button class="btn btn--primary">Save</button>
+}
+`)},
+		"templates/card/card.templ": {Data: []byte(`package ui
+
+templ Card() {
+	<div class={ ui.Card }></div>
+}
+`)},
+		"templates/card/card_templ.go": {Data: []byte(`package ui
+
+var _ = "class=\"generated\""
+`)},
+	}
+
+	refs, stats, err := ScanFS(fsys, []string{"templates/**/*.templ", "templates/**/*.go"})
+	require.NoError(t, err)
+
+	require.Equal(t, 2, stats.FilesScanned)
+	require.Equal(t, 1, stats.FilesSkipped)
+	require.Len(t, refs, 2)
+
+	require.Equal(t, "templates/button.templ", refs[0].Location.File)
+	require.Equal(t, 4, refs[0].Location.Line)
+	require.Equal(t, "btn btn--primary", refs[0].FullClassValue)
+
+	require.Equal(t, "templates/card/card.templ", refs[1].Location.File)
+	require.True(t, refs[1].IsConstant)
+	require.Equal(t, "Card", refs[1].ConstName)
+}
+
+func TestExtractTemplKVConstant(t *testing.T) {
+	tests := []struct {
+		name   string
+		line   string
+		consts []string
+	}{
+		{
+			name:   "constant first argument",
+			line:   `<div class={ templ.KV(ui.BtnActive, isActive) }></div>`,
+			consts: []string{"BtnActive"},
+		},
+		{
+			name:   "constant next to KV",
+			line:   `<button class={ ui.Btn, templ.KV(ui.BtnActive, isActive) }></button>`,
+			consts: []string{"BtnActive", "Btn"},
+		},
+		{
+			name:   "KV inside Classes",
+			line:   `<div class={ templ.Classes(ui.Card, templ.KV(ui.CardActive, on)) }></div>`,
+			consts: []string{"Card", "CardActive"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs := extractClassesFromLine(tt.line, 1, "page.templ")
+
+			var consts []string
+			for _, ref := range refs {
+				require.True(t, ref.IsConstant)
+				consts = append(consts, ref.ConstName)
+				require.Equal(t, "ui."+ref.ConstName, tt.line[ref.Location.Column-1:ref.Location.Column-1+len("ui.")+len(ref.ConstName)])
+			}
+			require.Equal(t, tt.consts, consts)
+		})
+	}
+}
+
+func TestExpandBraces(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		expected []string
+	}{
+		{"web/**/*.templ", []string{"web/**/*.templ"}},
+		{"web/**/*.{templ,go}", []string{"web/**/*.templ", "web/**/*.go"}},
+		{"{a,b}/*.{templ,go}", []string{"a/*.templ", "a/*.go", "b/*.templ", "b/*.go"}},
+		{"*.{go,{templ,html}}", []string{"*.go", "*.templ", "*.html"}},
+		{"*.{templ", []string{"*.{templ"}},
+		{`*.\{templ,go}`, []string{`*.\{templ,go}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			require.Equal(t, tt.expected, expandBraces([]string{tt.pattern}))
+		})
+	}
+}
+
+func TestCollectScanFilesBraces(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"page.templ", "handler.go", "style.css"} {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte("package test"), 0644))
+	}
+
+	files, stats, err := collectScanFiles([]string{filepath.Join(tmpDir, "*.{templ,go}")}, nil, scanOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.FilesScanned)
+	require.ElementsMatch(t, []string{
+		filepath.Join(tmpDir, "page.templ"),
+		filepath.Join(tmpDir, "handler.go"),
+	}, files)
+}
+
+func TestScanFilesStructTags(t *testing.T) {
+	dir := t.TempDir()
+	goFile := filepath.Join(dir, "button.go")
+	require.NoError(t, os.WriteFile(goFile, []byte("package ui\n\n"+
+		"type ButtonProps struct {\n"+
+		"\tVariant string `json:\"variant\" class:\"btn btn--primary\"`\n"+
+		"\tSize    string `data-class:\"ignored\"`\n"+
+		"}\n"), 0644))
+	templFile := filepath.Join(dir, "page.templ")
+	require.NoError(t, os.WriteFile(templFile, []byte("templ Page() {\n"+
+		"\t<p class=\"card\">class:\"not-a-tag\"</p>\n"+
+		"}\n"), 0644))
+
+	values := func(refs []ClassReference) []string {
+		var out []string
+		for _, ref := range refs {
+			out = append(out, filepath.Base(ref.Location.File)+":"+ref.FullClassValue)
+		}
+		return out
+	}
+
+	refs, _, err := scanFiles([]string{goFile, templFile}, nil, scanOptions{structTags: true}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{"button.go:btn btn--primary", "page.templ:card"}, values(refs))
+	require.Equal(t, 4, refs[0].Location.Line)
+
+	// Opt-in: without the option struct tags are not class references
+	refs, _, err = scanFiles([]string{goFile}, nil, scanOptions{}, false)
+	require.NoError(t, err)
+	require.Empty(t, refs)
+}
+
+func TestScanFilesDataKeys(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "buttons.yaml")
+	require.NoError(t, os.WriteFile(yamlFile, []byte(`buttons:
+  - label: Save
+    class: btn btn--primary   # main action
+  - label: Cancel
+    class: "btn btn--ghost"
+  # class: btn--old
+  link: {href: /home, class: 'nav-link'}
+  subclass: not-scanned
+`), 0644))
+	jsonFile := filepath.Join(dir, "card.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"title": "Card", "class": "card card--raised"}`+"\n"), 0644))
+
+	values := func(refs []ClassReference) []string {
+		var out []string
+		for _, ref := range refs {
+			out = append(out, fmt.Sprintf("%s:%d:%s", filepath.Base(ref.Location.File), ref.Location.Line, ref.FullClassValue))
+		}
+		return out
+	}
+
+	refs, _, err := scanFiles([]string{yamlFile, jsonFile}, nil, scanOptions{dataKeys: []string{"class"}}, false)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"buttons.yaml:3:btn btn--primary",
+		"buttons.yaml:5:btn btn--ghost",
+		"buttons.yaml:7:nav-link",
+		"card.json:1:card card--raised",
+	}, values(refs))
+
+	// Opt-in: without data keys the files yield nothing
+	refs, _, err = scanFiles([]string{yamlFile, jsonFile}, nil, scanOptions{}, false)
+	require.NoError(t, err)
+	require.Empty(t, refs)
+}
+
+func TestScanFilesToggleAttributes(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "menu.html")
+	require.NoError(t, os.WriteFile(file, []byte(`<div :class="{ 'menu--open': open, active: current === 'home' }"></div>
+<button x-bind:class="{ 'btn btn--primary': primary, 'btn--sm': small }">Go</button>
+<span :class="busy ? 'spinner' : ''" data-x:class="{ 'ignored': true }"></span>
+<p :class="`+"`text-${size}`"+`"></p>
+`), 0644))
+
+	refs, _, err := scanFiles([]string{file}, nil, scanOptions{toggles: []string{":class", "x-bind:class"}}, false)
+	require.NoError(t, err)
+
+	var got []string
+	for _, ref := range refs {
+		require.True(t, ref.InExpression)
+		require.Equal(t, ref.FullClassValue, ref.Location.Text[ref.Location.Column-1:][:len(ref.FullClassValue)])
+		got = append(got, fmt.Sprintf("%d:%s", ref.Location.Line, ref.FullClassValue))
+	}
+	require.Equal(t, []string{
+		"1:menu--open",
+		"1:active",
+		"2:btn btn--primary",
+		"2:btn--sm",
+		"3:spinner",
+	}, got)
+
+	// Opt-in: without toggle attributes the expressions are not scanned
+	refs, _, err = scanFiles([]string{file}, nil, scanOptions{}, false)
+	require.NoError(t, err)
+	require.Empty(t, refs)
+}
+
+func TestScanFilesPackageAlias(t *testing.T) {
+	dir := t.TempDir()
+
+	aliased := filepath.Join(dir, "aliased.templ")
+	require.NoError(t, os.WriteFile(aliased, []byte(`package pages
+
+import (
+	"fmt"
+	css "myapp/web/ui"
+)
+
+templ Page() {
+	<button class={ css.Btn }>Go</button>
+	<a class={ templ.Classes(css.BtnBrand, "extra") }></a>
+}
+`), 0644))
+
+	other := filepath.Join(dir, "other.templ")
+	require.NoError(t, os.WriteFile(other, []byte(`package pages
+
+import css "myapp/web/other"
+
+templ Other() {
+	<button class={ css.Btn }>Go</button>
+}
+`), 0644))
+
+	constNames := func(refs []ClassReference) []string {
+		var names []string
+		for _, ref := range refs {
+			if ref.IsConstant {
+				names = append(names, filepath.Base(ref.Location.File)+":"+ref.ConstName)
+			}
+		}
+		return names
+	}
+
+	t.Run("alias detected from import", func(t *testing.T) {
+		refs, _, err := scanFiles([]string{aliased, other}, nil, scanOptions{
+			imports: packageImport{path: "myapp/web/ui", name: "ui"},
+		}, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"aliased.templ:Btn", "aliased.templ:BtnBrand"}, constNames(refs))
+	})
+
+	t.Run("alias detected by package name", func(t *testing.T) {
+		refs, _, err := scanFiles([]string{aliased}, nil, scanOptions{
+			imports: packageImport{name: "ui"},
+		}, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"aliased.templ:Btn", "aliased.templ:BtnBrand"}, constNames(refs))
+	})
+
+	t.Run("configured qualifier", func(t *testing.T) {
+		refs, _, err := scanFiles([]string{other}, nil, scanOptions{qualifier: "css"}, false)
+		require.NoError(t, err)
+		require.Equal(t, []string{"other.templ:Btn"}, constNames(refs))
+	})
+
+	t.Run("unrelated alias ignored", func(t *testing.T) {
+		refs, _, err := scanFiles([]string{other}, nil, scanOptions{
+			imports: packageImport{path: "myapp/web/ui", name: "ui"},
+		}, false)
+		require.NoError(t, err)
+		require.Empty(t, constNames(refs))
+	})
+}
+
+func TestPackageImportQualifier(t *testing.T) {
+	imp := packageImport{path: "myapp/web/ui", name: "ui"}
+
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{`css "myapp/web/ui"`, "css"},
+		{`"myapp/web/ui"`, "ui"},
+		{`_ "myapp/web/ui"`, ""},
+		{`. "myapp/web/ui"`, ""},
+		{`css "myapp/web/other"`, ""},
+		{`"fmt"`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			require.Equal(t, tt.expected, imp.qualifier(tt.spec))
+		})
+	}
+}
+
+func TestScanMarkdownFences(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "button.mdx")
+	content := "# Button\n\n" +
+		"<Button className=\"btn btn--primary\">Save</Button>\n\n" +
+		"```html\n<button class=\"btn--example\">Example</button>\n```\n\n" +
+		"~~~jsx\n<Button className=\"btn--tilde\" />\n~~~\n\n" +
+		"<div class=\"card\"></div>\n"
+	require.NoError(t, os.WriteFile(file, []byte(content), 0644))
+
+	refs, err := scanFile(file)
+	require.NoError(t, err)
+
+	var values []string
+	for _, ref := range refs {
+		values = append(values, ref.FullClassValue)
+	}
+	require.Equal(t, []string{"btn btn--primary", "card"}, values)
+	require.Equal(t, 3, refs[0].Location.Line)
+	require.Equal(t, 13, refs[1].Location.Line)
+}
+
+func TestScanFilesMaxFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"a.templ", "b.templ", "c.templ"} {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(`<div class="btn"></div>`), 0644))
+	}
+	pattern := []string{filepath.Join(tmpDir, "*.templ")}
+
+	// Matching stops at the first file over the limit
+	_, stats, err := scanFiles(pattern, nil, scanOptions{maxFiles: 1}, false)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "more than max-files (1)")
+	require.Contains(t, err.Error(), "tighten the patterns")
+	require.Equal(t, 2, stats.FilesScanned)
+
+	// Rename expands its paths the same way
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	require.NoError(t, os.WriteFile(generatedFile, []byte("package ui\n\nconst Btn = \"btn\"\n"), 0644))
+	_, err = Rename(RenameConfig{ScanPaths: pattern, GeneratedFile: generatedFile, OldClass: "btn", NewClass: "button", MaxFiles: 2})
+	require.ErrorContains(t, err, "more than max-files (2)")
+
+	refs, _, err := scanFiles(pattern, nil, scanOptions{maxFiles: 3}, false)
+	require.NoError(t, err)
+	require.Len(t, refs, 3)
+}
@@ -0,0 +1,194 @@
+package cssgen
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxSuggestionDistance is the largest edit distance offered as "did you mean"
+const maxSuggestionDistance = 2
+
+// classIndex finds the stylesheet class closest to a typo without comparing
+// against every class: candidates must share enough trigrams and be of similar length
+type classIndex struct {
+	classes  []string         // Sorted, so ties resolve to the alphabetically first class
+	byLength map[int][]int32  // len(class) -> class ids
+	trigrams map[string][]int // trigram -> class ids, ascending
+}
+
+// newClassIndex indexes every class in allCSSClasses
+func newClassIndex(allCSSClasses map[string]bool) *classIndex {
+	classes := make([]string, 0, len(allCSSClasses))
+	for class := range allCSSClasses {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+
+	idx := &classIndex{
+		classes:  classes,
+		byLength: make(map[int][]int32),
+		trigrams: make(map[string][]int),
+	}
+	for id, class := range classes {
+		idx.byLength[len(class)] = append(idx.byLength[len(class)], int32(id))
+		for _, gram := range distinctTrigrams(class) {
+			idx.trigrams[gram] = append(idx.trigrams[gram], id)
+		}
+	}
+	return idx
+}
+
+// nearest returns the closest class within maxSuggestionDistance ("" if none)
+// Ties go to the alphabetically first class
+func (idx *classIndex) nearest(name string) string {
+	if idx == nil || len(idx.classes) == 0 {
+		return ""
+	}
+
+	best, bestDist := -1, maxSuggestionDistance
+	idx.candidates(name, func(id int) {
+		// Distances up to bestDist come back exact; anything further is capped
+		dist := boundedLevenshtein(name, idx.classes[id], bestDist+1)
+		if !acceptSuggestion(name, dist) || dist > bestDist {
+			return
+		}
+		if best == -1 || dist < bestDist || id < best {
+			best, bestDist = id, dist
+		}
+	})
+
+	if best == -1 {
+		return ""
+	}
+	return idx.classes[best]
+}
+
+// nearestN returns up to n classes within maxSuggestionDistance, closest first
+// Equally close classes are ordered alphabetically
+func (idx *classIndex) nearestN(name string, n int) []string {
+	if n <= 1 {
+		if near := idx.nearest(name); near != "" {
+			return []string{near}
+		}
+		return nil
+	}
+	if idx == nil || len(idx.classes) == 0 {
+		return nil
+	}
+
+	type match struct{ id, dist int }
+	var matches []match
+	idx.candidates(name, func(id int) {
+		if dist := boundedLevenshtein(name, idx.classes[id], maxSuggestionDistance+1); acceptSuggestion(name, dist) {
+			matches = append(matches, match{id, dist})
+		}
+	})
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].dist != matches[j].dist {
+			return matches[i].dist < matches[j].dist
+		}
+		return matches[i].id < matches[j].id
+	})
+
+	var names []string
+	for i := 0; i < len(matches) && i < n; i++ {
+		names = append(names, idx.classes[matches[i].id])
+	}
+	return names
+}
+
+// candidates calls consider with the id of every class that may be within
+// maxSuggestionDistance of name
+func (idx *classIndex) candidates(name string, consider func(id int)) {
+	// Each edit changes at most 3 trigrams, so a match within k edits shares
+	// at least len(grams) - 3k of them. Short names fall back to a length scan.
+	grams := distinctTrigrams(name)
+	required := len(grams) - 3*maxSuggestionDistance
+	if required <= 0 {
+		for length := len(name) - maxSuggestionDistance; length <= len(name)+maxSuggestionDistance; length++ {
+			for _, id := range idx.byLength[length] {
+				consider(int(id))
+			}
+		}
+	} else {
+		shared := make(map[int]int)
+		for _, gram := range grams {
+			for _, id := range idx.trigrams[gram] {
+				shared[id]++
+			}
+		}
+		for id, count := range shared {
+			diff := len(idx.classes[id]) - len(name)
+			if count >= required && diff >= -maxSuggestionDistance && diff <= maxSuggestionDistance {
+				consider(id)
+			}
+		}
+	}
+}
+
+// quotedAlternatives joins names as `"a", "b" or "c"`
+func quotedAlternatives(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = strconv.Quote(name)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+// acceptSuggestion reports whether a class dist edits away is worth suggesting for name
+// The distance must stay below the name's length so "ab" doesn't suggest "x"
+func acceptSuggestion(name string, dist int) bool {
+	return dist > 0 && dist <= maxSuggestionDistance && dist < len(name)
+}
+
+// distinctTrigrams returns the unique 3-byte grams of s padded with a space on each side
+func distinctTrigrams(s string) []string {
+	padded := " " + s + " "
+	seen := make(map[string]bool, len(padded))
+	var grams []string
+	for i := 0; i+3 <= len(padded); i++ {
+		gram := padded[i : i+3]
+		if !seen[gram] {
+			seen[gram] = true
+			grams = append(grams, gram)
+		}
+	}
+	return grams
+}
+
+// boundedLevenshtein returns the edit distance between a and b, or limit once it
+// is certain the distance is at least limit
+func boundedLevenshtein(a, b string, limit int) int {
+	if diff := len(a) - len(b); diff >= limit || -diff >= limit {
+		return limit
+	}
+
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			rowMin = min(rowMin, curr[j])
+		}
+		if rowMin >= limit {
+			return limit
+		}
+		prev, curr = curr, prev
+	}
+
+	return min(prev[len(b)], limit)
+}
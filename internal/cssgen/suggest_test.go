@@ -0,0 +1,210 @@
+package cssgen
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// naiveNearest is the reference implementation: full Levenshtein against every class
+// classes must be sorted so ties resolve like the index
+func naiveNearest(classes []string, name string) string {
+	best, bestDist := "", maxSuggestionDistance+1
+	for _, class := range classes {
+		dist := levenshtein(name, class)
+		if acceptSuggestion(name, dist) && dist < bestDist {
+			best, bestDist = class, dist
+		}
+	}
+	return best
+}
+
+// levenshtein is the textbook full-matrix edit distance
+func levenshtein(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+		}
+	}
+	return d[len(a)][len(b)]
+}
+
+// syntheticClasses returns n BEM-style class names
+func syntheticClasses(n int, rng *rand.Rand) []string {
+	blocks := []string{"btn", "card", "nav", "modal", "table", "form", "input", "badge", "alert", "tab", "menu", "list"}
+	parts := []string{"header", "body", "footer", "item", "icon", "label", "title", "link", "group", "row"}
+	mods := []string{"primary", "secondary", "sm", "lg", "active", "disabled", "open", "dark", "ghost", "outline"}
+
+	seen := make(map[string]bool, n)
+	var classes []string
+	for len(classes) < n {
+		name := blocks[rng.Intn(len(blocks))]
+		if rng.Intn(2) == 0 {
+			name += "__" + parts[rng.Intn(len(parts))]
+		}
+		if rng.Intn(2) == 0 {
+			name += "--" + mods[rng.Intn(len(mods))]
+		}
+		name += fmt.Sprintf("-%d", rng.Intn(n))
+		if !seen[name] {
+			seen[name] = true
+			classes = append(classes, name)
+		}
+	}
+	return classes
+}
+
+// typo applies up to edits random single-byte edits to s
+func typo(s string, edits int, rng *rand.Rand) string {
+	const alphabet = "abcdefghijklmnopqrstuvwxyz-_0123456789"
+	b := []byte(s)
+	for i := 0; i < edits && len(b) > 0; i++ {
+		pos := rng.Intn(len(b))
+		switch rng.Intn(3) {
+		case 0:
+			b[pos] = alphabet[rng.Intn(len(alphabet))]
+		case 1:
+			b = append(b[:pos], b[pos+1:]...)
+		default:
+			b = append(b[:pos], append([]byte{alphabet[rng.Intn(len(alphabet))]}, b[pos:]...)...)
+		}
+	}
+	return string(b)
+}
+
+func TestClassIndexNearest(t *testing.T) {
+	idx := newClassIndex(map[string]bool{
+		"btn": true, "btn--primary": true, "btn--secondary": true, "card": true, "card__header": true,
+	})
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"btn--primray", "btn--primary"},
+		{"btn--primar", "btn--primary"},
+		{"crad", "card"},
+		{"card__heder", "card__header"},
+		{"bt", "btn"},
+		{"x", ""},
+		{"completely-different", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, idx.nearest(tt.name))
+		})
+	}
+
+	var empty *classIndex
+	assert.Equal(t, "", empty.nearest("btn"))
+}
+
+func TestClassIndexMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	classes := syntheticClasses(1000, rng)
+	sort.Strings(classes)
+
+	all := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		all[class] = true
+	}
+	idx := newClassIndex(all)
+
+	for i := 0; i < 200; i++ {
+		name := typo(classes[rng.Intn(len(classes))], 1+rng.Intn(3), rng)
+		if all[name] {
+			continue
+		}
+		require.Equal(t, naiveNearest(classes, name), idx.nearest(name), "typo %q", name)
+	}
+
+	// Short names take the length-scan path
+	for _, name := range []string{"a", "bt", "nav", "tab-", "cardd"} {
+		require.Equal(t, naiveNearest(classes, name), idx.nearest(name), "short name %q", name)
+	}
+}
+
+func TestLintSuggestsNearestClass(t *testing.T) {
+	lookup := buildLookupMaps(map[string]string{"BtnPrimary": "btn--primary"})
+	lookup.AllCSSClasses = map[string]bool{"btn--primary": true}
+	lookup.ClassIndex = newClassIndex(lookup.AllCSSClasses)
+
+	refs := extractClassesFromLine(`<button class="btn--primray"></button>`, 3, "page.templ")
+	result := analyzeUsage(lookup.AllConstants, refs, lookup, LintConfig{})
+
+	require.Len(t, result.Issues, 1)
+	issue := result.Issues[0]
+	assert.Equal(t, `invalid CSS class "btn--primray" not found in stylesheet (did you mean "btn--primary"?)`, issue.Text)
+	require.NotNil(t, issue.Replacement)
+	assert.Equal(t, "btn--primary", issue.Replacement.NewText)
+	assert.Equal(t, "btn--primray", issue.Replacement.OldText)
+}
+
+func TestLintSuggestLimit(t *testing.T) {
+	lookup := buildLookupMaps(map[string]string{"BtnOutlined": "btn--outlined", "BtnOutline2": "btn--outline-2"})
+	lookup.AllCSSClasses = map[string]bool{"btn--outlined": true, "btn--outline-2": true}
+	lookup.ClassIndex = newClassIndex(lookup.AllCSSClasses)
+
+	// One edit from both classes
+	refs := extractClassesFromLine(`<button class="btn--outline-"></button>`, 3, "page.templ")
+
+	result := analyzeUsage(lookup.AllConstants, refs, lookup, LintConfig{SuggestLimit: 2})
+	require.Len(t, result.Issues, 1)
+	issue := result.Issues[0]
+	assert.Equal(t, `invalid CSS class "btn--outline-" not found in stylesheet (did you mean "btn--outline-2" or "btn--outlined"?)`, issue.Text)
+	assert.Nil(t, issue.Replacement, "an ambiguous suggestion is not a fix")
+
+	// Default: the alphabetically first of the closest, offered as a fix
+	result = analyzeUsage(lookup.AllConstants, refs, lookup, LintConfig{})
+	require.Len(t, result.Issues, 1)
+	assert.Equal(t, `invalid CSS class "btn--outline-" not found in stylesheet (did you mean "btn--outline-2"?)`, result.Issues[0].Text)
+	require.NotNil(t, result.Issues[0].Replacement)
+
+	assert.Equal(t, `"a", "b" or "c"`, quotedAlternatives([]string{"a", "b", "c"}))
+}
+
+func BenchmarkClassIndexNearest(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	classes := syntheticClasses(5000, rng)
+	sort.Strings(classes)
+	all := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		all[class] = true
+	}
+
+	typos := make([]string, 100)
+	for i := range typos {
+		typos[i] = typo(classes[rng.Intn(len(classes))], 2, rng)
+	}
+
+	b.Run("index", func(b *testing.B) {
+		idx := newClassIndex(all)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			idx.nearest(typos[i%len(typos)])
+		}
+	})
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			naiveNearest(classes, typos[i%len(typos)])
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package cssgen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// assignTypedGroups gives every class of a BEM family (a base class plus its
+// modifiers and elements) a shared named string type, e.g. BtnClass for .btn
+// Families are rooted at the outermost public ancestor; lone classes stay untyped
+func assignTypedGroups(classes []*CSSClass) {
+	public := make(map[*CSSClass]bool, len(classes))
+	goNames := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		public[class] = true
+		goNames[class.GoName] = true
+	}
+
+	root := func(class *CSSClass) *CSSClass {
+		for class.ParentClass != nil && public[class.ParentClass] {
+			class = class.ParentClass
+		}
+		return class
+	}
+
+	families := make(map[*CSSClass][]*CSSClass)
+	for _, class := range classes {
+		if r := root(class); r != class {
+			families[r] = append(families[r], class)
+		}
+	}
+
+	for base, members := range families {
+		typeName := base.GoName + "Class"
+		if goNames[typeName] {
+			typeName += "Type" // A constant already has the name
+		}
+		base.TypeName = typeName
+		for _, member := range members {
+			member.TypeName = typeName
+		}
+	}
+}
+
+// generateTypedGroupTypes declares the named types assigned by assignTypedGroups
+// Each type implements templ.CSSClass, so its constants work in class={ ... }
+func generateTypedGroupTypes(classes []*CSSClass) string {
+	bases := make(map[string]string) // TypeName -> base class
+	for _, class := range classes {
+		// The base is the family member whose parent is outside the family
+		if class.TypeName != "" && (class.ParentClass == nil || class.ParentClass.TypeName != class.TypeName) {
+			bases[class.TypeName] = class.Name
+		}
+	}
+	if len(bases) == 0 {
+		return ""
+	}
+
+	typeNames := make([]string, 0, len(bases))
+	for typeName := range bases {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	var buf strings.Builder
+	for _, typeName := range typeNames {
+		fmt.Fprintf(&buf, "// %s groups .%s with its modifiers and elements.\n", typeName, bases[typeName])
+		fmt.Fprintf(&buf, "type %s string\n\n", typeName)
+		fmt.Fprintf(&buf, "// ClassName returns the class name, so %s implements templ.CSSClass.\n", typeName)
+		fmt.Fprintf(&buf, "func (c %s) ClassName() string {\n\treturn string(c)\n}\n\n", typeName)
+	}
+	return buf.String()
+}
@@ -0,0 +1,63 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateTypedGroups(t *testing.T) {
+	tmpDir := t.TempDir()
+	css := `@layer base {
+		.btn { color: red; }
+		.btn--primary { background: blue; }
+		.btn__icon { width: 1rem; }
+		.card { padding: 1rem; }
+	}`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app.css"), []byte(css), 0644))
+
+	_, err := Generate(Config{
+		SourceDir:       tmpDir,
+		OutputDir:       tmpDir,
+		PackageName:     "ui",
+		Includes:        []string{"*.css"},
+		Format:          "compact",
+		PropertyLimit:   5,
+		EmitTypedGroups: true,
+	})
+	require.NoError(t, err)
+
+	generatedFile := filepath.Join(tmpDir, "styles.gen.go")
+	data, err := os.ReadFile(generatedFile)
+	require.NoError(t, err)
+	out := string(data)
+
+	assert.Contains(t, out, "type BtnClass string\n")
+	assert.Contains(t, out, "func (c BtnClass) ClassName() string {")
+	assert.Contains(t, out, `const Btn BtnClass = "btn"`)
+	assert.Contains(t, out, `const BtnPrimary BtnClass = "btn--primary"`)
+	assert.Contains(t, out, `const BtnIcon BtnClass = "btn__icon"`)
+	assert.Contains(t, out, `const Card = "card"`)
+	assert.Equal(t, 1, strings.Count(out, "type "), "lone classes get no type")
+
+	// The linter still reads typed constants
+	constants, _, err := ParseGeneratedFile(generatedFile)
+	require.NoError(t, err)
+	assert.Equal(t, "btn--primary", constants["BtnPrimary"])
+	assert.Equal(t, "card", constants["Card"])
+}
+
+func TestAssignTypedGroupsNameCollision(t *testing.T) {
+	btn := &CSSClass{Name: "btn", GoName: "Btn"}
+	primary := &CSSClass{Name: "btn--primary", GoName: "BtnPrimary", ParentClass: btn}
+	clash := &CSSClass{Name: "btn-class", GoName: "BtnClass"}
+
+	assignTypedGroups([]*CSSClass{btn, primary, clash})
+	assert.Equal(t, "BtnClassType", btn.TypeName)
+	assert.Equal(t, "BtnClassType", primary.TypeName)
+	assert.Equal(t, "", clash.TypeName)
+}
@@ -44,11 +44,31 @@ type CSSClass struct {
 	ParentClass           *CSSClass               // Link to base class (for comments/context only)
 	PseudoStates          []string                // [":hover", ":focus"] - included in comments
 	PseudoStateProperties []PseudoStateProperties // Property changes in pseudo-states
+	PseudoElements        []string                // ["::before", "::after"] - styled generated boxes
 	PropertyDiff          *PropertyDiff           // Diff vs. parent class
 	Intent                string                  // Human intent from @intent comment
 	IsUtility             bool                    // True if atomic utility class (no BEM)
 	IsInternal            bool                    // True if starts with _ (skip public const)
 	SourceFile            string                  // For debugging/conflict resolution
+	SourceLine            int                     // 1-based line of the first rule for the class (0 = unknown)
+	ContainerStates       []string                // @container conditions the class is styled under
+	MediaStates           []string                // @media conditions the class is styled under
+	OutsideMedia          bool                    // Had at least one rule outside any @media block
+	UsedAlone             bool                    // Appeared as a standalone selector at least once
+	CompoundWith          []string                // Classes it was combined with (.a.b)
+	ConflictingLayers     []string                // Other layers a duplicate definition was in (Layer is kept)
+	TypeName              string                  // Named string type of the constant, e.g. "BtnClass" ("" = untyped)
+	IgnoreDirective       string                  // cssgen:ignore comment above the rule, copied onto the constant
+}
+
+// CompoundOnly reports whether the class only ever appeared combined with other classes
+func (c *CSSClass) CompoundOnly() bool {
+	return !c.UsedAlone && len(c.CompoundWith) > 0
+}
+
+// MediaOnly reports whether the class is only styled inside @media blocks
+func (c *CSSClass) MediaOnly() bool {
+	return !c.OutsideMedia && len(c.MediaStates) > 0
 }
 
 // Layer represents a CSS cascade layer with priority
@@ -66,21 +86,67 @@ type Config struct {
 	Includes           []string // ["layers/components/**/*.css", "layers/utilities.css"]
 	Verbose            bool     // Enable debug logging
 	LayerInferFromPath bool     // Infer layer from file path (default: true)
+	DefaultLayer       string   // Layer for classes whose path names no layer (default: "base")
 	Format             string   // Output format: "markdown", "compact" (default: "markdown")
 	PropertyLimit      int      // Max properties to show per category (default: 5)
+	CommentWidth       int      // Max width of the compact properties comment (default: 120)
+	WrapComments       bool     // Wrap long compact properties onto continuation lines instead of truncating
 	ShowInternal       bool     // Show -webkit-* properties (default: false)
+	InternalPrefixes   []string // Property prefixes categorized as Internal (nil = -webkit-, -moz-, -ms-, -o-)
+	UnprefixVendor     bool     // Categorize known prefixed properties by their unprefixed name, e.g. -webkit-transform as Effects
 	ExtractIntent      bool     // Parse @intent comments (default: true)
+	InheritIntent      bool     // Modifiers without @intent show their base class's intent (default: false)
+	ExtractIDs         bool     // Generate ID constants in styles_ids.gen.go (default: false)
+	BuildTags          []string // Build constraints for generated files, joined with && (e.g. ["!prod"])
+	ManifestFile       string   // Write the BEM base/modifier/element tree as JSON here ("" = off)
+	DocsFile           string   // Write a Markdown class catalog here ("" = off)
+	IntentsFile        string   // Write documented classes by layer here, JSON for .json else Markdown ("" = off)
+	EmitTypedGroups    bool     // Give each BEM family a named string type, e.g. BtnPrimary BtnClass (default: false)
+	UsageFile          string   // Coverage JSON report; constants are only emitted for classes used there ("" = off)
+	MinUsage           int      // Minimum references in UsageFile to keep a constant (default: 1)
+	LineEnding         string   // Line endings of generated files: "lf", "crlf", "auto" (default: "lf")
+	ModifierSeparator  string   // Marks modifiers for parent linking, e.g. "_" for btn_primary (default: "--")
+	ElementSeparator   string   // Marks elements for parent linking, e.g. "-el-" (default: "__")
+
+	// HonorSourceComments attributes classes in a bundle to the file named by the
+	// nearest preceding /* src: button.css */ comment (default: false)
+	HonorSourceComments bool
+
+	// DetectLayerOverrides warns when classes in different declared layers set the same property
+	DetectLayerOverrides bool
+
+	// FailOnWarning makes Generate return a *WarningsError instead of writing files
+	// when any warning was produced (default: false)
+	FailOnWarning bool
+
+	// CategoryOverrides maps property names to categories, consulted before the built-in table
+	CategoryOverrides map[string]PropertyCategory // {"scroll-snap-type": CategoryLayout}
 }
 
 // GenerateResult contains generation stats
 type GenerateResult struct {
 	ClassesGenerated int
+	IDsGenerated     int // Number of ID constants (only with ExtractIDs)
+	ClassesPruned    int // Public classes left out by UsageFile (still in AllCSSClasses)
 	FilesScanned     int
-	IntentsExtracted int // Number of @intent comments extracted
-	Warnings         []string
+	IntentsExtracted int              // Number of @intent comments extracted
+	IntentCoverage   float64          // Generated classes with their own @intent / classes generated
+	LayerOrder       []string         // Declared @layer order, e.g. ["base", "components", "utilities"]
+	Changes          *GenerateChanges // Classes added/removed/renamed since the previous generation (nil = first run)
+	Warnings         GenerateWarnings
 	Errors           []error
 }
 
+// GenerateWarning is a non-fatal generation problem, located where possible
+type GenerateWarning struct {
+	Message string
+	File    string // "" when the warning spans several files
+	Line    int    // 1-based, 0 = unknown
+}
+
+// GenerateWarnings is the list of warnings from one Generate run
+type GenerateWarnings []GenerateWarning
+
 // OutputFormat represents the linter output format
 type OutputFormat string
 
@@ -93,6 +159,24 @@ const (
 	OutputFull OutputFormat = "full"
 	// OutputJSON exports structured data in JSON format (tooling integration)
 	OutputJSON OutputFormat = "json"
+	// OutputNDJSON writes one JSON object per issue and line (log processors)
+	OutputNDJSON OutputFormat = "ndjson"
+	// OutputCSV writes one row per issue (spreadsheet triage)
+	OutputCSV OutputFormat = "csv"
 	// OutputMarkdown generates a Markdown report (shareable reports)
 	OutputMarkdown OutputFormat = "markdown"
 )
+
+// GroupBy selects how issues are grouped under headers in issues output
+type GroupBy string
+
+const (
+	// GroupByNone prints issues sorted by file, line and column
+	GroupByNone GroupBy = ""
+	// GroupBySeverity prints errors first, then warnings
+	GroupBySeverity GroupBy = "severity"
+	// GroupByType groups by issue type (invalid, hardcoded, unused)
+	GroupByType GroupBy = "type"
+	// GroupByFile groups by source file
+	GroupByFile GroupBy = "file"
+)
@@ -0,0 +1,123 @@
+package cssgen
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// generatedAtPattern matches the header timestamp, which differs on every run
+var generatedAtPattern = regexp.MustCompile(`(?m)^// Generated: .*$`)
+
+// VerifyResult lists generated files whose committed content is out of date
+type VerifyResult struct {
+	Stale []StaleFile
+}
+
+// StaleFile is a generated file that differs from a fresh generation
+type StaleFile struct {
+	File string // Path in the output directory
+	Diff string // Unified diff from the committed file to the regenerated one
+}
+
+// UpToDate reports whether every generated file matches
+func (r *VerifyResult) UpToDate() bool {
+	return len(r.Stale) == 0
+}
+
+// Verify regenerates into a temporary directory and compares the styles*.gen.go
+// files with those in config.OutputDir, ignoring the "// Generated:" timestamp
+func Verify(config Config) (*VerifyResult, error) {
+	tmpDir, err := os.MkdirTemp("", "cssgen-verify-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Side outputs aren't styles*.gen.go files; don't write them outside tmpDir
+	fresh := withoutSideOutputs(config)
+	fresh.OutputDir = tmpDir
+	if _, err := Generate(fresh); err != nil {
+		return nil, err
+	}
+
+	names, err := generatedFileNames(config.OutputDir, tmpDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{}
+	for _, name := range names {
+		committedPath := filepath.Join(config.OutputDir, name)
+		committed, err := readGenerated(committedPath)
+		if err != nil {
+			return nil, err
+		}
+		regenerated, err := readGenerated(filepath.Join(tmpDir, name))
+		if err != nil {
+			return nil, err
+		}
+		if committed == regenerated {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(committed),
+			B:        difflib.SplitLines(regenerated),
+			FromFile: committedPath + " (committed)",
+			ToFile:   committedPath + " (regenerated)",
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("diffing %s: %w", name, err)
+		}
+		result.Stale = append(result.Stale, StaleFile{File: committedPath, Diff: diff})
+	}
+
+	return result, nil
+}
+
+// generatedFileNames returns the sorted union of styles*.gen.go names in both directories
+func generatedFileNames(dirs ...string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, dir := range dirs {
+		matches, err := filepath.Glob(filepath.Join(dir, "styles*.gen.go"))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			seen[filepath.Base(match)] = true
+		}
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readGenerated reads a generated file without its timestamp ("" when missing)
+func readGenerated(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", path, err)
+	}
+	return generatedAtPattern.ReplaceAllString(string(data), "// Generated: <timestamp>"), nil
+}
+
+// PrintVerifyResult writes the diff of each stale file
+func PrintVerifyResult(w io.Writer, result *VerifyResult) {
+	for _, stale := range result.Stale {
+		fmt.Fprint(w, stale.Diff)
+	}
+}
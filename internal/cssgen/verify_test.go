@@ -0,0 +1,63 @@
+package cssgen
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	srcDir := t.TempDir()
+	outDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "buttons.css"), []byte(`.btn { color: red; }`), 0644))
+
+	config := Config{
+		SourceDir:     srcDir,
+		OutputDir:     outDir,
+		PackageName:   "ui",
+		Includes:      []string{"*.css"},
+		Format:        "markdown",
+		PropertyLimit: 5,
+	}
+	_, err := Generate(config)
+	require.NoError(t, err)
+
+	// Freshly generated files match (the timestamp is ignored)
+	result, err := Verify(config)
+	require.NoError(t, err)
+	assert.True(t, result.UpToDate())
+
+	// A CSS change makes the committed files stale
+	require.NoError(t, os.WriteFile(filepath.Join(srcDir, "buttons.css"), []byte(".btn { color: red; }\n.btn--ghost { color: gray; }"), 0644))
+	result, err = Verify(config)
+	require.NoError(t, err)
+	require.False(t, result.UpToDate())
+
+	stale := make(map[string]string)
+	for _, file := range result.Stale {
+		stale[filepath.Base(file.File)] = file.Diff
+	}
+	require.Contains(t, stale, "styles_buttons.gen.go")
+	assert.Contains(t, stale["styles_buttons.gen.go"], `+const BtnGhost = "btn--ghost"`)
+
+	// Verify never writes into the output directory
+	_, err = os.ReadFile(filepath.Join(outDir, "styles_buttons.gen.go"))
+	require.NoError(t, err)
+	committed, _, err := ParseGeneratedFile(filepath.Join(outDir, "styles.gen.go"))
+	require.NoError(t, err)
+	assert.NotContains(t, committed, "BtnGhost")
+
+	// Nor any side output (manifest, docs, intents)
+	sideDir := t.TempDir()
+	config.ManifestFile = filepath.Join(sideDir, "manifest.json")
+	config.DocsFile = filepath.Join(sideDir, "docs.md")
+	config.IntentsFile = filepath.Join(sideDir, "intents.md")
+	_, err = Verify(config)
+	require.NoError(t, err)
+	entries, err := os.ReadDir(sideDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -1,21 +1,28 @@
 package cssgen
 
 import (
+	"bytes"
 	"fmt"
+	"go/build/constraint"
+	"go/format"
 	"os"
 	"path/filepath"
+	"runtime"
 	"sort"
 	"strings"
 	"time"
 )
 
+// idsFileName is the generated file holding ID selector constants
+const idsFileName = "styles_ids.gen.go"
+
 // WriteGoFile generates multiple output .go files split by component
 func WriteGoFile(publicClasses []*CSSClass, allClasses []*CSSClass, config Config, stats GenerateResult) error {
 	return WriteGoFiles(publicClasses, allClasses, config, stats)
 }
 
-// generateAllCSSClassesMap creates a map of all CSS classes found in source files
-func generateAllCSSClassesMap(classes []*CSSClass) string {
+// sortedClassNames returns the unique class names, sorted for deterministic output
+func sortedClassNames(classes []*CSSClass) []string {
 	// Collect all unique class names (1:1 mapping - just the class name)
 	allClassNames := make(map[string]bool)
 
@@ -24,13 +31,19 @@ func generateAllCSSClassesMap(classes []*CSSClass) string {
 		allClassNames[class.Name] = true
 	}
 
-	// Sort for deterministic output
 	var sortedClasses []string
 	for className := range allClassNames {
 		sortedClasses = append(sortedClasses, className)
 	}
 	sort.Strings(sortedClasses)
 
+	return sortedClasses
+}
+
+// generateAllCSSClassesMap creates a map of all CSS classes found in source files
+func generateAllCSSClassesMap(classes []*CSSClass) string {
+	sortedClasses := sortedClassNames(classes)
+
 	// Build the map declaration
 	var buf strings.Builder
 	buf.WriteString("// AllCSSClasses is a map of all CSS classes found in the source files.\n")
@@ -47,6 +60,182 @@ func generateAllCSSClassesMap(classes []*CSSClass) string {
 	return buf.String()
 }
 
+// generateAllCSSClassList emits the AllCSSClasses keys as a sorted slice,
+// for consumers that need a stable iteration order
+func generateAllCSSClassList(classes []*CSSClass) string {
+	var buf strings.Builder
+	buf.WriteString("// AllCSSClassList holds the keys of AllCSSClasses in sorted order.\n")
+	buf.WriteString("// Use it where iteration order matters, e.g. listing classes in docs or tests.\n")
+	buf.WriteString("var AllCSSClassList = []string{\n")
+
+	for _, className := range sortedClassNames(classes) {
+		fmt.Fprintf(&buf, "\t%q,\n", className)
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// generateCSSClassPropertiesMap emits the properties each class sets
+// The linter reads it to detect redundant class combinations
+func generateCSSClassPropertiesMap(classes []*CSSClass) string {
+	props := make(map[string]map[string]string)
+	for _, class := range classes {
+		if len(class.Properties) > 0 {
+			props[class.Name] = class.Properties
+		}
+	}
+
+	classNames := make([]string, 0, len(props))
+	for className := range props {
+		classNames = append(classNames, className)
+	}
+	sort.Strings(classNames)
+
+	var buf strings.Builder
+	buf.WriteString("// CSSClassProperties maps each CSS class to the properties it sets.\n")
+	buf.WriteString("// This is used by the linter to detect redundant class combinations.\n")
+	buf.WriteString("var CSSClassProperties = map[string]map[string]string{\n")
+
+	for _, className := range classNames {
+		propNames := make([]string, 0, len(props[className]))
+		for name := range props[className] {
+			propNames = append(propNames, name)
+		}
+		sort.Strings(propNames)
+
+		fmt.Fprintf(&buf, "\t%q: {\n", className)
+		for _, name := range propNames {
+			fmt.Fprintf(&buf, "\t\t%q: %q,\n", name, props[className][name])
+		}
+		buf.WriteString("\t},\n")
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// generateCSSClassSourcesMap emits where each class is defined, as file:line
+// The linter reads it to point "did you mean" hints at the real class
+func generateCSSClassSourcesMap(classes []*CSSClass, config Config) string {
+	sources := make(map[string]string)
+	for _, class := range classes {
+		if class.SourceFile != "" {
+			if _, ok := sources[class.Name]; !ok {
+				sources[class.Name] = classSourceLocation(class, config)
+			}
+		}
+	}
+
+	classNames := make([]string, 0, len(sources))
+	for className := range sources {
+		classNames = append(classNames, className)
+	}
+	sort.Strings(classNames)
+
+	var buf strings.Builder
+	buf.WriteString("// CSSClassSources maps each CSS class to the file:line defining it.\n")
+	buf.WriteString("// This is used by the linter to locate suggested classes.\n")
+	buf.WriteString("var CSSClassSources = map[string]string{\n")
+
+	for _, className := range classNames {
+		fmt.Fprintf(&buf, "\t%q: %q,\n", className, sources[className])
+	}
+
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// classSourceLocation renders class's definition as a slash-separated file:line,
+// relative to the working directory (where cssgen runs from the project root)
+// however SourceDir is spelled, so generated files don't depend on where the
+// repository is checked out. Files outside the working directory fall back to
+// a path relative to SourceDir
+func classSourceLocation(class *CSSClass, config Config) string {
+	file := filepath.Clean(class.SourceFile)
+	if filepath.IsAbs(file) {
+		if rel, ok := relativeToWorkingDir(file); ok {
+			file = rel
+		} else if rel, err := filepath.Rel(config.SourceDir, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	file = filepath.ToSlash(file)
+	if class.SourceLine > 0 {
+		return fmt.Sprintf("%s:%d", file, class.SourceLine)
+	}
+	return file
+}
+
+// relativeToWorkingDir returns path relative to the working directory when it lies inside it
+func relativeToWorkingDir(path string) (string, bool) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(wd, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
+
+// generateIsValidClassFunc emits a runtime lookup against AllCSSClasses
+func generateIsValidClassFunc() string {
+	var buf strings.Builder
+	buf.WriteString("// IsValidClass reports whether s is a CSS class found in the source files.\n")
+	buf.WriteString("// Use it to validate user-supplied class names at runtime.\n")
+	buf.WriteString("func IsValidClass(s string) bool {\n")
+	buf.WriteString("\treturn AllCSSClasses[s]\n")
+	buf.WriteString("}\n")
+
+	return buf.String()
+}
+
+// buildConstraint joins BuildTags into a validated //go:build line ("" when no tags)
+func buildConstraint(tags []string) (string, error) {
+	if len(tags) == 0 {
+		return "", nil
+	}
+
+	parts := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if _, err := constraint.Parse("//go:build " + tag); err != nil {
+			return "", fmt.Errorf("invalid build tag %q: %w", tag, err)
+		}
+		if len(tags) > 1 && strings.ContainsAny(tag, "&| ") {
+			tag = "(" + tag + ")"
+		}
+		parts = append(parts, tag)
+	}
+	if len(parts) == 0 {
+		return "", nil
+	}
+
+	line := "//go:build " + strings.Join(parts, " && ")
+	if _, err := constraint.Parse(line); err != nil {
+		return "", fmt.Errorf("invalid build constraint %q: %w", line, err)
+	}
+	return line, nil
+}
+
+// formatBuildConstraint returns the //go:build line plus the required blank line
+// Tags are validated up front in WriteGoFiles, so errors are not expected here
+func formatBuildConstraint(config Config) string {
+	line, err := buildConstraint(config.BuildTags)
+	if err != nil || line == "" {
+		return ""
+	}
+	return line + "\n\n"
+}
+
 // formatFileHeader generates package declaration and metadata
 func formatFileHeader(config Config, stats GenerateResult) string {
 	var lines []string
@@ -70,16 +259,43 @@ func formatConstant(class *CSSClass, config Config) string {
 
 	switch config.Format {
 	case "compact":
-		comment = formatCommentCompact(class)
+		comment = formatCommentCompact(class, config)
 	case "markdown":
 		fallthrough
 	default:
 		comment = formatCommentMarkdown(class, config)
 	}
 
+	// Applying a compound-only class alone matches nothing
+	if class.CompoundOnly() {
+		partners := make([]string, len(class.CompoundWith))
+		for i, partner := range class.CompoundWith {
+			partners[i] = "." + partner
+		}
+		sort.Strings(partners)
+		comment += "\n// only meaningful combined with: " + strings.Join(partners, ", ")
+	}
+
+	// A media-only class has no effect outside its media, e.g. print-only helpers
+	if class.MediaOnly() {
+		conditions := make([]string, len(class.MediaStates))
+		for i, condition := range class.MediaStates {
+			conditions[i] = "@media " + condition
+		}
+		comment += "\n// only under " + strings.Join(conditions, ", ")
+	}
+
+	// Lint reads the directive from the declaration, as unused-constant is reported there
+	if class.IgnoreDirective != "" {
+		comment += "\n// " + class.IgnoreDirective
+	}
+
 	// Pure 1:1 mapping: always use class.Name
 	value := class.Name
 
+	if class.TypeName != "" {
+		return fmt.Sprintf("%s\nconst %s %s = %q\n", comment, class.GoName, class.TypeName, value)
+	}
 	return fmt.Sprintf("%s\nconst %s = %q\n", comment, class.GoName, value)
 }
 
@@ -88,7 +304,7 @@ func formatCommentMarkdown(class *CSSClass, config Config) string {
 	var lines []string
 
 	// Layer badge
-	if class.Layer != "" && class.Layer != "n/a" {
+	if class.Layer != "" && class.Layer != unknownLayer {
 		lines = append(lines, fmt.Sprintf("// @layer %s", class.Layer))
 		lines = append(lines, "//")
 	}
@@ -99,9 +315,11 @@ func formatCommentMarkdown(class *CSSClass, config Config) string {
 		lines = append(lines, fmt.Sprintf("// **Context:** Use with .%s for proper styling", class.ParentClass.Name))
 	}
 
-	// Intent (if available)
+	// Intent (if available), optionally falling back to the base class's intent
 	if class.Intent != "" {
 		lines = append(lines, fmt.Sprintf("// **Intent:** %s", class.Intent))
+	} else if config.InheritIntent && class.ParentClass != nil && class.ParentClass.Intent != "" {
+		lines = append(lines, fmt.Sprintf("// **Intent:** (inherited) %s", class.ParentClass.Intent))
 	}
 
 	// Property diff (for modifiers)
@@ -132,7 +350,7 @@ func formatCommentMarkdown(class *CSSClass, config Config) string {
 
 	// Categorized properties
 	if len(class.Properties) > 0 {
-		categorized := categorizeProperties(class.Properties)
+		categorized := categorizeProperties(class.Properties, config)
 		lines = append(lines, formatCategorizedProperties(categorized, config)...)
 	}
 
@@ -162,15 +380,34 @@ func formatCommentMarkdown(class *CSSClass, config Config) string {
 		}
 	}
 
+	// Pseudo-elements
+	if len(class.PseudoElements) > 0 {
+		elements := make([]string, len(class.PseudoElements))
+		for i, pe := range class.PseudoElements {
+			elements[i] = "`" + pe + "`"
+		}
+		lines = append(lines, "//")
+		lines = append(lines, "// **Pseudo-elements:** "+strings.Join(elements, ", "))
+	}
+
+	// Container queries
+	if len(class.ContainerStates) > 0 {
+		lines = append(lines, "//")
+		lines = append(lines, "// **Container:**")
+		for _, cs := range class.ContainerStates {
+			lines = append(lines, fmt.Sprintf("// - `@container %s`", cs))
+		}
+	}
+
 	return strings.Join(lines, "\n")
 }
 
-// formatCommentCompact generates single-line compact comment
-func formatCommentCompact(class *CSSClass) string {
+// formatCommentCompact generates a compact comment, one line unless properties wrap
+func formatCommentCompact(class *CSSClass, config Config) string {
 	parts := []string{}
 
 	// Layer
-	if class.Layer != "" && class.Layer != "n/a" {
+	if class.Layer != "" && class.Layer != unknownLayer {
 		parts = append(parts, fmt.Sprintf("@layer %s", class.Layer))
 	}
 
@@ -179,13 +416,19 @@ func formatCommentCompact(class *CSSClass) string {
 		parts = append(parts, fmt.Sprintf("Base: .%s", class.ParentClass.Name))
 	}
 
-	// Properties (truncated)
+	// Properties (truncated or wrapped at CommentWidth)
+	var continued []string
 	if len(class.Properties) > 0 {
-		props := cleanProperties(class.Properties)
-		parts = append(parts, props)
+		props := cleanProperties(class.Properties, config.CommentWidth, config.WrapComments)
+		parts = append(parts, props[0])
+		continued = props[1:]
 	}
 
-	return "// " + strings.Join(parts, " | ")
+	lines := []string{"// " + strings.Join(parts, " | ")}
+	for _, line := range continued {
+		lines = append(lines, "//   "+line)
+	}
+	return strings.Join(lines, "\n")
 }
 
 // formatCategorizedProperties formats properties grouped by category
@@ -269,13 +512,18 @@ func formatCategorizedProperties(
 
 // WriteGoFiles generates multiple output .go files split by component
 func WriteGoFiles(publicClasses []*CSSClass, allClasses []*CSSClass, config Config, stats GenerateResult) error {
+	// Reject malformed build tags before touching the output directory
+	if _, err := buildConstraint(config.BuildTags); err != nil {
+		return err
+	}
+
 	// Clean up old generated files before writing new ones
 	if err := cleanupOldGeneratedFiles(config.OutputDir); err != nil {
 		return fmt.Errorf("cleanup failed: %w", err)
 	}
 
 	// Group classes by component
-	grouped := groupClassesByComponent(publicClasses, config)
+	grouped := groupClassesByComponent(publicClasses, config, stats.LayerOrder)
 
 	// Collect component names for table of contents
 	var componentNames []string
@@ -322,7 +570,8 @@ func cleanupOldGeneratedFiles(dir string) error {
 }
 
 // groupClassesByComponent groups classes by their source component
-func groupClassesByComponent(classes []*CSSClass, config Config) map[string][]*CSSClass {
+// When a layer order is declared, classes are sorted by layer rank first
+func groupClassesByComponent(classes []*CSSClass, config Config, layerOrder []string) map[string][]*CSSClass {
 	grouped := make(map[string][]*CSSClass)
 
 	for _, class := range classes {
@@ -330,9 +579,24 @@ func groupClassesByComponent(classes []*CSSClass, config Config) map[string][]*C
 		grouped[component] = append(grouped[component], class)
 	}
 
-	// Sort classes within each group alphabetically
+	// Sort classes within each group by layer rank, then alphabetically
+	rank := make(map[string]int, len(layerOrder))
+	for i, layer := range layerOrder {
+		rank[layer] = i
+	}
+	layerRank := func(layer string) int {
+		if r, ok := rank[layer]; ok {
+			return r
+		}
+		return len(layerOrder) // Undeclared layers sort last
+	}
+
 	for _, classes := range grouped {
 		sort.Slice(classes, func(i, j int) bool {
+			ri, rj := layerRank(classes[i].Layer), layerRank(classes[j].Layer)
+			if ri != rj {
+				return ri < rj
+			}
 			return classes[i].GoName < classes[j].GoName
 		})
 	}
@@ -375,6 +639,7 @@ func writeBaseFile(filename string, allClasses []*CSSClass, baseClasses []*CSSCl
 	var buf strings.Builder
 
 	// File header
+	buf.WriteString(formatBuildConstraint(config))
 	buf.WriteString(formatFileHeader(config, stats))
 	buf.WriteString("\n\n")
 
@@ -396,6 +661,15 @@ func writeBaseFile(filename string, allClasses []*CSSClass, baseClasses []*CSSCl
 	// AllCSSClasses map
 	buf.WriteString(generateAllCSSClassesMap(allClasses))
 	buf.WriteString("\n")
+	buf.WriteString(generateAllCSSClassList(allClasses))
+	buf.WriteString("\n")
+	buf.WriteString(generateIsValidClassFunc())
+	buf.WriteString("\n")
+	buf.WriteString(generateCSSClassPropertiesMap(allClasses))
+	buf.WriteString("\n")
+	buf.WriteString(generateCSSClassSourcesMap(allClasses, config))
+	buf.WriteString("\n")
+	buf.WriteString(generateTypedGroupTypes(allClasses))
 
 	// Base/utility constants
 	for _, class := range baseClasses {
@@ -403,8 +677,7 @@ func writeBaseFile(filename string, allClasses []*CSSClass, baseClasses []*CSSCl
 		buf.WriteString("\n")
 	}
 
-	// #nosec G306 - generated file should be readable by all
-	return os.WriteFile(filename, []byte(buf.String()), 0644)
+	return writeFormattedGoFile(filename, buf.String(), config.LineEnding)
 }
 
 // writeComponentFile writes a component-specific file (e.g., styles_buttons.gen.go)
@@ -412,6 +685,7 @@ func writeComponentFile(filename string, classes []*CSSClass, component string,
 	var buf strings.Builder
 
 	// File header
+	buf.WriteString(formatBuildConstraint(config))
 	buf.WriteString(formatComponentFileHeader(component))
 	buf.WriteString("\n\n")
 
@@ -434,8 +708,80 @@ func writeComponentFile(filename string, classes []*CSSClass, component string,
 		buf.WriteString("\n")
 	}
 
+	return writeFormattedGoFile(filename, buf.String(), config.LineEnding)
+}
+
+// writeIDsFile writes ID selector constants (e.g., const IDMainNav = "main-nav")
+// IDs live in their own file so the linter never treats them as CSS classes
+func writeIDsFile(filename string, ids []*CSSClass, config Config) error {
+	var buf strings.Builder
+
+	// File header
+	buf.WriteString(formatBuildConstraint(config))
+	buf.WriteString(formatComponentFileHeader("ids"))
+	buf.WriteString("\n\n")
+
+	// Package declaration
+	fmt.Fprintf(&buf, "package %s\n\n", config.PackageName)
+
+	buf.WriteString("// ID selector constants\n")
+	buf.WriteString("//\n")
+	buf.WriteString("// These are element IDs, not CSS classes. Use them in id attributes.\n")
+	buf.WriteString("\n")
+
+	// Constants
+	for _, id := range ids {
+		buf.WriteString(formatConstant(id, config))
+		buf.WriteString("\n")
+	}
+
+	return writeFormattedGoFile(filename, buf.String(), config.LineEnding)
+}
+
+// writeFormattedGoFile gofmts src and writes it, so generated files pass gofmt -l checks
+// A formatting failure means the generator emitted invalid Go
+func writeFormattedGoFile(filename string, src string, lineEnding string) error {
+	formatted, err := format.Source([]byte(src))
+	if err != nil {
+		return fmt.Errorf("generated %s is not valid Go (generator bug): %w", filepath.Base(filename), err)
+	}
+
+	// gofmt always emits LF, so convert afterwards
+	if resolveLineEnding(lineEnding) == LineEndingCRLF {
+		formatted = bytes.ReplaceAll(formatted, []byte("\n"), []byte("\r\n"))
+	}
+
 	// #nosec G306 - generated file should be readable by all
-	return os.WriteFile(filename, []byte(buf.String()), 0644)
+	return os.WriteFile(filename, formatted, 0644)
+}
+
+// Line endings for generated files (Config.LineEnding)
+const (
+	LineEndingLF   = "lf"
+	LineEndingCRLF = "crlf"
+	LineEndingAuto = "auto" // CRLF on Windows, LF elsewhere
+)
+
+// validateLineEnding rejects line endings other than lf, crlf, and auto ("" = lf)
+func validateLineEnding(lineEnding string) error {
+	switch lineEnding {
+	case "", LineEndingLF, LineEndingCRLF, LineEndingAuto:
+		return nil
+	}
+	return fmt.Errorf("invalid line ending %q (want lf, crlf or auto)", lineEnding)
+}
+
+// resolveLineEnding maps auto to the platform convention and "" to lf
+func resolveLineEnding(lineEnding string) string {
+	switch lineEnding {
+	case LineEndingCRLF:
+		return LineEndingCRLF
+	case LineEndingAuto:
+		if runtime.GOOS == "windows" {
+			return LineEndingCRLF
+		}
+	}
+	return LineEndingLF
 }
 
 // formatComponentFileHeader generates header for component files